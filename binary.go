@@ -0,0 +1,41 @@
+package null
+
+import "fmt"
+
+// The binary encoding used by MarshalBinary starts with a version byte and a
+// flags byte, followed by a type-specific payload that is only present for
+// valid values. The one exception is Time's custom layout, flagged by
+// binaryFlagLayout, which comes before the payload and is written for null
+// values too, so that a null Time keeps it. Bumping binaryVersion lets
+// UnmarshalBinary reject data written in a format it does not understand
+// instead of misreading it.
+const (
+	binaryVersion byte = 1
+
+	binaryFlagValid  byte = 1 << 0
+	binaryFlagSet    byte = 1 << 1
+	binaryFlagLayout byte = 1 << 2
+)
+
+func binaryHeader(valid, set bool) []byte {
+	var flags byte
+	if valid {
+		flags |= binaryFlagValid
+	}
+	if set {
+		flags |= binaryFlagSet
+	}
+	return []byte{binaryVersion, flags}
+}
+
+// readBinaryHeader checks the version byte of data written by binaryHeader
+// and returns the flags byte and the payload that follows it.
+func readBinaryHeader(data []byte, typ string) (byte, []byte, error) {
+	if len(data) < 2 {
+		return 0, nil, fmt.Errorf("null: %s.UnmarshalBinary: data too short", typ)
+	}
+	if data[0] != binaryVersion {
+		return 0, nil, fmt.Errorf("null: %s.UnmarshalBinary: unsupported version %d", typ, data[0])
+	}
+	return data[1], data[2:], nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/volatiletech/null/v9/convert"
 )
@@ -38,6 +39,13 @@ func BoolFromPtr(b *bool) Bool {
 	return NewBool(*b, true, true)
 }
 
+// BoolUnset creates a new Bool that is null and not Set, as for a field
+// absent from the input. IsSet reports false until a value or an explicit
+// null is unmarshaled into it, or it is changed with SetValid or SetNull.
+func BoolUnset() Bool {
+	return Bool{}
+}
+
 func (b Bool) IsSet() bool {
 	return b.Set
 }
@@ -111,6 +119,14 @@ func (b *Bool) SetValid(v bool) {
 	b.Set = true
 }
 
+// SetNull changes this Bool's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (b *Bool) SetNull() {
+	b.Bool = false
+	b.Valid = false
+	b.Set = true
+}
+
 // Ptr returns a pointer to this Bool's value, or a nil pointer if this Bool is null.
 func (b Bool) Ptr() *bool {
 	if !b.Valid {
@@ -124,14 +140,41 @@ func (b Bool) IsZero() bool {
 	return !b.Valid
 }
 
+// Or returns this Bool if it is valid, or other if it is not.
+func (b Bool) Or(other Bool) Bool {
+	if b.Valid {
+		return b
+	}
+	return other
+}
+
+// Get returns this Bool's value and true, or the zero value and false if it is null.
+func (b Bool) Get() (bool, bool) {
+	if !b.Valid {
+		return false, false
+	}
+	return b.Bool, true
+}
+
 // Scan implements the Scanner interface.
+// Besides bool, it accepts the integers 0 and 1, and strings or []byte such as
+// "0", "1", "t", "f", "true" and "false" as parsed by strconv.ParseBool, which
+// is how drivers return booleans stored as e.g. TINYINT(1).
 func (b *Bool) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
 	if value == nil {
 		b.Bool, b.Valid, b.Set = false, false, false
 		return nil
 	}
-	b.Valid, b.Set = true, true
-	return convert.ConvertAssign(&b.Bool, value)
+	var v bool
+	if err := convert.ConvertAssign(&v, value); err != nil {
+		return fmt.Errorf("null: cannot scan type %T into null.Bool: %v: %w", value, value, err)
+	}
+	b.Bool, b.Valid, b.Set = v, true, true
+	return nil
 }
 
 // Value implements the driver Valuer interface.
@@ -2,6 +2,9 @@ package null
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -30,6 +33,14 @@ func TestBoolFromPtr(t *testing.T) {
 	assertNullBool(t, null, "BoolFromPtr(nil)")
 }
 
+func TestBoolUnset(t *testing.T) {
+	v := BoolUnset()
+	assertNullBool(t, v, "BoolUnset()")
+	if v.IsSet() {
+		t.Error("BoolUnset() should not be Set")
+	}
+}
+
 func TestUnmarshalBool(t *testing.T) {
 	var b Bool
 	err := json.Unmarshal(boolJSON, &b)
@@ -156,18 +167,88 @@ func TestBoolSetValid(t *testing.T) {
 	assertBool(t, change, "SetValid()")
 }
 
+func TestBoolSetNull(t *testing.T) {
+	change := NewBool(false, false, true)
+	change.SetValid(true)
+	change.SetNull()
+	assertNullBool(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.Bool != false {
+		t.Errorf("SetNull() should zero the value, got %v", change.Bool)
+	}
+}
+
 func TestBoolScan(t *testing.T) {
 	var b Bool
 	err := b.Scan(true)
 	maybePanic(err)
 	assertBool(t, b, "scanned bool")
 
+	for _, v := range []interface{}{int64(1), "1", "t", "true", "TRUE", []byte("1"), []byte("t"), []byte("true")} {
+		var b Bool
+		err := b.Scan(v)
+		maybePanic(err)
+		assertBool(t, b, fmt.Sprintf("scanned %T %v", v, v))
+	}
+	for _, v := range []interface{}{int64(0), "0", "f", "false", []byte("0"), []byte("f"), []byte("false")} {
+		var b Bool
+		err := b.Scan(v)
+		maybePanic(err)
+		assertFalseBool(t, b, fmt.Sprintf("scanned %T %v", v, v))
+	}
+
+	for _, v := range []interface{}{int64(2), int64(-1), "yes", []byte("no"), "", 1.5} {
+		var bad Bool
+		err := bad.Scan(v)
+		want := fmt.Sprintf("null: cannot scan type %T into null.Bool: %v: ", v, v)
+		if err == nil || !strings.HasPrefix(err.Error(), want) {
+			t.Errorf("scanning %T %v: got error %v, want one starting with %q", v, v, err, want)
+		}
+		if cause := errors.Unwrap(err); cause == nil || !strings.HasSuffix(err.Error(), cause.Error()) {
+			t.Errorf("scanning %T %v: error %v should wrap its cause", v, v, err)
+		}
+		assertNullBool(t, bad, fmt.Sprintf("bad scan of %T %v", v, v))
+	}
+
+	var str, typ Bool
+	strErr, typErr := errors.Unwrap(str.Scan("yes")), errors.Unwrap(typ.Scan(1.5))
+	if strErr == nil || typErr == nil || strErr.Error() == typErr.Error() {
+		t.Errorf("an unparseable string and a bad type should have different causes, got %v and %v", strErr, typErr)
+	}
+
 	var null Bool
 	err = null.Scan(nil)
 	maybePanic(err)
 	assertNullBool(t, null, "scanned null")
 }
 
+func TestBoolOr(t *testing.T) {
+	a := NewBool(true, true, true)
+	b := NewBool(false, true, true)
+	null := NewBool(false, false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestBoolGet(t *testing.T) {
+	if v, ok := BoolFrom(true).Get(); !ok || v != true {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null Bool
+	if v, ok := null.Get(); ok || v != false {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
 func assertBool(t *testing.T, b Bool, from string) {
 	if b.Bool != true {
 		t.Errorf("bad %s bool: %v ≠ %v\n", from, b.Bool, true)
@@ -0,0 +1,428 @@
+//go:build bson
+
+package null
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// BSON support is only built with the bson build tag, so that the MongoDB
+// driver is not a dependency for everyone else.
+//
+// The types implement bson.ValueMarshaler and bson.ValueUnmarshaler, which is
+// what the driver uses for values inside a document; bson.Marshaler and
+// bson.Unmarshaler are for whole documents and do not apply to a single
+// nullable value. Invalid values are written as BSON null, and both null and
+// undefined read back as an invalid, Set value. The types whose JSON form is
+// a string (Byte, Date, Decimal, Duration, IP and URL) are written as the
+// same BSON string.
+
+// bsonUUIDSubtype is the BSON binary subtype for RFC 4122 UUIDs.
+const bsonUUIDSubtype = 0x04
+
+func isBSONNull(typ byte) bool {
+	return bson.Type(typ) == bson.TypeNull || bson.Type(typ) == bson.TypeUndefined
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (n Null[T]) MarshalBSONValue() (byte, []byte, error) {
+	if !n.Valid {
+		return byte(bson.TypeNull), nil, nil
+	}
+	typ, data, err := bson.MarshalValue(n.Val)
+	return byte(typ), data, err
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (n *Null[T]) UnmarshalBSONValue(typ byte, data []byte) error {
+	if isBSONNull(typ) {
+		var zero T
+		n.Val, n.Valid, n.Set = zero, false, true
+		return nil
+	}
+	var v T
+	if err := bson.UnmarshalValue(bson.Type(typ), data, &v); err != nil {
+		return err
+	}
+	n.SetValid(v)
+	return nil
+}
+
+// marshalBSONText writes the text form of m as a BSON string, or null if
+// valid is false.
+func marshalBSONText(m encoding.TextMarshaler, valid bool) (byte, []byte, error) {
+	if !valid {
+		return byte(bson.TypeNull), nil, nil
+	}
+	text, err := m.MarshalText()
+	if err != nil {
+		return 0, nil, err
+	}
+	typ, data, err := bson.MarshalValue(string(text))
+	return byte(typ), data, err
+}
+
+// unmarshalBSONText reads a BSON string and hands it to u.
+func unmarshalBSONText(typ byte, data []byte, u encoding.TextUnmarshaler) error {
+	if isBSONNull(typ) {
+		return u.UnmarshalText(nil)
+	}
+	var s string
+	if err := bson.UnmarshalValue(bson.Type(typ), data, &s); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (b Bool) MarshalBSONValue() (byte, []byte, error) {
+	return Null[bool]{Val: b.Bool, Valid: b.Valid}.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (b *Bool) UnmarshalBSONValue(typ byte, data []byte) error {
+	var n Null[bool]
+	err := n.UnmarshalBSONValue(typ, data)
+	*b = NewBool(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (b Byte) MarshalBSONValue() (byte, []byte, error) {
+	return marshalBSONText(b, b.Valid)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (b *Byte) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalBSONText(typ, data, b)
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// Bytes are written as BSON binary data with the generic subtype.
+func (b Bytes) MarshalBSONValue() (byte, []byte, error) {
+	return Null[[]byte]{Val: b.Bytes, Valid: b.Valid}.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (b *Bytes) UnmarshalBSONValue(typ byte, data []byte) error {
+	var n Null[[]byte]
+	err := n.UnmarshalBSONValue(typ, data)
+	*b = NewBytes(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (d Date) MarshalBSONValue() (byte, []byte, error) {
+	return marshalBSONText(d, d.Valid)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (d *Date) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalBSONText(typ, data, d)
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (d Decimal) MarshalBSONValue() (byte, []byte, error) {
+	return marshalBSONText(d, d.Valid)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (d *Decimal) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalBSONText(typ, data, d)
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (d Duration) MarshalBSONValue() (byte, []byte, error) {
+	return marshalBSONText(d, d.Valid)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (d *Duration) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalBSONText(typ, data, d)
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (f Float32) MarshalBSONValue() (byte, []byte, error) {
+	return Null[float32]{Val: f.Float32, Valid: f.Valid}.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (f *Float32) UnmarshalBSONValue(typ byte, data []byte) error {
+	var n Null[float32]
+	err := n.UnmarshalBSONValue(typ, data)
+	*f = NewFloat32(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (f Float64) MarshalBSONValue() (byte, []byte, error) {
+	return Null[float64]{Val: f.Float64, Valid: f.Valid}.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (f *Float64) UnmarshalBSONValue(typ byte, data []byte) error {
+	var n Null[float64]
+	err := n.UnmarshalBSONValue(typ, data)
+	*f = NewFloat64(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (i Int) MarshalBSONValue() (byte, []byte, error) {
+	return Null[int]{Val: i.Int, Valid: i.Valid}.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (i *Int) UnmarshalBSONValue(typ byte, data []byte) error {
+	var n Null[int]
+	err := n.UnmarshalBSONValue(typ, data)
+	*i = NewInt(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (i Int8) MarshalBSONValue() (byte, []byte, error) {
+	return Null[int8]{Val: i.Int8, Valid: i.Valid}.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (i *Int8) UnmarshalBSONValue(typ byte, data []byte) error {
+	var n Null[int8]
+	err := n.UnmarshalBSONValue(typ, data)
+	*i = NewInt8(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (i Int16) MarshalBSONValue() (byte, []byte, error) {
+	return Null[int16]{Val: i.Int16, Valid: i.Valid}.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (i *Int16) UnmarshalBSONValue(typ byte, data []byte) error {
+	var n Null[int16]
+	err := n.UnmarshalBSONValue(typ, data)
+	*i = NewInt16(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (i Int32) MarshalBSONValue() (byte, []byte, error) {
+	return Null[int32]{Val: i.Int32, Valid: i.Valid}.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (i *Int32) UnmarshalBSONValue(typ byte, data []byte) error {
+	var n Null[int32]
+	err := n.UnmarshalBSONValue(typ, data)
+	*i = NewInt32(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (i Int64) MarshalBSONValue() (byte, []byte, error) {
+	return Null[int64]{Val: i.Int64, Valid: i.Valid}.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (i *Int64) UnmarshalBSONValue(typ byte, data []byte) error {
+	var n Null[int64]
+	err := n.UnmarshalBSONValue(typ, data)
+	*i = NewInt64(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (i IP) MarshalBSONValue() (byte, []byte, error) {
+	return marshalBSONText(i, i.Valid)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (i *IP) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalBSONText(typ, data, i)
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// The JSON is read as relaxed MongoDB Extended JSON and written as the value
+// it describes, so objects become embedded documents with their key order
+// kept, and keys such as "$date" produce the BSON types they name.
+func (j JSON) MarshalBSONValue() (byte, []byte, error) {
+	if !j.Valid || len(j.JSON) == 0 {
+		return byte(bson.TypeNull), nil, nil
+	}
+
+	// Extended JSON must be a document, so wrap the value in one.
+	wrapped := make([]byte, 0, len(j.JSON)+6)
+	wrapped = append(wrapped, `{"v":`...)
+	wrapped = append(wrapped, j.JSON...)
+	wrapped = append(wrapped, '}')
+
+	var doc bson.Raw
+	if err := bson.UnmarshalExtJSON(wrapped, false, &doc); err != nil {
+		return 0, nil, err
+	}
+	v := doc.Lookup("v")
+	return byte(v.Type), v.Value, nil
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// Any BSON value is accepted and stored as relaxed MongoDB Extended JSON.
+func (j *JSON) UnmarshalBSONValue(typ byte, data []byte) error {
+	j.Set = true
+	if isBSONNull(typ) {
+		j.JSON, j.Valid = nil, false
+		return nil
+	}
+
+	ext, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: bson.RawValue{Type: bson.Type(typ), Value: data}}}, false, false)
+	if err != nil {
+		return err
+	}
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(ext, &doc); err != nil {
+		return err
+	}
+	j.JSON, j.Valid = []byte(doc["v"]), true
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (s String) MarshalBSONValue() (byte, []byte, error) {
+	return Null[string]{Val: s.String, Valid: s.Valid}.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// Unlike UnmarshalText, an empty string is a valid value.
+func (s *String) UnmarshalBSONValue(typ byte, data []byte) error {
+	var n Null[string]
+	err := n.UnmarshalBSONValue(typ, data)
+	*s = NewString(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// A valid Time is written as a BSON datetime, which keeps milliseconds
+// since the Unix epoch but no zone.
+func (t Time) MarshalBSONValue() (byte, []byte, error) {
+	return Null[time.Time]{Val: t.Time, Valid: t.Valid}.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (t *Time) UnmarshalBSONValue(typ byte, data []byte) error {
+	var n Null[time.Time]
+	err := n.UnmarshalBSONValue(typ, data)
+	t.Time, t.Valid, t.Set = n.Val, n.Valid, err == nil
+	return err
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (u Uint) MarshalBSONValue() (byte, []byte, error) {
+	return Null[uint]{Val: u.Uint, Valid: u.Valid}.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (u *Uint) UnmarshalBSONValue(typ byte, data []byte) error {
+	var n Null[uint]
+	err := n.UnmarshalBSONValue(typ, data)
+	*u = NewUint(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (u Uint8) MarshalBSONValue() (byte, []byte, error) {
+	return Null[uint8]{Val: u.Uint8, Valid: u.Valid}.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (u *Uint8) UnmarshalBSONValue(typ byte, data []byte) error {
+	var n Null[uint8]
+	err := n.UnmarshalBSONValue(typ, data)
+	*u = NewUint8(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (u Uint16) MarshalBSONValue() (byte, []byte, error) {
+	return Null[uint16]{Val: u.Uint16, Valid: u.Valid}.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (u *Uint16) UnmarshalBSONValue(typ byte, data []byte) error {
+	var n Null[uint16]
+	err := n.UnmarshalBSONValue(typ, data)
+	*u = NewUint16(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (u Uint32) MarshalBSONValue() (byte, []byte, error) {
+	return Null[uint32]{Val: u.Uint32, Valid: u.Valid}.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (u *Uint32) UnmarshalBSONValue(typ byte, data []byte) error {
+	var n Null[uint32]
+	err := n.UnmarshalBSONValue(typ, data)
+	*u = NewUint32(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (u Uint64) MarshalBSONValue() (byte, []byte, error) {
+	return Null[uint64]{Val: u.Uint64, Valid: u.Valid}.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (u *Uint64) UnmarshalBSONValue(typ byte, data []byte) error {
+	var n Null[uint64]
+	err := n.UnmarshalBSONValue(typ, data)
+	u.Uint64, u.Valid, u.Set = n.Val, n.Valid, err == nil
+	return err
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (u URL) MarshalBSONValue() (byte, []byte, error) {
+	return marshalBSONText(u, u.Valid)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (u *URL) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalBSONText(typ, data, u)
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// A valid UUID is written as BSON binary data with the UUID subtype.
+func (u UUID) MarshalBSONValue() (byte, []byte, error) {
+	if !u.Valid {
+		return byte(bson.TypeNull), nil, nil
+	}
+	typ, data, err := bson.MarshalValue(bson.Binary{Subtype: bsonUUIDSubtype, Data: u.UUID[:]})
+	return byte(typ), data, err
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// Both 16 byte binary data and strings in the hyphenated form are accepted.
+func (u *UUID) UnmarshalBSONValue(typ byte, data []byte) error {
+	if bson.Type(typ) == bson.TypeString {
+		return unmarshalBSONText(typ, data, u)
+	}
+	var n Null[bson.Binary]
+	if err := n.UnmarshalBSONValue(typ, data); err != nil {
+		return err
+	}
+	if !n.Valid {
+		u.UUID, u.Valid, u.Set = [16]byte{}, false, true
+		return nil
+	}
+	if len(n.Val.Data) != 16 {
+		return fmt.Errorf("null: cannot unmarshal %d bytes of BSON binary data into null.UUID", len(n.Val.Data))
+	}
+	copy(u.UUID[:], n.Val.Data)
+	u.Valid, u.Set = true, true
+	return nil
+}
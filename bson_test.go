@@ -0,0 +1,192 @@
+//go:build bson
+
+package null
+
+import (
+	"bytes"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type bsonRecord struct {
+	Bool     Bool      `bson:"bool"`
+	Byte     Byte      `bson:"byte"`
+	Bytes    Bytes     `bson:"bytes"`
+	Date     Date      `bson:"date"`
+	Decimal  Decimal   `bson:"decimal"`
+	Duration Duration  `bson:"duration"`
+	Float32  Float32   `bson:"float32"`
+	Float64  Float64   `bson:"float64"`
+	Int      Int       `bson:"int"`
+	Int8     Int8      `bson:"int8"`
+	Int16    Int16     `bson:"int16"`
+	Int32    Int32     `bson:"int32"`
+	Int64    Int64     `bson:"int64"`
+	IP       IP        `bson:"ip"`
+	JSON     JSON      `bson:"json"`
+	String   String    `bson:"string"`
+	Time     Time      `bson:"time"`
+	Uint     Uint      `bson:"uint"`
+	Uint8    Uint8     `bson:"uint8"`
+	Uint16   Uint16    `bson:"uint16"`
+	Uint32   Uint32    `bson:"uint32"`
+	Uint64   Uint64    `bson:"uint64"`
+	URL      URL       `bson:"url"`
+	UUID     UUID      `bson:"uuid"`
+	Null     Null[int] `bson:"null"`
+}
+
+func TestBSONRoundTrip(t *testing.T) {
+	in := bsonRecord{
+		Bool:     BoolFrom(true),
+		Byte:     ByteFrom('a'),
+		Bytes:    BytesFrom([]byte{0, 1, 2}),
+		Date:     DateFrom(dateValue),
+		Decimal:  DecimalFrom(big.NewRat(12345678, 10000)),
+		Duration: DurationFrom(90 * time.Minute),
+		Float32:  Float32From(1.5),
+		Float64:  Float64From(1.5),
+		Int:      IntFrom(-1),
+		Int8:     Int8From(-8),
+		Int16:    Int16From(-16),
+		Int32:    Int32From(-32),
+		Int64:    Int64From(-64),
+		IP:       IPFrom(net.IPv4(192, 0, 2, 1)),
+		JSON:     JSONFrom([]byte(`{"z":1,"a":[true,"x",1.5],"m":{"n":null}}`)),
+		String:   StringFrom(""),
+		Time:     TimeFrom(timeValue),
+		Uint:     UintFrom(1),
+		Uint8:    Uint8From(8),
+		Uint16:   Uint16From(16),
+		Uint32:   Uint32From(32),
+		Uint64:   Uint64From(64),
+		URL:      NewURL(urlValue, true, true),
+		UUID:     NewUUID(uuidValue, true, true),
+		Null:     From(12345),
+	}
+	data, err := bson.Marshal(in)
+	maybePanic(err)
+
+	var out bsonRecord
+	err = bson.Unmarshal(data, &out)
+	maybePanic(err)
+
+	assertBool(t, out.Bool, "bson bool")
+	if out.Byte != in.Byte || !bytes.Equal(out.Bytes.Bytes, in.Bytes.Bytes) || out.Date != in.Date || out.Duration != in.Duration {
+		t.Errorf("bad bson byte, bytes, date or duration: %v %v %v %v", out.Byte, out.Bytes, out.Date, out.Duration)
+	}
+	assertDecimal(t, out.Decimal, "bson decimal")
+	if out.Float32 != in.Float32 || out.Float64 != in.Float64 {
+		t.Errorf("bad bson floats: %v %v", out.Float32, out.Float64)
+	}
+	if out.Int != in.Int || out.Int8 != in.Int8 || out.Int16 != in.Int16 || out.Int32 != in.Int32 || out.Int64 != in.Int64 {
+		t.Errorf("bad bson ints: %v %v %v %v %v", out.Int, out.Int8, out.Int16, out.Int32, out.Int64)
+	}
+	if out.Uint != in.Uint || out.Uint8 != in.Uint8 || out.Uint16 != in.Uint16 || out.Uint32 != in.Uint32 || out.Uint64 != in.Uint64 {
+		t.Errorf("bad bson uints: %v %v %v %v %v", out.Uint, out.Uint8, out.Uint16, out.Uint32, out.Uint64)
+	}
+	assertIP(t, out.IP, "bson ip")
+	assertJSONEquals(t, out.JSON.JSON, `{"z":1,"a":[true,"x",1.5],"m":{"n":null}}`, "bson json")
+	if !out.String.Valid || out.String.String != "" {
+		t.Errorf("empty bson string should be valid: %v", out.String)
+	}
+	if !out.Time.Valid || !out.Time.Time.Equal(timeValue) {
+		t.Errorf("bad bson time: %v", out.Time)
+	}
+	assertURL(t, out.URL, "bson url")
+	assertUUID(t, out.UUID, "bson uuid")
+	assertNull(t, out.Null, 12345, "bson generic")
+}
+
+func TestBSONNativeTypes(t *testing.T) {
+	data, err := bson.Marshal(bsonRecord{
+		JSON: JSONFrom([]byte(`{"a":1}`)),
+		Time: TimeFrom(timeValue),
+		UUID: NewUUID(uuidValue, true, true),
+	})
+	maybePanic(err)
+	doc := bson.Raw(data)
+
+	if typ := doc.Lookup("time").Type; typ != bson.TypeDateTime {
+		t.Errorf("Time should be a BSON datetime, got %v", typ)
+	}
+	if typ := doc.Lookup("json").Type; typ != bson.TypeEmbeddedDocument {
+		t.Errorf("JSON object should be an embedded document, got %v", typ)
+	}
+	if v, ok := doc.Lookup("json", "a").Int32OK(); !ok || v != 1 {
+		t.Errorf("bad embedded json value: %v", doc.Lookup("json", "a"))
+	}
+	if sub, b, ok := doc.Lookup("uuid").BinaryOK(); !ok || sub != bsonUUIDSubtype || !bytes.Equal(b, uuidValue[:]) {
+		t.Errorf("UUID should be BSON binary with subtype 4, got %v", doc.Lookup("uuid"))
+	}
+
+	// Decoding into a Time with a layout keeps the layout for JSON and text.
+	out := bsonRecord{Time: NewTimeWithLayout(time.Time{}, false, false, "2006")}
+	err = bson.Unmarshal(data, &out)
+	maybePanic(err)
+	if txt, _ := out.Time.MarshalText(); string(txt) != "2012" {
+		t.Errorf("UnmarshalBSONValue should keep the layout, got %s", txt)
+	}
+}
+
+func TestBSONNull(t *testing.T) {
+	data, err := bson.Marshal(bsonRecord{})
+	maybePanic(err)
+
+	elems, err := bson.Raw(data).Elements()
+	maybePanic(err)
+	if len(elems) != 25 {
+		t.Errorf("expected 25 elements, got %d", len(elems))
+	}
+	for _, e := range elems {
+		if e.Value().Type != bson.TypeNull {
+			t.Errorf("null %s should be BSON null, got %v", e.Key(), e.Value().Type)
+		}
+	}
+
+	out := bsonRecord{Int: IntFrom(1), JSON: JSONFrom([]byte(`1`)), Time: TimeFrom(timeValue), UUID: NewUUID(uuidValue, true, true)}
+	err = bson.Unmarshal(data, &out)
+	maybePanic(err)
+	assertNullInt(t, out.Int, "bson null int")
+	assertNullJSON(t, out.JSON, "bson null json")
+	assertNullTime(t, out.Time, "bson null time")
+	assertNullUUID(t, out.UUID, "bson null uuid")
+}
+
+func TestBSONInterop(t *testing.T) {
+	data, err := bson.Marshal(bson.D{
+		{Key: "json", Value: bson.A{1, "two"}},
+		{Key: "time", Value: bson.NewDateTimeFromTime(timeValue)},
+		{Key: "uuid", Value: uuidString},
+		{Key: "string", Value: "test"},
+	})
+	maybePanic(err)
+
+	var out bsonRecord
+	err = bson.Unmarshal(data, &out)
+	maybePanic(err)
+	assertJSONEquals(t, out.JSON.JSON, `[1,"two"]`, "bson array into json")
+	if !out.Time.Valid || !out.Time.Time.Equal(timeValue) {
+		t.Errorf("bad bson datetime: %v", out.Time)
+	}
+	assertUUID(t, out.UUID, "bson string uuid")
+	assertStr(t, out.String, "bson string")
+
+	for _, doc := range []bson.D{
+		{{Key: "int8", Value: 1000}},
+		{{Key: "string", Value: 1}},
+		{{Key: "uuid", Value: bson.Binary{Subtype: bsonUUIDSubtype, Data: []byte{1, 2, 3}}}},
+		{{Key: "time", Value: "yesterday"}},
+	} {
+		data, err := bson.Marshal(doc)
+		maybePanic(err)
+		var out bsonRecord
+		if err = bson.Unmarshal(data, &out); err == nil {
+			t.Errorf("expected error unmarshaling %v", doc)
+		}
+	}
+}
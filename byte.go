@@ -5,6 +5,8 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+
+	"github.com/volatiletech/null/v9/convert"
 )
 
 // Byte is an nullable int.
@@ -36,6 +38,13 @@ func ByteFromPtr(b *byte) Byte {
 	return NewByte(*b, true, true)
 }
 
+// ByteUnset creates a new Byte that is null and not Set, as for a field
+// absent from the input. IsSet reports false until a value or an explicit
+// null is unmarshaled into it, or it is changed with SetValid or SetNull.
+func ByteUnset() Byte {
+	return Byte{}
+}
+
 func (b Byte) IsSet() bool {
 	return b.Set
 }
@@ -104,6 +113,14 @@ func (b *Byte) SetValid(n byte) {
 	b.Set = true
 }
 
+// SetNull changes this Byte's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (b *Byte) SetNull() {
+	b.Byte = 0
+	b.Valid = false
+	b.Set = true
+}
+
 // Ptr returns a pointer to this Byte's value, or a nil pointer if this Byte is null.
 func (b Byte) Ptr() *byte {
 	if !b.Valid {
@@ -117,8 +134,28 @@ func (b Byte) IsZero() bool {
 	return !b.Valid
 }
 
+// Or returns this Byte if it is valid, or other if it is not.
+func (b Byte) Or(other Byte) Byte {
+	if b.Valid {
+		return b
+	}
+	return other
+}
+
+// Get returns this Byte's value and true, or the zero value and false if it is null.
+func (b Byte) Get() (byte, bool) {
+	if !b.Valid {
+		return 0, false
+	}
+	return b.Byte, true
+}
+
 // Scan implements the Scanner interface.
 func (b *Byte) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
 	if value == nil {
 		b.Byte, b.Valid, b.Set = 0, false, false
 		return nil
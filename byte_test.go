@@ -29,6 +29,14 @@ func TestByteFromPtr(t *testing.T) {
 	assertNullByte(t, null, "ByteFromPtr(nil)")
 }
 
+func TestByteUnset(t *testing.T) {
+	v := ByteUnset()
+	assertNullByte(t, v, "ByteUnset()")
+	if v.IsSet() {
+		t.Error("ByteUnset() should not be Set")
+	}
+}
+
 func TestUnmarshalByte(t *testing.T) {
 	var null Byte
 	err := json.Unmarshal(nullJSON, &null)
@@ -137,6 +145,19 @@ func TestByteSetValid(t *testing.T) {
 	assertByte(t, change, "SetValid()")
 }
 
+func TestByteSetNull(t *testing.T) {
+	change := NewByte(0, false, true)
+	change.SetValid('b')
+	change.SetNull()
+	assertNullByte(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.Byte != 0 {
+		t.Errorf("SetNull() should zero the value, got %v", change.Byte)
+	}
+}
+
 func TestByteScan(t *testing.T) {
 	var i Byte
 	err := i.Scan("b")
@@ -149,6 +170,31 @@ func TestByteScan(t *testing.T) {
 	assertNullByte(t, null, "scanned null")
 }
 
+func TestByteOr(t *testing.T) {
+	a := NewByte('a', true, true)
+	b := NewByte('b', true, true)
+	null := NewByte(0, false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestByteGet(t *testing.T) {
+	if v, ok := ByteFrom('a').Get(); !ok || v != byte('a') {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null Byte
+	if v, ok := null.Get(); ok || v != 0 {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
 func assertByte(t *testing.T, i Byte, from string) {
 	if i.Byte != 'b' {
 		t.Errorf("bad %s int: %d ≠ %d\n", from, i.Byte, 'b')
@@ -6,12 +6,15 @@ import (
 	"encoding/json"
 
 	"github.com/volatiletech/null/v9/convert"
+	"github.com/volatiletech/randomize"
 )
 
 // NullBytes is a global byte slice of JSON null
 var NullBytes = []byte("null")
 
-// Bytes is a nullable []byte.
+// Bytes is a nullable []byte, for raw binary such as BYTEA and BLOB
+// columns. Unlike JSON it gives its bytes no meaning: they are scanned and
+// written as they are, and marshal to JSON as a base64 string.
 type Bytes struct {
 	Bytes []byte
 	Valid bool
@@ -41,6 +44,13 @@ func BytesFromPtr(b *[]byte) Bytes {
 	return n
 }
 
+// BytesUnset creates a new Bytes that is null and not Set, as for a field
+// absent from the input. IsSet reports false until a value or an explicit
+// null is unmarshaled into it, or it is changed with SetValid or SetNull.
+func BytesUnset() Bytes {
+	return Bytes{}
+}
+
 func (b Bytes) IsSet() bool {
 	return b.Set
 }
@@ -102,6 +112,14 @@ func (b *Bytes) SetValid(n []byte) {
 	b.Set = true
 }
 
+// SetNull changes this Bytes's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (b *Bytes) SetNull() {
+	b.Bytes = nil
+	b.Valid = false
+	b.Set = true
+}
+
 // Ptr returns a pointer to this Bytes's value, or a nil pointer if this Bytes is null.
 func (b Bytes) Ptr() *[]byte {
 	if !b.Valid {
@@ -115,8 +133,28 @@ func (b Bytes) IsZero() bool {
 	return !b.Valid
 }
 
+// Or returns this Bytes if it is valid, or other if it is not.
+func (b Bytes) Or(other Bytes) Bytes {
+	if b.Valid {
+		return b
+	}
+	return other
+}
+
+// Get returns a copy of this Bytes's value and true, or nil and false if it is null.
+func (b Bytes) Get() ([]byte, bool) {
+	if !b.Valid {
+		return nil, false
+	}
+	return append([]byte{}, b.Bytes...), true
+}
+
 // Scan implements the Scanner interface.
 func (b *Bytes) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
 	if value == nil {
 		b.Bytes, b.Valid, b.Set = nil, false, false
 		return nil
@@ -139,7 +177,7 @@ func (b *Bytes) Randomize(nextInt func() int64, fieldType string, shouldBeNull b
 		b.Bytes = nil
 		b.Valid = false
 	} else {
-		b.Bytes = []byte{byte(nextInt() % 256)}
+		b.Bytes = randomize.ByteSlice(nextInt, 1+int(uint64(nextInt())%16))
 		b.Valid = true
 	}
 }
@@ -37,6 +37,14 @@ func TestBytesFromPtr(t *testing.T) {
 	assertNullBytes(t, null, "BytesFromPtr(nil)")
 }
 
+func TestBytesUnset(t *testing.T) {
+	v := BytesUnset()
+	assertNullBytes(t, v, "BytesUnset()")
+	if v.IsSet() {
+		t.Error("BytesUnset() should not be Set")
+	}
+}
+
 func TestUnmarshalBytes(t *testing.T) {
 	var i Bytes
 	err := json.Unmarshal(b64BytesJSON, &i)
@@ -138,16 +146,107 @@ func TestBytesSetValid(t *testing.T) {
 	assertBytes(t, change, "SetValid()")
 }
 
+func TestBytesSetNull(t *testing.T) {
+	change := NewBytes(nil, false, true)
+	change.SetValid(hello)
+	change.SetNull()
+	assertNullBytes(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.Bytes != nil {
+		t.Errorf("SetNull() should zero the value, got %v", change.Bytes)
+	}
+}
+
 func TestBytesScan(t *testing.T) {
 	var i Bytes
 	err := i.Scan(`hello`)
 	maybePanic(err)
 	assertBytes(t, i, "Scan() []byte")
 
+	blob := []byte{0xff, 0x00, '{', 0x80}
+	var bin Bytes
+	err = bin.Scan(blob)
+	maybePanic(err)
+	blob[0] = 0
+	if !bytes.Equal(bin.Bytes, []byte{0xff, 0x00, '{', 0x80}) || !bin.Valid {
+		t.Errorf("Scan() should copy binary data as it is, got %#v", bin)
+	}
+	if v, err := bin.Value(); !bytes.Equal(v.([]byte), bin.Bytes) || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+	data, err := json.Marshal(bin)
+	maybePanic(err)
+	assertJSONEquals(t, data, `"/wB7gA=="`, "binary json marshal")
+
 	var null Bytes
 	err = null.Scan(nil)
 	maybePanic(err)
 	assertNullBytes(t, null, "scanned null")
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+}
+
+func TestBytesRandomize(t *testing.T) {
+	var seed int64
+	nextInt := func() int64 {
+		seed++
+		return seed
+	}
+
+	lengths := make(map[int]bool)
+	for n := 0; n < 50; n++ {
+		var b Bytes
+		b.Randomize(nextInt, "bytea", false)
+		if !b.Valid || len(b.Bytes) == 0 || len(b.Bytes) > 16 {
+			t.Fatalf("Randomize() should produce 1 to 16 valid bytes, got %#v", b)
+		}
+		lengths[len(b.Bytes)] = true
+	}
+	if len(lengths) < 2 {
+		t.Errorf("Randomize() should vary the length, got %v", lengths)
+	}
+
+	b := BytesFrom(hello)
+	b.Randomize(nextInt, "bytea", true)
+	assertNullBytes(t, b, "Randomize() null")
+	if b.Bytes != nil {
+		t.Errorf("Randomize() null should clear the value, got %v", b.Bytes)
+	}
+}
+
+func TestBytesOr(t *testing.T) {
+	a := NewBytes([]byte("a"), true, true)
+	b := NewBytes([]byte("b"), true, true)
+	null := NewBytes(nil, false, true)
+	if got := a.Or(b); !(string(got.Bytes) == string(a.Bytes) && got.Valid == a.Valid) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(string(got.Bytes) == string(b.Bytes) && got.Valid == b.Valid) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestBytesGet(t *testing.T) {
+	b := BytesFrom([]byte("hello"))
+	v, ok := b.Get()
+	if !ok || string(v) != "hello" {
+		t.Errorf("bad Get() result: %s, %v", v, ok)
+	}
+	v[0] = 'x'
+	if string(b.Bytes) != "hello" {
+		t.Error("Get() should return a copy, but the original was modified:", string(b.Bytes))
+	}
+
+	var null Bytes
+	if v, ok := null.Get(); ok || v != nil {
+		t.Errorf("bad null Get() result: %s, %v", v, ok)
+	}
 }
 
 func assertBytes(t *testing.T, i Bytes, from string) {
@@ -0,0 +1,390 @@
+//go:build cbor
+
+package null
+
+import (
+	"encoding"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// CBOR support is only built with the cbor build tag, so that
+// github.com/fxamacker/cbor is not a dependency for everyone else.
+//
+// Invalid values encode as CBOR null, and both null and undefined decode to
+// an invalid, Set value. Valid values encode as their native CBOR type; the
+// types whose JSON form is a string (Byte, Date, Decimal, Duration, IP, URL
+// and UUID) encode as the same text string.
+
+// cborNull is the encoding of the CBOR simple value null.
+var cborNull = []byte{0xf6}
+
+// cborTimeMode encodes times as RFC 3339 strings with tag 0, which keeps
+// both nanoseconds and the zone offset.
+var cborTimeMode, _ = cbor.EncOptions{
+	Time:    cbor.TimeRFC3339Nano,
+	TimeTag: cbor.EncTagRequired,
+}.EncMode()
+
+func isCBORNull(data []byte) bool {
+	return len(data) == 1 && (data[0] == 0xf6 || data[0] == 0xf7)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (n Null[T]) MarshalCBOR() ([]byte, error) {
+	if !n.Valid {
+		return cborNull, nil
+	}
+	return cbor.Marshal(n.Val)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (n *Null[T]) UnmarshalCBOR(data []byte) error {
+	if isCBORNull(data) {
+		var zero T
+		n.Val, n.Valid, n.Set = zero, false, true
+		return nil
+	}
+	var v T
+	if err := cbor.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	n.SetValid(v)
+	return nil
+}
+
+// marshalCBORText encodes the text form of m as a CBOR text string, or null
+// if valid is false.
+func marshalCBORText(m encoding.TextMarshaler, valid bool) ([]byte, error) {
+	if !valid {
+		return cborNull, nil
+	}
+	text, err := m.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return cbor.Marshal(string(text))
+}
+
+// unmarshalCBORText decodes a CBOR text string and hands it to u.
+func unmarshalCBORText(data []byte, u encoding.TextUnmarshaler) error {
+	if isCBORNull(data) {
+		return u.UnmarshalText(nil)
+	}
+	var s string
+	if err := cbor.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (b Bool) MarshalCBOR() ([]byte, error) {
+	return Null[bool]{Val: b.Bool, Valid: b.Valid}.MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (b *Bool) UnmarshalCBOR(data []byte) error {
+	var n Null[bool]
+	err := n.UnmarshalCBOR(data)
+	*b = NewBool(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (b Byte) MarshalCBOR() ([]byte, error) {
+	return marshalCBORText(b, b.Valid)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (b *Byte) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBORText(data, b)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// Bytes are encoded as a CBOR byte string.
+func (b Bytes) MarshalCBOR() ([]byte, error) {
+	return Null[[]byte]{Val: b.Bytes, Valid: b.Valid}.MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (b *Bytes) UnmarshalCBOR(data []byte) error {
+	var n Null[[]byte]
+	err := n.UnmarshalCBOR(data)
+	*b = NewBytes(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (d Date) MarshalCBOR() ([]byte, error) {
+	return marshalCBORText(d, d.Valid)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (d *Date) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBORText(data, d)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (d Decimal) MarshalCBOR() ([]byte, error) {
+	return marshalCBORText(d, d.Valid)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (d *Decimal) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBORText(data, d)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (d Duration) MarshalCBOR() ([]byte, error) {
+	return marshalCBORText(d, d.Valid)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (d *Duration) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBORText(data, d)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (f Float32) MarshalCBOR() ([]byte, error) {
+	return Null[float32]{Val: f.Float32, Valid: f.Valid}.MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (f *Float32) UnmarshalCBOR(data []byte) error {
+	var n Null[float32]
+	err := n.UnmarshalCBOR(data)
+	*f = NewFloat32(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (f Float64) MarshalCBOR() ([]byte, error) {
+	return Null[float64]{Val: f.Float64, Valid: f.Valid}.MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (f *Float64) UnmarshalCBOR(data []byte) error {
+	var n Null[float64]
+	err := n.UnmarshalCBOR(data)
+	*f = NewFloat64(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (i Int) MarshalCBOR() ([]byte, error) {
+	return Null[int]{Val: i.Int, Valid: i.Valid}.MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (i *Int) UnmarshalCBOR(data []byte) error {
+	var n Null[int]
+	err := n.UnmarshalCBOR(data)
+	*i = NewInt(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (i Int8) MarshalCBOR() ([]byte, error) {
+	return Null[int8]{Val: i.Int8, Valid: i.Valid}.MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (i *Int8) UnmarshalCBOR(data []byte) error {
+	var n Null[int8]
+	err := n.UnmarshalCBOR(data)
+	*i = NewInt8(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (i Int16) MarshalCBOR() ([]byte, error) {
+	return Null[int16]{Val: i.Int16, Valid: i.Valid}.MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (i *Int16) UnmarshalCBOR(data []byte) error {
+	var n Null[int16]
+	err := n.UnmarshalCBOR(data)
+	*i = NewInt16(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (i Int32) MarshalCBOR() ([]byte, error) {
+	return Null[int32]{Val: i.Int32, Valid: i.Valid}.MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (i *Int32) UnmarshalCBOR(data []byte) error {
+	var n Null[int32]
+	err := n.UnmarshalCBOR(data)
+	*i = NewInt32(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (i Int64) MarshalCBOR() ([]byte, error) {
+	return Null[int64]{Val: i.Int64, Valid: i.Valid}.MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (i *Int64) UnmarshalCBOR(data []byte) error {
+	var n Null[int64]
+	err := n.UnmarshalCBOR(data)
+	*i = NewInt64(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (i IP) MarshalCBOR() ([]byte, error) {
+	return marshalCBORText(i, i.Valid)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (i *IP) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBORText(data, i)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// A valid JSON is encoded as a CBOR byte string holding its bytes.
+func (j JSON) MarshalCBOR() ([]byte, error) {
+	return Null[[]byte]{Val: j.JSON, Valid: j.Valid}.MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// Both byte strings and text strings are accepted.
+func (j *JSON) UnmarshalCBOR(data []byte) error {
+	var n Null[[]byte]
+	err := n.UnmarshalCBOR(data)
+	if n.Valid && n.Val == nil {
+		n.Val = []byte{}
+	}
+	*j = NewJSON(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (s String) MarshalCBOR() ([]byte, error) {
+	return Null[string]{Val: s.String, Valid: s.Valid}.MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// Unlike UnmarshalText, an empty string is a valid value.
+func (s *String) UnmarshalCBOR(data []byte) error {
+	var n Null[string]
+	err := n.UnmarshalCBOR(data)
+	*s = NewString(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// A valid Time is encoded as a tag 0 date/time string.
+func (t Time) MarshalCBOR() ([]byte, error) {
+	if !t.Valid {
+		return cborNull, nil
+	}
+	return cborTimeMode.Marshal(t.Time)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// Tag 0 strings, tag 1 epoch times and untagged values are all accepted.
+func (t *Time) UnmarshalCBOR(data []byte) error {
+	t.Set = true
+	if isCBORNull(data) {
+		t.Time, t.Valid = time.Time{}, false
+		return nil
+	}
+	var v time.Time
+	if err := cbor.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	t.Time, t.Valid = v, true
+	return nil
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (u Uint) MarshalCBOR() ([]byte, error) {
+	return Null[uint]{Val: u.Uint, Valid: u.Valid}.MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (u *Uint) UnmarshalCBOR(data []byte) error {
+	var n Null[uint]
+	err := n.UnmarshalCBOR(data)
+	*u = NewUint(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (u Uint8) MarshalCBOR() ([]byte, error) {
+	return Null[uint8]{Val: u.Uint8, Valid: u.Valid}.MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (u *Uint8) UnmarshalCBOR(data []byte) error {
+	var n Null[uint8]
+	err := n.UnmarshalCBOR(data)
+	*u = NewUint8(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (u Uint16) MarshalCBOR() ([]byte, error) {
+	return Null[uint16]{Val: u.Uint16, Valid: u.Valid}.MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (u *Uint16) UnmarshalCBOR(data []byte) error {
+	var n Null[uint16]
+	err := n.UnmarshalCBOR(data)
+	*u = NewUint16(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (u Uint32) MarshalCBOR() ([]byte, error) {
+	return Null[uint32]{Val: u.Uint32, Valid: u.Valid}.MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (u *Uint32) UnmarshalCBOR(data []byte) error {
+	var n Null[uint32]
+	err := n.UnmarshalCBOR(data)
+	*u = NewUint32(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (u Uint64) MarshalCBOR() ([]byte, error) {
+	return Null[uint64]{Val: u.Uint64, Valid: u.Valid}.MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (u *Uint64) UnmarshalCBOR(data []byte) error {
+	var n Null[uint64]
+	err := n.UnmarshalCBOR(data)
+	u.Uint64, u.Valid, u.Set = n.Val, n.Valid, err == nil
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (u URL) MarshalCBOR() ([]byte, error) {
+	return marshalCBORText(u, u.Valid)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (u *URL) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBORText(data, u)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (u UUID) MarshalCBOR() ([]byte, error) {
+	return marshalCBORText(u, u.Valid)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (u *UUID) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBORText(data, u)
+}
@@ -0,0 +1,206 @@
+//go:build cbor
+
+package null
+
+import (
+	"bytes"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+type cborRecord struct {
+	Bool     Bool      `cbor:"bool"`
+	Byte     Byte      `cbor:"byte"`
+	Bytes    Bytes     `cbor:"bytes"`
+	Date     Date      `cbor:"date"`
+	Decimal  Decimal   `cbor:"decimal"`
+	Duration Duration  `cbor:"duration"`
+	Float32  Float32   `cbor:"float32"`
+	Float64  Float64   `cbor:"float64"`
+	Int      Int       `cbor:"int"`
+	Int8     Int8      `cbor:"int8"`
+	Int16    Int16     `cbor:"int16"`
+	Int32    Int32     `cbor:"int32"`
+	Int64    Int64     `cbor:"int64"`
+	IP       IP        `cbor:"ip"`
+	JSON     JSON      `cbor:"json"`
+	String   String    `cbor:"string"`
+	Time     Time      `cbor:"time"`
+	Uint     Uint      `cbor:"uint"`
+	Uint8    Uint8     `cbor:"uint8"`
+	Uint16   Uint16    `cbor:"uint16"`
+	Uint32   Uint32    `cbor:"uint32"`
+	Uint64   Uint64    `cbor:"uint64"`
+	URL      URL       `cbor:"url"`
+	UUID     UUID      `cbor:"uuid"`
+	Null     Null[int] `cbor:"null"`
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	in := cborRecord{
+		Bool:     BoolFrom(true),
+		Byte:     ByteFrom('a'),
+		Bytes:    BytesFrom([]byte{0, 1, 2}),
+		Date:     DateFrom(dateValue),
+		Decimal:  DecimalFrom(big.NewRat(12345678, 10000)),
+		Duration: DurationFrom(90 * time.Minute),
+		Float32:  Float32From(1.5),
+		Float64:  Float64From(1.5),
+		Int:      IntFrom(-1),
+		Int8:     Int8From(-8),
+		Int16:    Int16From(-16),
+		Int32:    Int32From(-32),
+		Int64:    Int64From(-64),
+		IP:       IPFrom(net.IPv4(192, 0, 2, 1)),
+		JSON:     JSONFrom([]byte(`{"a":1}`)),
+		String:   StringFrom(""),
+		Time:     TimeFrom(timeValue.Add(123 * time.Nanosecond).In(time.FixedZone("", 3600))),
+		Uint:     UintFrom(1),
+		Uint8:    Uint8From(8),
+		Uint16:   Uint16From(16),
+		Uint32:   Uint32From(32),
+		Uint64:   Uint64From(64),
+		URL:      NewURL(urlValue, true, true),
+		UUID:     NewUUID(uuidValue, true, true),
+		Null:     From(12345),
+	}
+	data, err := cbor.Marshal(in)
+	maybePanic(err)
+
+	var out cborRecord
+	err = cbor.Unmarshal(data, &out)
+	maybePanic(err)
+
+	assertBool(t, out.Bool, "cbor bool")
+	if out.Byte != in.Byte || !bytes.Equal(out.Bytes.Bytes, in.Bytes.Bytes) || out.Date != in.Date || out.Duration != in.Duration {
+		t.Errorf("bad cbor byte, bytes, date or duration: %v %v %v %v", out.Byte, out.Bytes, out.Date, out.Duration)
+	}
+	assertDecimal(t, out.Decimal, "cbor decimal")
+	if out.Float32 != in.Float32 || out.Float64 != in.Float64 {
+		t.Errorf("bad cbor floats: %v %v", out.Float32, out.Float64)
+	}
+	if out.Int != in.Int || out.Int8 != in.Int8 || out.Int16 != in.Int16 || out.Int32 != in.Int32 || out.Int64 != in.Int64 {
+		t.Errorf("bad cbor ints: %v %v %v %v %v", out.Int, out.Int8, out.Int16, out.Int32, out.Int64)
+	}
+	if out.Uint != in.Uint || out.Uint8 != in.Uint8 || out.Uint16 != in.Uint16 || out.Uint32 != in.Uint32 || out.Uint64 != in.Uint64 {
+		t.Errorf("bad cbor uints: %v %v %v %v %v", out.Uint, out.Uint8, out.Uint16, out.Uint32, out.Uint64)
+	}
+	assertIP(t, out.IP, "cbor ip")
+	assertJSONEquals(t, out.JSON.JSON, `{"a":1}`, "cbor json")
+	if !out.String.Valid || out.String.String != "" {
+		t.Errorf("empty cbor string should be valid: %v", out.String)
+	}
+	if !out.Time.Valid || !out.Time.Time.Equal(in.Time.Time) {
+		t.Errorf("bad cbor time: %v ≠ %v", out.Time.Time, in.Time.Time)
+	}
+	if _, off := out.Time.Time.Zone(); off != 3600 {
+		t.Errorf("cbor time should keep its offset, got %d", off)
+	}
+	assertURL(t, out.URL, "cbor url")
+	assertUUID(t, out.UUID, "cbor uuid")
+	assertNull(t, out.Null, 12345, "cbor generic")
+}
+
+func TestCBORNull(t *testing.T) {
+	data, err := cbor.Marshal(cborRecord{})
+	maybePanic(err)
+
+	var generic map[string]interface{}
+	err = cbor.Unmarshal(data, &generic)
+	maybePanic(err)
+	if len(generic) != 25 {
+		t.Errorf("expected 25 keys, got %d", len(generic))
+	}
+	for k, v := range generic {
+		if v != nil {
+			t.Errorf("null %s should encode as CBOR null, got %#v", k, v)
+		}
+	}
+
+	out := cborRecord{Int: IntFrom(1), JSON: JSONFrom([]byte(`1`)), Time: TimeFrom(timeValue), UUID: NewUUID(uuidValue, true, true)}
+	err = cbor.Unmarshal(data, &out)
+	maybePanic(err)
+	assertNullInt(t, out.Int, "cbor null int")
+	assertNullJSON(t, out.JSON, "cbor null json")
+	assertNullTime(t, out.Time, "cbor null time")
+	assertNullUUID(t, out.UUID, "cbor null uuid")
+
+	var undef Int
+	err = cbor.Unmarshal([]byte{0xf7}, &undef)
+	maybePanic(err)
+	assertNullInt(t, undef, "cbor undefined")
+}
+
+func TestCBORTimeTags(t *testing.T) {
+	data, err := cbor.Marshal(TimeFrom(timeValue))
+	maybePanic(err)
+	if data[0] != 0xc0 {
+		t.Errorf("Time should encode with tag 0, got %x", data)
+	}
+
+	// Times from other encoders may use epoch seconds with tag 1.
+	epoch, err := cbor.EncOptions{Time: cbor.TimeUnix, TimeTag: cbor.EncTagRequired}.EncMode()
+	maybePanic(err)
+	data, err = epoch.Marshal(timeValue)
+	maybePanic(err)
+	if data[0] != 0xc1 {
+		t.Errorf("expected tag 1, got %x", data)
+	}
+	var ti Time
+	err = cbor.Unmarshal(data, &ti)
+	maybePanic(err)
+	if !ti.Valid || !ti.Time.Equal(timeValue) {
+		t.Errorf("bad tag 1 time: %v", ti)
+	}
+
+	// Decoding into a Time with a layout keeps the layout for JSON and text.
+	kept := NewTimeWithLayout(time.Time{}, false, false, "2006")
+	err = cbor.Unmarshal(data, &kept)
+	maybePanic(err)
+	if txt, _ := kept.MarshalText(); string(txt) != "2012" {
+		t.Errorf("UnmarshalCBOR should keep the layout, got %s", txt)
+	}
+
+	var wrong Time
+	if err = cbor.Unmarshal([]byte{0xf5}, &wrong); err == nil {
+		t.Error("expected error decoding a bool into Time")
+	}
+}
+
+func TestCBORInterop(t *testing.T) {
+	for _, c := range []struct {
+		v    interface{}
+		want interface{}
+	}{
+		{IntFrom(12345), 12345},
+		{StringFrom("hello"), "hello"},
+		{BoolFrom(true), true},
+		{Float64From(1.5), 1.5},
+		{BytesFrom([]byte("hi")), []byte("hi")},
+		{JSONFrom([]byte(`[1]`)), []byte(`[1]`)},
+		{NewUUID(uuidValue, true, true), uuidString},
+	} {
+		got, err := cbor.Marshal(c.v)
+		maybePanic(err)
+		want, err := cbor.Marshal(c.want)
+		maybePanic(err)
+		if !bytes.Equal(got, want) {
+			t.Errorf("%T should encode like %T: %x ≠ %x", c.v, c.want, got, want)
+		}
+	}
+
+	var s String
+	err := cbor.Unmarshal([]byte{0x01}, &s)
+	if err == nil {
+		t.Error("expected error decoding an integer into String")
+	}
+	var i8 Int8
+	data, _ := cbor.Marshal(1000)
+	if err = cbor.Unmarshal(data, &i8); err == nil {
+		t.Error("expected error decoding an overflowing integer into Int8")
+	}
+}
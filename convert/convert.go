@@ -10,8 +10,10 @@ package convert
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
 	"strconv"
 	"time"
@@ -19,10 +21,119 @@ import (
 
 var errNilPtr = errors.New("destination pointer is nil") // embedded in descriptive error
 
+// converters are the fallbacks added with RegisterConverter.
+var converters []func(dest, src interface{}) (bool, error)
+
+// RegisterConverter adds fn to the fallbacks ConvertAssign consults, in
+// registration order, before reporting an unsupported conversion. fn should
+// return handled=false for pairs of dest and src it does not know, and
+// handled=true with any conversion error otherwise.
+//
+// RegisterConverter is not safe for concurrent use with itself or with
+// ConvertAssign; call it during initialization, e.g. from an init func.
+func RegisterConverter(fn func(dest, src interface{}) (handled bool, err error)) {
+	converters = append(converters, fn)
+}
+
+// TimeLayouts are the layouts tried, in order, when converting a string or
+// []byte into a time.Time and TimeLayoutsFunc is nil. The first layout that
+// parses wins. It should only be changed during initialization, before any
+// conversions run.
+var TimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// TimeLayoutsFunc, if set, returns the layouts to try in place of
+// TimeLayouts. Package null sets it to read null.TimeScanLayouts, so that
+// ConvertAssign and null.Time.Scan share one list once null is imported.
+var TimeLayoutsFunc func() []string
+
+func timeLayouts() []string {
+	if TimeLayoutsFunc != nil {
+		return TimeLayoutsFunc()
+	}
+	return TimeLayouts
+}
+
+// maxValuerDepth limits how many driver.Valuers ValuerValue follows, so a
+// Valuer that returns itself or a cycle of Valuers cannot recurse forever.
+const maxValuerDepth = 8
+
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+// ValuerValue returns the result of src's Value method if src is a
+// driver.Valuer, following Valuers that return other Valuers, or src itself
+// if it is not. A nil pointer whose Value method has a value receiver
+// yields nil, as it does in database/sql. A *interface{} src, as passed by
+// some reflective scan frameworks, is dereferenced first, see ConvertAssign.
+func ValuerValue(src interface{}) (interface{}, error) {
+	for i := 0; i < maxValuerDepth; i++ {
+		if p, ok := src.(*interface{}); ok {
+			v, err := derefInterface(p)
+			if err != nil {
+				return nil, err
+			}
+			src = v
+		}
+		vr, ok := src.(driver.Valuer)
+		if !ok {
+			return src, nil
+		}
+		if rv := reflect.ValueOf(vr); rv.Kind() == reflect.Ptr && rv.IsNil() && rv.Type().Elem().Implements(valuerType) {
+			return nil, nil
+		}
+		v, err := vr.Value()
+		if err != nil {
+			return nil, err
+		}
+		src = v
+	}
+	if _, ok := src.(driver.Valuer); ok {
+		return nil, fmt.Errorf("converting driver.Valuer type %T: more than %d nested Valuers", src, maxValuerDepth)
+	}
+	return src, nil
+}
+
+// derefInterface follows p, and any *interface{} it holds, to the value
+// at the end. A nil pointer yields nil, which is read as SQL NULL.
+func derefInterface(p *interface{}) (interface{}, error) {
+	for i := 0; i < maxValuerDepth; i++ {
+		if p == nil {
+			return nil, nil
+		}
+		next, ok := (*p).(*interface{})
+		if !ok {
+			return *p, nil
+		}
+		p = next
+	}
+	return nil, fmt.Errorf("converting driver.Value type %T: more than %d nested *interface{}", p, maxValuerDepth)
+}
+
 // ConvertAssign copies to dest the value in src, converting it if possible.
 // An error is returned if the copy would result in loss of information.
-// dest should be a pointer type.
+// dest should be a pointer type. A driver.Valuer src that cannot be stored
+// in dest as it is is replaced by its value first. A *interface{} src is
+// replaced by the value it points to, following nested ones, and a nil one
+// is read as nil.
 func ConvertAssign(dest, src interface{}) error {
+	if p, ok := src.(*interface{}); ok {
+		v, err := derefInterface(p)
+		if err != nil {
+			return err
+		}
+		return ConvertAssign(dest, v)
+	}
+	if _, ok := src.(driver.Valuer); ok && !assignable(dest, src) {
+		v, err := ValuerValue(src)
+		if err != nil {
+			return err
+		}
+		return ConvertAssign(dest, v)
+	}
+
 	// Common cases, without reflect.
 	switch s := src.(type) {
 	case string:
@@ -39,6 +150,11 @@ func ConvertAssign(dest, src interface{}) error {
 			}
 			*d = []byte(s)
 			return nil
+		case *time.Time:
+			if d == nil {
+				return errNilPtr
+			}
+			return parseTime(d, src, s)
 		}
 	case []byte:
 		switch d := dest.(type) {
@@ -66,6 +182,37 @@ func ConvertAssign(dest, src interface{}) error {
 			}
 			*d = s
 			return nil
+		case *time.Time:
+			if d == nil {
+				return errNilPtr
+			}
+			return parseTime(d, src, string(s))
+		}
+	case json.Number:
+		// json.Number is handled like the string it holds, so numeric
+		// destinations parse it with the strconv cases below.
+		switch d := dest.(type) {
+		case *string:
+			if d == nil {
+				return errNilPtr
+			}
+			*d = string(s)
+			return nil
+		case *[]byte:
+			if d == nil {
+				return errNilPtr
+			}
+			*d = []byte(s)
+			return nil
+		case *bool:
+			if d == nil {
+				return errNilPtr
+			}
+			bv, err := driver.Bool.ConvertValue(string(s))
+			if err == nil {
+				*d = bv.(bool)
+			}
+			return err
 		}
 	case time.Time:
 		switch d := dest.(type) {
@@ -136,6 +283,23 @@ func ConvertAssign(dest, src interface{}) error {
 	case *interface{}:
 		*d = src
 		return nil
+	case *big.Int:
+		if d == nil {
+			return errNilPtr
+		}
+		switch s := src.(type) {
+		case int64:
+			d.SetInt64(s)
+			return nil
+		case string, []byte, json.Number:
+			str := asString(s)
+			i, ok := new(big.Int).SetString(str, 10)
+			if !ok {
+				return fmt.Errorf("converting driver.Value type %T (%q) to a *big.Int: invalid syntax", src, str)
+			}
+			d.Set(i)
+			return nil
+		}
 	}
 
 	if scanner, ok := dest.(sql.Scanner); ok {
@@ -203,9 +367,41 @@ func ConvertAssign(dest, src interface{}) error {
 		return nil
 	}
 
+	for _, fn := range converters {
+		if handled, err := fn(dest, src); handled {
+			return err
+		}
+	}
+
+	// As a last resort, a fmt.Stringer is stored into a string by its String
+	// method, once no conversion by kind applies: a time.Duration still reads
+	// as its integer. Other destinations are left to fail, so a genuine type
+	// mismatch is still reported.
+	if str, ok := src.(fmt.Stringer); ok && dv.Kind() == reflect.String && !(sv.Kind() == reflect.Ptr && sv.IsNil()) {
+		dv.SetString(str.String())
+		return nil
+	}
+
 	return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type %T", src, dest)
 }
 
+func parseTime(dest *time.Time, src interface{}, s string) error {
+	layouts := timeLayouts()
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			*dest = t
+			return nil
+		}
+	}
+	return fmt.Errorf("converting driver.Value type %T (%q) to a time.Time: no layout matched, tried %q", src, s, layouts)
+}
+
+// assignable reports whether src can be stored in dest, a pointer, as it is.
+func assignable(dest, src interface{}) bool {
+	dpv := reflect.ValueOf(dest)
+	return dpv.Kind() == reflect.Ptr && !dpv.IsNil() && reflect.TypeOf(src).AssignableTo(dpv.Type().Elem())
+}
+
 func strconvErr(err error) error {
 	if ne, ok := err.(*strconv.NumError); ok {
 		return ne.Err
@@ -229,6 +425,8 @@ func asString(src interface{}) string {
 		return v
 	case []byte:
 		return string(v)
+	case json.Number:
+		return string(v)
 	}
 	rv := reflect.ValueOf(src)
 	switch rv.Kind() {
@@ -9,7 +9,9 @@ package convert
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"reflect"
 	"runtime"
 	"testing"
@@ -41,6 +43,7 @@ type conversionTest struct {
 	wantptr    *int64 // if non-nil, *d's pointed value must be equal to *wantptr
 	wantnil    bool   // if true, *d must be *int64(nil)
 	wantusrdef userDefined
+	wantbig    string // if non-empty, d must be a *big.Int with this value
 }
 
 // Target variables for scanning into.
@@ -52,6 +55,7 @@ var (
 	scanint8   int8
 	scanint16  int16
 	scanint32  int32
+	scanint64  int64
 	scanuint8  uint8
 	scanuint16 uint16
 	scanbool   bool
@@ -88,6 +92,15 @@ var conversionTests = []conversionTest{
 	{s: time.Unix(1, 2).UTC(), d: &scanbytes, wantbytes: []byte("1970-01-01T00:00:01.000000002Z")},
 	{s: time.Unix(1, 2).UTC(), d: &scaniface, wantiface: time.Unix(1, 2).UTC()},
 
+	// To time.Time
+	{s: "1970-01-01T00:00:01Z", d: &scantime, wanttime: time.Unix(1, 0)},
+	{s: []byte("2016-01-26T22:03:17.5-08:00"), d: &scantime, wanttime: time.Unix(1453874597, 5e8)},
+	{s: "2016-01-27 06:03:17", d: &scantime, wanttime: time.Unix(1453874597, 0)},
+	{s: []byte("2016-01-27 06:03:17.25"), d: &scantime, wanttime: time.Unix(1453874597, 25e7)},
+	{s: "2016-01-27", d: &scantime, wanttime: time.Unix(1453852800, 0)},
+	{s: "27/01/2016", d: &scantime, wanterr: `converting driver.Value type string ("27/01/2016") to a time.Time: no layout matched, tried ["2006-01-02T15:04:05.999999999Z07:00" "2006-01-02 15:04:05.999999999" "2006-01-02"]`},
+	{s: []byte{}, d: &scantime, wanterr: `converting driver.Value type []uint8 ("") to a time.Time: no layout matched, tried ["2006-01-02T15:04:05.999999999Z07:00" "2006-01-02 15:04:05.999999999" "2006-01-02"]`},
+
 	// To []byte
 	{s: nil, d: &scanbytes, wantbytes: nil},
 	{s: "string", d: &scanbytes, wantbytes: []byte("string")},
@@ -155,6 +168,33 @@ var conversionTests = []conversionTest{
 	{s: "1.5", d: &scanf32, wantf32: float32(1.5)},
 	{s: "1.5", d: &scanf64, wantf64: float64(1.5)},
 
+	// From json.Number
+	{s: json.Number("123"), d: &scanint, wantint: 123},
+	{s: json.Number("-9223372036854775808"), d: &scanint64, wantint: -9223372036854775808},
+	{s: json.Number("9223372036854775808"), d: &scanint64, wanterr: "converting driver.Value type json.Number (\"9223372036854775808\") to a int64: value out of range"},
+	{s: json.Number("128"), d: &scanint8, wanterr: "converting driver.Value type json.Number (\"128\") to a int8: value out of range"},
+	{s: json.Number("1.5"), d: &scanint, wanterr: "converting driver.Value type json.Number (\"1.5\") to a int: invalid syntax"},
+	{s: json.Number("255"), d: &scanuint8, wantuint: 255},
+	{s: json.Number("-1"), d: &scanuint8, wanterr: "converting driver.Value type json.Number (\"-1\") to a uint8: invalid syntax"},
+	{s: json.Number("1.5"), d: &scanf64, wantf64: 1.5},
+	{s: json.Number("1e3"), d: &scanf32, wantf32: 1000},
+	{s: json.Number("1e39"), d: &scanf32, wanterr: "converting driver.Value type json.Number (\"1e39\") to a float32: value out of range"},
+	{s: json.Number("abc"), d: &scanf64, wanterr: "converting driver.Value type json.Number (\"abc\") to a float64: invalid syntax"},
+	{s: json.Number("12.50"), d: &scanstr, wantstr: "12.50"},
+	{s: json.Number("12.50"), d: &scanbytes, wantbytes: []byte("12.50")},
+	{s: json.Number("1"), d: &scanbool, wantbool: true},
+	{s: json.Number("2"), d: &scanbool, wanterr: `sql/driver: couldn't convert "2" into type bool`},
+	{s: json.Number("12"), d: &scaniface, wantiface: json.Number("12")},
+
+	// To *big.Int
+	{s: int64(-42), d: new(big.Int), wantbig: "-42"},
+	{s: "123456789012345678901234567890", d: new(big.Int), wantbig: "123456789012345678901234567890"},
+	{s: []byte("-9223372036854775809"), d: new(big.Int), wantbig: "-9223372036854775809"},
+	{s: json.Number("18446744073709551616"), d: new(big.Int), wantbig: "18446744073709551616"},
+	{s: "12.5", d: new(big.Int), wanterr: `converting driver.Value type string ("12.5") to a *big.Int: invalid syntax`},
+	{s: []byte("0x10"), d: new(big.Int), wanterr: `converting driver.Value type []uint8 ("0x10") to a *big.Int: invalid syntax`},
+	{s: 1.5, d: new(big.Int), wanterr: `unsupported Scan, storing driver.Value type float64 into type *big.Int`},
+
 	// Pointers
 	{s: interface{}(nil), d: &scanptr, wantnil: true},
 	{s: int64(42), d: &scanptr, wantptr: &answer},
@@ -259,12 +299,224 @@ func TestConversions(t *testing.T) {
 				}
 			}
 		}
+		if ct.wantbig != "" && ct.wantbig != ct.d.(*big.Int).String() {
+			errf("want big.Int %s, got %s", ct.wantbig, ct.d.(*big.Int))
+		}
 		if ct.wantusrdef != 0 && ct.wantusrdef != *ct.d.(*userDefined) {
 			errf("want userDefined %f, got %f", ct.wantusrdef, *ct.d.(*userDefined))
 		}
 	}
 }
 
+func TestTimeLayouts(t *testing.T) {
+	defer func(layouts []string) { TimeLayouts = layouts }(TimeLayouts)
+	TimeLayouts = append([]string{"02/01/2006"}, TimeLayouts...)
+
+	var tm time.Time
+	if err := ConvertAssign(&tm, "27/01/2016"); err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2016, 1, 27, 0, 0, 0, 0, time.UTC); !tm.Equal(want) {
+		t.Errorf("want time %v, got %v", want, tm)
+	}
+}
+
+// selfValuer is a driver.Valuer that returns itself.
+type selfValuer struct{}
+
+func (v selfValuer) Value() (driver.Value, error) { return v, nil }
+
+// wrapValuer is a driver.Valuer that wraps another value.
+type wrapValuer struct {
+	v interface{}
+}
+
+func (w wrapValuer) Value() (driver.Value, error) { return w.v, nil }
+
+// ptrValuer has a value receiver Value method, like sql.NullString.
+type ptrValuer struct{}
+
+func (ptrValuer) Value() (driver.Value, error) { return "value", nil }
+
+func TestConvertValuer(t *testing.T) {
+	var s string
+	if err := ConvertAssign(&s, sql.NullString{String: "hi", Valid: true}); err != nil || s != "hi" {
+		t.Errorf("want string %q, got %q, %v", "hi", s, err)
+	}
+
+	var i int8
+	if err := ConvertAssign(&i, wrapValuer{wrapValuer{sql.NullInt64{Int64: 12, Valid: true}}}); err != nil || i != 12 {
+		t.Errorf("want int8 12 from nested Valuers, got %d, %v", i, err)
+	}
+
+	var ns sql.NullString
+	if err := ConvertAssign(&ns, wrapValuer{nil}); err != nil || ns.Valid {
+		t.Errorf("a Valuer returning nil should scan as NULL, got %#v, %v", ns, err)
+	}
+
+	var b []byte
+	if err := ConvertAssign(&b, (*ptrValuer)(nil)); err != nil || b != nil {
+		t.Errorf("a nil *ptrValuer should convert as nil, got %q, %v", b, err)
+	}
+
+	var kept wrapValuer
+	if err := ConvertAssign(&kept, wrapValuer{"x"}); err != nil || kept.v != "x" {
+		t.Errorf("an assignable Valuer should be stored as it is, got %#v, %v", kept, err)
+	}
+
+	err := ConvertAssign(&s, selfValuer{})
+	if want := "converting driver.Valuer type convert.selfValuer: more than 8 nested Valuers"; err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %q", err, want)
+	}
+
+	if _, err := ValuerValue(wrapValuer{sql.NullBool{}}); err != nil {
+		t.Errorf("ValuerValue() of a null Valuer should not fail: %v", err)
+	}
+	if v, err := ValuerValue(42); v != 42 || err != nil {
+		t.Errorf("ValuerValue() of a non-Valuer should return it unchanged, got %v, %v", v, err)
+	}
+}
+
+func TestConvertInterfacePtr(t *testing.T) {
+	var inner interface{} = int64(42)
+	var outer interface{} = &inner
+
+	var i int
+	if err := ConvertAssign(&i, &outer); err != nil || i != 42 {
+		t.Errorf("want int 42 from a doubly-wrapped value, got %d, %v", i, err)
+	}
+	var s string
+	if err := ConvertAssign(&s, &inner); err != nil || s != "42" {
+		t.Errorf("want string %q, got %q, %v", "42", s, err)
+	}
+
+	var null interface{}
+	p := &s
+	if err := ConvertAssign(&p, &null); err != nil || p != nil {
+		t.Errorf("a nil inner value should be read as NULL, got %v, %v", p, err)
+	}
+	if err := ConvertAssign(&p, (*interface{})(nil)); err != nil || p != nil {
+		t.Errorf("a nil *interface{} should be read as NULL, got %v, %v", p, err)
+	}
+
+	var valuer interface{} = ptrValuer{}
+	wrapped := interface{}(&valuer)
+	if v, err := ValuerValue(&wrapped); v != "value" || err != nil {
+		t.Errorf("ValuerValue() should unwrap *interface{} before a Valuer, got %v, %v", v, err)
+	}
+
+	var loop interface{}
+	loop = &loop
+	if err := ConvertAssign(&s, &loop); err == nil {
+		t.Error("want an error for a *interface{} that points to itself")
+	}
+}
+
+type money struct {
+	cents int64
+}
+
+func TestRegisterConverter(t *testing.T) {
+	defer func(fns []func(dest, src interface{}) (bool, error)) { converters = fns }(converters)
+
+	var calls int
+	RegisterConverter(func(dest, src interface{}) (bool, error) {
+		calls++
+		return false, nil
+	})
+	RegisterConverter(func(dest, src interface{}) (bool, error) {
+		m, ok := src.(money)
+		if !ok {
+			return false, nil
+		}
+		d, ok := dest.(*string)
+		if !ok {
+			return true, fmt.Errorf("cannot store money in %T", dest)
+		}
+		*d = fmt.Sprintf("%d.%02d", m.cents/100, m.cents%100)
+		return true, nil
+	})
+
+	var s string
+	if err := ConvertAssign(&s, money{1250}); err != nil {
+		t.Fatal(err)
+	}
+	if s != "12.50" {
+		t.Errorf("want string %q, got %q", "12.50", s)
+	}
+
+	var i []int
+	if err := ConvertAssign(&i, money{5}); err == nil || err.Error() != "cannot store money in *[]int" {
+		t.Errorf("want the registered converter's error, got %v", err)
+	}
+
+	err := ConvertAssign(&i, complex(1, 2))
+	if want := "unsupported Scan, storing driver.Value type complex128 into type *[]int"; err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %q", err, want)
+	}
+	if s := "foo"; ConvertAssign(&s, "bar") != nil || s != "bar" {
+		t.Error("built-in conversions should not consult registered converters")
+	}
+	if calls != 3 {
+		t.Errorf("want 3 calls to the first converter, got %d", calls)
+	}
+}
+
+type label int64
+
+func (l label) String() string { return fmt.Sprintf("label-%d", int64(l)) }
+
+type name string
+
+type version struct{ major, minor int }
+
+func (v version) String() string { return fmt.Sprintf("v%d.%d", v.major, v.minor) }
+
+func TestConvertStringer(t *testing.T) {
+	var s string
+	if err := ConvertAssign(&s, version{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	if s != "v1.2" {
+		t.Errorf("want string %q, got %q", "v1.2", s)
+	}
+
+	// String is a last resort: a Stringer with a numeric kind, such as
+	// time.Duration, keeps its integer text.
+	if err := ConvertAssign(&s, 1500*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if s != "1500000000" {
+		t.Errorf("want string %q, got %q", "1500000000", s)
+	}
+	if err := ConvertAssign(&s, label(7)); err != nil || s != "7" {
+		t.Errorf("want string %q, got %q, %v", "7", s, err)
+	}
+
+	var n name
+	if err := ConvertAssign(&n, label(7)); err != nil {
+		t.Fatal(err)
+	}
+	if n != "label-7" {
+		t.Errorf("want name %q, got %q", "label-7", n)
+	}
+
+	// Non-string destinations keep their usual conversions and errors.
+	var i int64
+	if err := ConvertAssign(&i, label(7)); err != nil || i != 7 {
+		t.Errorf("want int64 7, got %d, %v", i, err)
+	}
+	var b []int
+	err := ConvertAssign(&b, label(7))
+	if want := "unsupported Scan, storing driver.Value type convert.label into type *[]int"; err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %q", err, want)
+	}
+
+	if err := ConvertAssign(&s, (*big.Int)(nil)); err == nil {
+		t.Error("a nil fmt.Stringer pointer should not be stored into a string")
+	}
+}
+
 func TestNullString(t *testing.T) {
 	var ns sql.NullString
 	ConvertAssign(&ns, []byte("foo"))
@@ -290,8 +542,8 @@ type valueConverterTest struct {
 }
 
 var valueConverterTests = []valueConverterTest{
-	{driver.DefaultParameterConverter, sql.NullString{"hi", true}, "hi", ""},
-	{driver.DefaultParameterConverter, sql.NullString{"", false}, nil, ""},
+	{driver.DefaultParameterConverter, sql.NullString{String: "hi", Valid: true}, "hi", ""},
+	{driver.DefaultParameterConverter, sql.NullString{String: "", Valid: false}, nil, ""},
 }
 
 func TestValueConverters(t *testing.T) {
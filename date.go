@@ -0,0 +1,202 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/volatiletech/null/v9/convert"
+	"github.com/volatiletech/randomize"
+)
+
+// DateLayout is the layout Date marshals to and unmarshals from.
+const DateLayout = "2006-01-02"
+
+// Date is a nullable date without a time of day. It supports SQL and JSON serialization.
+// The date is always stored as midnight UTC; times passed in are truncated to
+// their calendar date in their own location.
+type Date struct {
+	Date  time.Time
+	Valid bool
+	Set   bool
+}
+
+// NewDate creates a new Date.
+func NewDate(t time.Time, valid, set bool) Date {
+	return Date{
+		Date:  truncateDate(t),
+		Valid: valid,
+		Set:   set,
+	}
+}
+
+// DateFrom creates a new Date that will always be valid.
+func DateFrom(t time.Time) Date {
+	return NewDate(t, true, true)
+}
+
+// DateFromPtr creates a new Date that will be null if t is nil.
+func DateFromPtr(t *time.Time) Date {
+	if t == nil {
+		return NewDate(time.Time{}, false, true)
+	}
+	return NewDate(*t, true, true)
+}
+
+// DateUnset creates a new Date that is null and not Set, as for a field
+// absent from the input. IsSet reports false until a value or an explicit
+// null is unmarshaled into it, or it is changed with SetValid or SetNull.
+func DateUnset() Date {
+	return Date{}
+}
+
+func (d Date) IsSet() bool {
+	return d.Set
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return NullBytes, nil
+	}
+	return json.Marshal(d.Date.Format(DateLayout))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	d.Set = true
+	if bytes.Equal(data, NullBytes) {
+		d.Valid = false
+		d.Date = time.Time{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Date) MarshalText() ([]byte, error) {
+	if !d.Valid {
+		return NullBytes, nil
+	}
+	return []byte(d.Date.Format(DateLayout)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Date) UnmarshalText(text []byte) error {
+	d.Set = true
+	if len(text) == 0 {
+		d.Date, d.Valid = time.Time{}, false
+		return nil
+	}
+	v, err := time.Parse(DateLayout, string(text))
+	if err != nil {
+		return err
+	}
+	d.Date, d.Valid = v, true
+	return nil
+}
+
+// SetValid changes this Date's value and sets it to be non-null.
+func (d *Date) SetValid(v time.Time) {
+	d.Date = truncateDate(v)
+	d.Valid = true
+	d.Set = true
+}
+
+// SetNull changes this Date's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (d *Date) SetNull() {
+	d.Date = time.Time{}
+	d.Valid = false
+	d.Set = true
+}
+
+// Ptr returns a pointer to this Date's value, or a nil pointer if this Date is null.
+func (d Date) Ptr() *time.Time {
+	if !d.Valid {
+		return nil
+	}
+	return &d.Date
+}
+
+// IsZero returns true for an invalid Date's value, for potential future omitempty support.
+func (d Date) IsZero() bool {
+	return !d.Valid
+}
+
+// Or returns this Date if it is valid, or other if it is not.
+func (d Date) Or(other Date) Date {
+	if d.Valid {
+		return d
+	}
+	return other
+}
+
+// Get returns this Date's value and true, or the zero value and false if it is null.
+func (d Date) Get() (time.Time, bool) {
+	if !d.Valid {
+		return time.Time{}, false
+	}
+	return d.Date, true
+}
+
+// Scan implements the Scanner interface.
+// Strings and []byte are parsed with the same layouts as Time.Scan.
+func (d *Date) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
+	switch x := value.(type) {
+	case time.Time:
+		d.Date = truncateDate(x)
+	case string:
+		var v time.Time
+		v, err = parseScanTime(x)
+		d.Date = truncateDate(v)
+	case []byte:
+		var v time.Time
+		v, err = parseScanTime(string(x))
+		d.Date = truncateDate(v)
+	case nil:
+		d.Valid, d.Set = false, false
+		return nil
+	default:
+		err = fmt.Errorf("null: cannot scan type %T into null.Date: %v", value, value)
+	}
+	if err == nil {
+		d.Valid, d.Set = true, true
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+func (d Date) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return d.Date, nil
+}
+
+// Randomize for sqlboiler
+func (d *Date) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		d.Date = time.Time{}
+		d.Valid = false
+	} else {
+		d.Date = truncateDate(randomize.Date(nextInt))
+		d.Valid = true
+	}
+}
+
+func truncateDate(t time.Time) time.Time {
+	y, m, day := t.Date()
+	return time.Date(y, m, day, 0, 0, 0, 0, time.UTC)
+}
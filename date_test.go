@@ -0,0 +1,250 @@
+package null
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var (
+	dateString = "2012-12-21"
+	dateJSON   = []byte(`"` + dateString + `"`)
+	dateValue  = time.Date(2012, 12, 21, 0, 0, 0, 0, time.UTC)
+)
+
+func TestUnmarshalDateJSON(t *testing.T) {
+	var d Date
+	err := json.Unmarshal(dateJSON, &d)
+	maybePanic(err)
+	assertDate(t, d, "UnmarshalJSON() json")
+
+	var null Date
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullDate(t, null, "null date json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var full Date
+	err = json.Unmarshal(timeJSON, &full)
+	if err == nil {
+		t.Error("expected error: full timestamp")
+	}
+	assertNullDate(t, full, "full timestamp json")
+
+	var wrongType Date
+	err = json.Unmarshal(intJSON, &wrongType)
+	if err == nil {
+		t.Error("expected error: wrong type JSON")
+	}
+	assertNullDate(t, wrongType, "wrong type object json")
+}
+
+func TestUnmarshalDateText(t *testing.T) {
+	d := DateFrom(timeValue)
+	txt, err := d.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, txt, dateString, "marshal text")
+
+	var unmarshal Date
+	err = unmarshal.UnmarshalText(txt)
+	maybePanic(err)
+	assertDate(t, unmarshal, "unmarshal text")
+
+	var blank Date
+	err = blank.UnmarshalText([]byte(""))
+	maybePanic(err)
+	assertNullDate(t, blank, "blank text")
+
+	reused := DateFrom(timeValue)
+	err = reused.UnmarshalText([]byte(""))
+	maybePanic(err)
+	assertNullDate(t, reused, "blank text into a valid Date")
+	if !reused.Date.IsZero() {
+		t.Errorf("blank text should clear the old date, got %v", reused.Date)
+	}
+
+	var invalid Date
+	err = invalid.UnmarshalText([]byte("hello world"))
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullDate(t, invalid, "bad string")
+}
+
+func TestMarshalDate(t *testing.T) {
+	d := DateFrom(timeValue)
+	data, err := json.Marshal(d)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(dateJSON), "non-empty json marshal")
+
+	d.Valid = false
+	data, err = json.Marshal(d)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(nullJSON), "null json marshal")
+}
+
+func TestDateFrom(t *testing.T) {
+	d := DateFrom(timeValue)
+	assertDate(t, d, "DateFrom() time.Time")
+
+	ny, err := time.LoadLocation("America/New_York")
+	maybePanic(err)
+	late := time.Date(2012, 12, 21, 23, 30, 0, 0, ny)
+	d = DateFrom(late)
+	assertDate(t, d, "DateFrom() keeps the calendar date in its location")
+}
+
+func TestDateFromPtr(t *testing.T) {
+	d := DateFromPtr(&timeValue)
+	assertDate(t, d, "DateFromPtr() time")
+
+	null := DateFromPtr(nil)
+	assertNullDate(t, null, "DateFromPtr(nil)")
+}
+
+func TestDateUnset(t *testing.T) {
+	v := DateUnset()
+	assertNullDate(t, v, "DateUnset()")
+	if v.IsSet() {
+		t.Error("DateUnset() should not be Set")
+	}
+}
+
+func TestDateSetValid(t *testing.T) {
+	change := NewDate(time.Time{}, false, true)
+	assertNullDate(t, change, "SetValid()")
+	change.SetValid(timeValue)
+	assertDate(t, change, "SetValid()")
+}
+
+func TestDateSetNull(t *testing.T) {
+	change := NewDate(time.Time{}, false, true)
+	change.SetValid(timeValue)
+	change.SetNull()
+	assertNullDate(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if !change.Date.IsZero() {
+		t.Errorf("SetNull() should zero the value, got %v", change.Date)
+	}
+}
+
+func TestDatePointer(t *testing.T) {
+	d := DateFrom(timeValue)
+	ptr := d.Ptr()
+	if *ptr != dateValue {
+		t.Errorf("bad %s date: %#v ≠ %v\n", "pointer", ptr, dateValue)
+	}
+
+	null := NewDate(time.Time{}, false, true)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s date: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestDateIsZero(t *testing.T) {
+	d := DateFrom(time.Now())
+	if d.IsZero() {
+		t.Errorf("IsZero() should be false")
+	}
+
+	null := DateFromPtr(nil)
+	if !null.IsZero() {
+		t.Errorf("IsZero() should be true")
+	}
+}
+
+func TestDateScanValue(t *testing.T) {
+	for _, in := range []interface{}{timeValue, dateString, []byte(dateString), "2012-12-21 21:21:21"} {
+		var d Date
+		err := d.Scan(in)
+		maybePanic(err)
+		assertDate(t, d, "scanned date")
+		if v, err := d.Value(); v != dateValue || err != nil {
+			t.Error("bad value or err:", v, err)
+		}
+	}
+
+	var null Date
+	err := null.Scan(nil)
+	maybePanic(err)
+	assertNullDate(t, null, "scanned null")
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	var wrong Date
+	err = wrong.Scan(true)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullDate(t, wrong, "scanned wrong")
+
+	var bad Date
+	err = bad.Scan("21/12/2012")
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullDate(t, bad, "scanned bad string")
+}
+
+func TestDateRandomize(t *testing.T) {
+	var seed int64
+	nextInt := func() int64 {
+		seed++
+		return seed
+	}
+
+	var d Date
+	d.Randomize(nextInt, "date", false)
+	if !d.Valid || d.Date != truncateDate(d.Date) {
+		t.Errorf("Randomize() should produce a valid date at midnight UTC: %v", d.Date)
+	}
+
+	d.Randomize(nextInt, "date", true)
+	assertNullDate(t, d, "Randomize() null")
+}
+
+func TestDateOr(t *testing.T) {
+	a := NewDate(dateValue, true, true)
+	b := NewDate(dateValue.AddDate(0, 0, 1), true, true)
+	null := NewDate(time.Time{}, false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestDateGet(t *testing.T) {
+	if v, ok := DateFrom(dateValue).Get(); !ok || v != dateValue {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null Date
+	if v, ok := null.Get(); ok || !v.IsZero() {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
+func assertDate(t *testing.T, d Date, from string) {
+	if d.Date != dateValue {
+		t.Errorf("bad %v date: %v ≠ %v\n", from, d.Date, dateValue)
+	}
+	if !d.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullDate(t *testing.T, d Date, from string) {
+	if d.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
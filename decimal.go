@@ -0,0 +1,291 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/volatiletech/null/v9/convert"
+)
+
+// DecimalQuoteJSON makes Decimal marshal to a JSON string instead of a
+// bare number, for consumers that would otherwise parse it as a float.
+var DecimalQuoteJSON = false
+
+// Decimal is a nullable exact decimal number, for NUMERIC and DECIMAL columns.
+// It supports SQL and JSON serialization without going through float64.
+// Values are written to SQL as strings. Only numbers with a finite decimal
+// expansion can be written out; anything else, such as 1/3, is an error.
+type Decimal struct {
+	Decimal *big.Rat
+	Valid   bool
+	Set     bool
+}
+
+// NewDecimal creates a new Decimal
+func NewDecimal(d *big.Rat, valid, set bool) Decimal {
+	return Decimal{
+		Decimal: d,
+		Valid:   valid,
+		Set:     set,
+	}
+}
+
+// DecimalFrom creates a new Decimal that will be null if d is nil.
+func DecimalFrom(d *big.Rat) Decimal {
+	return NewDecimal(d, d != nil, true)
+}
+
+// DecimalFromString creates a new valid Decimal by parsing s,
+// returning an error if s is not a decimal number.
+func DecimalFromString(s string) (Decimal, error) {
+	d, err := parseDecimal(s)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return NewDecimal(d, true, true), nil
+}
+
+// DecimalUnset creates a new Decimal that is null and not Set, as for a
+// field absent from the input. IsSet reports false until a value or an
+// explicit null is unmarshaled into it, or it is changed with SetValid or
+// SetNull.
+func DecimalUnset() Decimal {
+	return Decimal{}
+}
+
+func (d Decimal) IsSet() bool {
+	return d.Set
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts both bare numbers and numbers in strings.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	d.Set = true
+	if bytes.Equal(data, NullBytes) {
+		d.Decimal, d.Valid = nil, false
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	return d.UnmarshalText([]byte(n))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	d.Set = true
+	if len(text) == 0 {
+		d.Decimal, d.Valid = nil, false
+		return nil
+	}
+	v, err := parseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	d.Decimal, d.Valid = v, true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return NullBytes, nil
+	}
+	s, err := formatDecimal(d.Decimal)
+	if err != nil {
+		return nil, err
+	}
+	if DecimalQuoteJSON {
+		return []byte(`"` + s + `"`), nil
+	}
+	return []byte(s), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Decimal) MarshalText() ([]byte, error) {
+	if !d.Valid {
+		return []byte{}, nil
+	}
+	s, err := formatDecimal(d.Decimal)
+	return []byte(s), err
+}
+
+// SetValid changes this Decimal's value and also sets it to be non-null.
+func (d *Decimal) SetValid(v *big.Rat) {
+	d.Decimal = v
+	d.Valid = true
+	d.Set = true
+}
+
+// SetNull changes this Decimal's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (d *Decimal) SetNull() {
+	d.Decimal = nil
+	d.Valid = false
+	d.Set = true
+}
+
+// Ptr returns this Decimal's value, or a nil pointer if this Decimal is null.
+func (d Decimal) Ptr() *big.Rat {
+	if !d.Valid {
+		return nil
+	}
+	return d.Decimal
+}
+
+// IsZero returns true for invalid Decimals, for potential future omitempty support.
+func (d Decimal) IsZero() bool {
+	return !d.Valid
+}
+
+// Or returns this Decimal if it is valid, or other if it is not.
+func (d Decimal) Or(other Decimal) Decimal {
+	if d.Valid {
+		return d
+	}
+	return other
+}
+
+// Get returns a copy of this Decimal's value and true, or nil and false if it is null.
+func (d Decimal) Get() (*big.Rat, bool) {
+	if !d.Valid {
+		return nil, false
+	}
+	return new(big.Rat).Set(d.Decimal), true
+}
+
+// Scan implements the Scanner interface.
+func (d *Decimal) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
+	switch x := value.(type) {
+	case string:
+		d.Decimal, err = parseDecimal(x)
+	case []byte:
+		d.Decimal, err = parseDecimal(string(x))
+	case float64:
+		d.Decimal, err = parseDecimal(strconv.FormatFloat(x, 'g', -1, 64))
+	case int64:
+		d.Decimal = new(big.Rat).SetInt64(x)
+	case nil:
+		d.Decimal, d.Valid, d.Set = nil, false, false
+		return nil
+	default:
+		err = fmt.Errorf("null: cannot scan type %T into null.Decimal: %v", value, value)
+	}
+	if err == nil {
+		d.Valid, d.Set = true, true
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+func (d Decimal) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return formatDecimal(d.Decimal)
+}
+
+// Randomize for sqlboiler
+func (d *Decimal) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		d.Decimal = nil
+		d.Valid = false
+	} else {
+		d.Decimal = big.NewRat(nextInt()%1000000, 100)
+		d.Valid = true
+	}
+}
+
+// maxDecimalExponent bounds the exponent parseDecimal accepts. It is well
+// past what SQL NUMERIC types hold, but keeps input such as 1e99999999 from
+// making big.Rat allocate a number with that many digits.
+const maxDecimalExponent = 1 << 17
+
+// parseDecimal parses s as a plain decimal literal: an optional sign, digits
+// with an optional fraction, and an optional exponent. Unlike
+// big.Rat.SetString alone, it rejects 0x and other prefixes, a/b fractions
+// and exponents beyond maxDecimalExponent.
+func parseDecimal(s string) (*big.Rat, error) {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	digits := 0
+	for ; i < len(s) && isDigit(s[i]); i++ {
+		digits++
+	}
+	if i < len(s) && s[i] == '.' {
+		for i++; i < len(s) && isDigit(s[i]); i++ {
+			digits++
+		}
+	}
+	if digits == 0 {
+		return nil, fmt.Errorf("null: invalid decimal %q", s)
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		exp, err := strconv.ParseInt(s[i+1:], 10, 64)
+		if errors.Is(err, strconv.ErrSyntax) {
+			return nil, fmt.Errorf("null: invalid decimal %q", s)
+		}
+		if err != nil || exp > maxDecimalExponent || exp < -maxDecimalExponent {
+			return nil, fmt.Errorf("null: decimal %q exponent out of range", s)
+		}
+		i = len(s)
+	}
+	if i != len(s) {
+		return nil, fmt.Errorf("null: invalid decimal %q", s)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("null: invalid decimal %q", s)
+	}
+	return r, nil
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// formatDecimal writes r out in full as a decimal number. The denominator
+// of a Rat is in lowest terms, so r has a finite expansion exactly when the
+// denominator has no prime factors besides 2 and 5, and the larger of the
+// two exponents is the number of digits needed after the decimal point.
+func formatDecimal(r *big.Rat) (string, error) {
+	if r == nil {
+		return "", fmt.Errorf("null: valid Decimal has a nil value")
+	}
+	if r.IsInt() {
+		return r.Num().String(), nil
+	}
+
+	var twos, fives int
+	rest := new(big.Int).Set(r.Denom())
+	two, five, mod := big.NewInt(2), big.NewInt(5), new(big.Int)
+	for mod.Mod(rest, two).Sign() == 0 {
+		rest.Quo(rest, two)
+		twos++
+	}
+	for mod.Mod(rest, five).Sign() == 0 {
+		rest.Quo(rest, five)
+		fives++
+	}
+	if rest.Cmp(big.NewInt(1)) != 0 {
+		return "", fmt.Errorf("null: %s has no exact decimal representation", r.RatString())
+	}
+
+	if twos < fives {
+		twos = fives
+	}
+	return r.FloatString(twos), nil
+}
@@ -0,0 +1,332 @@
+package null
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+var (
+	decimalJSON       = []byte(`1234.5678`)
+	decimalStringJSON = []byte(`"1234.5678"`)
+	decimalValue      = big.NewRat(12345678, 10000)
+)
+
+func TestDecimalFrom(t *testing.T) {
+	d := DecimalFrom(decimalValue)
+	assertDecimal(t, d, "DecimalFrom()")
+
+	null := DecimalFrom(nil)
+	assertNullDecimal(t, null, "DecimalFrom(nil)")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+}
+
+func TestDecimalUnset(t *testing.T) {
+	v := DecimalUnset()
+	assertNullDecimal(t, v, "DecimalUnset()")
+	if v.IsSet() {
+		t.Error("DecimalUnset() should not be Set")
+	}
+}
+
+func TestDecimalFromString(t *testing.T) {
+	d, err := DecimalFromString("1234.5678")
+	maybePanic(err)
+	assertDecimal(t, d, "DecimalFromString()")
+
+	d, err = DecimalFromString("1.2345678e3")
+	maybePanic(err)
+	assertDecimal(t, d, "DecimalFromString() exponent")
+
+	for _, s := range []string{"-.5", "+5.", "1E-3", "1e+3", "0001.10"} {
+		if _, err := DecimalFromString(s); err != nil {
+			t.Errorf("unexpected error for %q: %v", s, err)
+		}
+	}
+
+	for _, s := range []string{"", "abc", "1/3", "0x1F", "0b101", "1_000", ".", "-", "1e", "1e+", "1.2.3", " 1", "1 ", "Inf", "NaN", "1e0x10"} {
+		if _, err := DecimalFromString(s); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+
+	for _, s := range []string{"1e99999999", "1e-99999999", "1e999999999999999999999"} {
+		_, err := DecimalFromString(s)
+		if want := `null: decimal "` + s + `" exponent out of range`; errString(err) != want {
+			t.Errorf("got error %v, want %q", err, want)
+		}
+	}
+}
+
+func TestUnmarshalDecimalJSON(t *testing.T) {
+	var d Decimal
+	err := json.Unmarshal(decimalJSON, &d)
+	maybePanic(err)
+	assertDecimal(t, d, "decimal json")
+
+	var str Decimal
+	err = json.Unmarshal(decimalStringJSON, &str)
+	maybePanic(err)
+	assertDecimal(t, str, "decimal string json")
+
+	var long Decimal
+	err = json.Unmarshal([]byte(`123456789012345678901234567890.000000000000000000001`), &long)
+	maybePanic(err)
+	data, err := json.Marshal(long)
+	maybePanic(err)
+	assertJSONEquals(t, data, "123456789012345678901234567890.000000000000000000001", "precision round trip")
+
+	var null Decimal
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullDecimal(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var badType Decimal
+	err = json.Unmarshal(boolJSON, &badType)
+	if err == nil {
+		panic("err should not be nil")
+	}
+	assertNullDecimal(t, badType, "wrong type json")
+
+	var invalid Decimal
+	err = invalid.UnmarshalJSON(invalidJSON)
+	if _, ok := err.(*json.SyntaxError); !ok {
+		t.Errorf("expected json.SyntaxError, not %T", err)
+	}
+	assertNullDecimal(t, invalid, "invalid json")
+}
+
+func TestUnmarshalDecimalText(t *testing.T) {
+	var d Decimal
+	err := d.UnmarshalText([]byte("1234.5678"))
+	maybePanic(err)
+	assertDecimal(t, d, "UnmarshalText() decimal")
+
+	var blank Decimal
+	err = blank.UnmarshalText([]byte(""))
+	maybePanic(err)
+	assertNullDecimal(t, blank, "UnmarshalText() empty decimal")
+
+	for _, in := range []string{"1.2.3", "0x10", "1/3", "1e99999999"} {
+		var invalid Decimal
+		if err = invalid.UnmarshalText([]byte(in)); err == nil {
+			t.Errorf("expected error unmarshaling %q", in)
+		}
+		assertNullDecimal(t, invalid, "invalid text")
+	}
+}
+
+func TestMarshalDecimal(t *testing.T) {
+	d := DecimalFrom(decimalValue)
+	data, err := json.Marshal(d)
+	maybePanic(err)
+	assertJSONEquals(t, data, "1234.5678", "non-empty json marshal")
+
+	i := DecimalFrom(big.NewRat(-42, 1))
+	data, err = json.Marshal(i)
+	maybePanic(err)
+	assertJSONEquals(t, data, "-42", "integer json marshal")
+
+	DecimalQuoteJSON = true
+	data, err = json.Marshal(d)
+	DecimalQuoteJSON = false
+	maybePanic(err)
+	assertJSONEquals(t, data, `"1234.5678"`, "quoted json marshal")
+
+	// invalid values should be encoded as null
+	null := NewDecimal(nil, false, true)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+
+	third := DecimalFrom(big.NewRat(1, 3))
+	if _, err = json.Marshal(third); err == nil {
+		t.Error("expected error for a non-terminating decimal")
+	}
+}
+
+func TestMarshalDecimalText(t *testing.T) {
+	d := DecimalFrom(big.NewRat(1, 8))
+	data, err := d.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, "0.125", "non-empty text marshal")
+
+	// invalid values should be encoded as null
+	null := NewDecimal(nil, false, true)
+	data, err = null.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, "", "null text marshal")
+}
+
+func TestDecimalPointer(t *testing.T) {
+	d := DecimalFrom(decimalValue)
+	ptr := d.Ptr()
+	if ptr.Cmp(decimalValue) != 0 {
+		t.Errorf("bad %s decimal: %#v ≠ %s\n", "pointer", ptr, decimalValue)
+	}
+
+	null := NewDecimal(nil, false, true)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s decimal: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestDecimalIsZero(t *testing.T) {
+	d := DecimalFrom(new(big.Rat))
+	if d.IsZero() {
+		t.Errorf("IsZero() should be false")
+	}
+
+	null := NewDecimal(nil, false, true)
+	if !null.IsZero() {
+		t.Errorf("IsZero() should be true")
+	}
+}
+
+func TestDecimalSetValid(t *testing.T) {
+	change := NewDecimal(nil, false, false)
+	assertNullDecimal(t, change, "SetValid()")
+	change.SetValid(decimalValue)
+	assertDecimal(t, change, "SetValid()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+}
+
+func TestDecimalSetNull(t *testing.T) {
+	change := NewDecimal(nil, false, false)
+	change.SetValid(decimalValue)
+	change.SetNull()
+	assertNullDecimal(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.Decimal != nil {
+		t.Errorf("SetNull() should zero the value, got %v", change.Decimal)
+	}
+}
+
+func TestDecimalScan(t *testing.T) {
+	var d Decimal
+	err := d.Scan("1234.5678")
+	maybePanic(err)
+	assertDecimal(t, d, "scanned string")
+
+	var b Decimal
+	err = b.Scan([]byte("1234.5678"))
+	maybePanic(err)
+	assertDecimal(t, b, "scanned []byte")
+
+	var f Decimal
+	err = f.Scan(1234.5678)
+	maybePanic(err)
+	assertDecimal(t, f, "scanned float64")
+
+	var i Decimal
+	err = i.Scan(int64(42))
+	maybePanic(err)
+	if i.Decimal.Cmp(big.NewRat(42, 1)) != 0 || !i.Valid {
+		t.Errorf("bad scanned int64: %v", i.Decimal)
+	}
+
+	var null Decimal
+	err = null.Scan(nil)
+	maybePanic(err)
+	assertNullDecimal(t, null, "scanned null")
+
+	var wrong Decimal
+	err = wrong.Scan(true)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullDecimal(t, wrong, "scanned wrong type")
+
+	for _, in := range []interface{}{"abc", "0x10", []byte("1/3"), "1e99999999"} {
+		var bad Decimal
+		if err = bad.Scan(in); err == nil {
+			t.Errorf("expected error scanning %v", in)
+		}
+		assertNullDecimal(t, bad, "scanned bad string")
+	}
+}
+
+func TestDecimalValue(t *testing.T) {
+	d := DecimalFrom(decimalValue)
+	v, err := d.Value()
+	maybePanic(err)
+	if v != "1234.5678" {
+		t.Errorf("bad value: %#v", v)
+	}
+
+	null := NewDecimal(nil, false, true)
+	v, err = null.Value()
+	maybePanic(err)
+	if v != nil {
+		t.Errorf("bad null value: %#v", v)
+	}
+}
+
+func TestDecimalRandomize(t *testing.T) {
+	var d Decimal
+	d.Randomize(func() int64 { return 123456 }, "", false)
+	assertJSONEquals(t, []byte(d.Decimal.FloatString(2)), "1234.56", "randomized decimal")
+	if !d.Valid {
+		t.Error("should be valid")
+	}
+
+	d.Randomize(func() int64 { return 1 }, "", true)
+	assertNullDecimal(t, d, "randomized null")
+}
+
+func TestDecimalOr(t *testing.T) {
+	a := NewDecimal(big.NewRat(1, 2), true, true)
+	b := NewDecimal(big.NewRat(3, 4), true, true)
+	null := NewDecimal(nil, false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestDecimalGet(t *testing.T) {
+	d := DecimalFrom(new(big.Rat).Set(decimalValue))
+	v, ok := d.Get()
+	if !ok || v.Cmp(decimalValue) != 0 {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	v.SetInt64(1)
+	if d.Decimal.Cmp(decimalValue) != 0 {
+		t.Error("Get() should return a copy, but the original was modified:", d.Decimal)
+	}
+
+	var null Decimal
+	if v, ok := null.Get(); ok || v != nil {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
+func assertDecimal(t *testing.T, d Decimal, from string) {
+	if d.Decimal == nil || d.Decimal.Cmp(decimalValue) != 0 {
+		t.Errorf("bad %v decimal: %v ≠ %v\n", from, d.Decimal, decimalValue)
+	}
+	if !d.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullDecimal(t *testing.T, d Decimal, from string) {
+	if d.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
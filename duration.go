@@ -0,0 +1,202 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/volatiletech/null/v9/convert"
+)
+
+// Duration is a nullable time.Duration. It supports SQL and JSON serialization.
+// It is stored in SQL as integer nanoseconds and marshals to JSON in
+// time.Duration's string form, such as "1h30m0s".
+type Duration struct {
+	Duration time.Duration
+	Valid    bool
+	Set      bool
+}
+
+// NewDuration creates a new Duration
+func NewDuration(d time.Duration, valid, set bool) Duration {
+	return Duration{
+		Duration: d,
+		Valid:    valid,
+		Set:      set,
+	}
+}
+
+// DurationFrom creates a new Duration that will always be valid.
+func DurationFrom(d time.Duration) Duration {
+	return NewDuration(d, true, true)
+}
+
+// DurationFromPtr creates a new Duration that will be null if d is nil.
+func DurationFromPtr(d *time.Duration) Duration {
+	if d == nil {
+		return NewDuration(0, false, true)
+	}
+	return NewDuration(*d, true, true)
+}
+
+// DurationUnset creates a new Duration that is null and not Set, as for a
+// field absent from the input. IsSet reports false until a value or an
+// explicit null is unmarshaled into it, or it is changed with SetValid or
+// SetNull.
+func DurationUnset() Duration {
+	return Duration{}
+}
+
+func (d Duration) IsSet() bool {
+	return d.Set
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts both the string form and a number of nanoseconds.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	d.Set = true
+	if bytes.Equal(data, NullBytes) {
+		d.Valid = false
+		d.Duration = 0
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return d.UnmarshalText([]byte(s))
+	}
+
+	var n int64
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	d.Duration, d.Valid = time.Duration(n), true
+	return nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Duration) UnmarshalText(text []byte) error {
+	d.Set = true
+	if len(text) == 0 {
+		d.Valid = false
+		return nil
+	}
+	var err error
+	d.Duration, err = time.ParseDuration(string(text))
+	d.Valid = err == nil
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return NullBytes, nil
+	}
+	return json.Marshal(d.Duration.String())
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	if !d.Valid {
+		return []byte{}, nil
+	}
+	return []byte(d.Duration.String()), nil
+}
+
+// SetValid changes this Duration's value and also sets it to be non-null.
+func (d *Duration) SetValid(v time.Duration) {
+	d.Duration = v
+	d.Valid = true
+	d.Set = true
+}
+
+// SetNull changes this Duration's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (d *Duration) SetNull() {
+	d.Duration = 0
+	d.Valid = false
+	d.Set = true
+}
+
+// Ptr returns a pointer to this Duration's value, or a nil pointer if this Duration is null.
+func (d Duration) Ptr() *time.Duration {
+	if !d.Valid {
+		return nil
+	}
+	return &d.Duration
+}
+
+// IsZero returns true for invalid Durations, for potential future omitempty support.
+func (d Duration) IsZero() bool {
+	return !d.Valid
+}
+
+// Or returns this Duration if it is valid, or other if it is not.
+func (d Duration) Or(other Duration) Duration {
+	if d.Valid {
+		return d
+	}
+	return other
+}
+
+// Get returns this Duration's value and true, or the zero value and false if it is null.
+func (d Duration) Get() (time.Duration, bool) {
+	if !d.Valid {
+		return 0, false
+	}
+	return d.Duration, true
+}
+
+// Scan implements the Scanner interface.
+// Integers are read as nanoseconds and strings with time.ParseDuration.
+func (d *Duration) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
+	switch x := value.(type) {
+	case int64:
+		d.Duration = time.Duration(x)
+	case string:
+		d.Duration, err = time.ParseDuration(x)
+	case []byte:
+		d.Duration, err = time.ParseDuration(string(x))
+	case nil:
+		d.Duration, d.Valid, d.Set = 0, false, false
+		return nil
+	default:
+		var n int64
+		if err = convert.ConvertAssign(&n, value); err != nil {
+			err = fmt.Errorf("null: cannot scan type %T into null.Duration: %v", value, value)
+		}
+		d.Duration = time.Duration(n)
+	}
+	if err == nil {
+		d.Valid, d.Set = true, true
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+func (d Duration) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return int64(d.Duration), nil
+}
+
+// Randomize for sqlboiler
+func (d *Duration) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		d.Duration = 0
+		d.Valid = false
+	} else {
+		d.Duration = time.Duration(nextInt()%86400) * time.Second
+		d.Valid = true
+	}
+}
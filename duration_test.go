@@ -0,0 +1,256 @@
+package null
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var (
+	durationValue = 90 * time.Minute
+	durationJSON  = []byte(`"1h30m0s"`)
+)
+
+func TestDurationFrom(t *testing.T) {
+	d := DurationFrom(durationValue)
+	assertDuration(t, d, "DurationFrom()")
+
+	zero := DurationFrom(0)
+	if !zero.Valid {
+		t.Error("DurationFrom(0)", "is invalid, but should be valid")
+	}
+}
+
+func TestDurationFromPtr(t *testing.T) {
+	n := durationValue
+	d := DurationFromPtr(&n)
+	assertDuration(t, d, "DurationFromPtr()")
+
+	null := DurationFromPtr(nil)
+	assertNullDuration(t, null, "DurationFromPtr(nil)")
+}
+
+func TestDurationUnset(t *testing.T) {
+	v := DurationUnset()
+	assertNullDuration(t, v, "DurationUnset()")
+	if v.IsSet() {
+		t.Error("DurationUnset() should not be Set")
+	}
+}
+
+func TestUnmarshalDuration(t *testing.T) {
+	var d Duration
+	err := json.Unmarshal(durationJSON, &d)
+	maybePanic(err)
+	assertDuration(t, d, "string json")
+
+	var num Duration
+	err = json.Unmarshal([]byte(`5400000000000`), &num)
+	maybePanic(err)
+	assertDuration(t, num, "nanoseconds json")
+
+	var null Duration
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullDuration(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var badType Duration
+	err = json.Unmarshal(boolJSON, &badType)
+	if err == nil {
+		panic("err should not be nil")
+	}
+	assertNullDuration(t, badType, "wrong type json")
+
+	var badString Duration
+	err = json.Unmarshal([]byte(`"ninety minutes"`), &badString)
+	if err == nil {
+		panic("err should not be nil")
+	}
+	assertNullDuration(t, badString, "bad string json")
+
+	var invalid Duration
+	err = invalid.UnmarshalJSON(invalidJSON)
+	if _, ok := err.(*json.SyntaxError); !ok {
+		t.Errorf("expected json.SyntaxError, not %T", err)
+	}
+	assertNullDuration(t, invalid, "invalid json")
+}
+
+func TestTextUnmarshalDuration(t *testing.T) {
+	var d Duration
+	err := d.UnmarshalText([]byte("1h30m"))
+	maybePanic(err)
+	assertDuration(t, d, "UnmarshalText() duration")
+
+	var blank Duration
+	err = blank.UnmarshalText([]byte(""))
+	maybePanic(err)
+	assertNullDuration(t, blank, "UnmarshalText() empty duration")
+}
+
+func TestMarshalDuration(t *testing.T) {
+	d := DurationFrom(durationValue)
+	data, err := json.Marshal(d)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(durationJSON), "non-empty json marshal")
+
+	// invalid values should be encoded as null
+	null := NewDuration(0, false, true)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+}
+
+func TestMarshalDurationText(t *testing.T) {
+	d := DurationFrom(durationValue)
+	data, err := d.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, "1h30m0s", "non-empty text marshal")
+
+	// invalid values should be encoded as null
+	null := NewDuration(0, false, true)
+	data, err = null.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, "", "null text marshal")
+}
+
+func TestDurationPointer(t *testing.T) {
+	d := DurationFrom(durationValue)
+	ptr := d.Ptr()
+	if *ptr != durationValue {
+		t.Errorf("bad %s duration: %#v ≠ %v\n", "pointer", ptr, durationValue)
+	}
+
+	null := NewDuration(0, false, true)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s duration: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestDurationIsZero(t *testing.T) {
+	d := DurationFrom(durationValue)
+	if d.IsZero() {
+		t.Errorf("IsZero() should be false")
+	}
+
+	null := NewDuration(0, false, true)
+	if !null.IsZero() {
+		t.Errorf("IsZero() should be true")
+	}
+}
+
+func TestDurationSetValid(t *testing.T) {
+	change := NewDuration(0, false, true)
+	assertNullDuration(t, change, "SetValid()")
+	change.SetValid(durationValue)
+	assertDuration(t, change, "SetValid()")
+}
+
+func TestDurationSetNull(t *testing.T) {
+	change := NewDuration(0, false, true)
+	change.SetValid(durationValue)
+	change.SetNull()
+	assertNullDuration(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.Duration != 0 {
+		t.Errorf("SetNull() should zero the value, got %v", change.Duration)
+	}
+}
+
+func TestDurationScanValue(t *testing.T) {
+	for _, in := range []interface{}{int64(durationValue), "1h30m", []byte("90m")} {
+		var d Duration
+		err := d.Scan(in)
+		maybePanic(err)
+		assertDuration(t, d, "scanned duration")
+		if v, err := d.Value(); v != int64(durationValue) || err != nil {
+			t.Error("bad value or err:", v, err)
+		}
+	}
+
+	var small Duration
+	err := small.Scan(int32(5))
+	maybePanic(err)
+	if !small.Valid || small.Duration != 5 {
+		t.Errorf("bad scanned int32 duration: %v", small.Duration)
+	}
+
+	var null Duration
+	err = null.Scan(nil)
+	maybePanic(err)
+	assertNullDuration(t, null, "scanned null")
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	for _, in := range []interface{}{"ninety minutes", true} {
+		var wrong Duration
+		if err = wrong.Scan(in); err == nil {
+			t.Errorf("Scan(%v) should fail", in)
+		}
+		assertNullDuration(t, wrong, "scanned wrong")
+	}
+}
+
+func TestDurationRandomize(t *testing.T) {
+	var seed int64
+	nextInt := func() int64 {
+		seed++
+		return seed
+	}
+
+	var d Duration
+	d.Randomize(nextInt, "bigint", false)
+	if !d.Valid || d.Duration == 0 {
+		t.Errorf("Randomize() should produce a valid, non-zero duration: %v", d.Duration)
+	}
+
+	d.Randomize(nextInt, "bigint", true)
+	assertNullDuration(t, d, "Randomize() null")
+}
+
+func TestDurationOr(t *testing.T) {
+	a := NewDuration(time.Second, true, true)
+	b := NewDuration(time.Minute, true, true)
+	null := NewDuration(0, false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestDurationGet(t *testing.T) {
+	if v, ok := DurationFrom(time.Second).Get(); !ok || v != time.Second {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null Duration
+	if v, ok := null.Get(); ok || v != 0 {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
+func assertDuration(t *testing.T, d Duration, from string) {
+	if d.Duration != durationValue {
+		t.Errorf("bad %s duration: %v ≠ %v\n", from, d.Duration, durationValue)
+	}
+	if !d.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullDuration(t *testing.T, d Duration, from string) {
+	if d.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
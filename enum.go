@@ -0,0 +1,225 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/volatiletech/null/v9/convert"
+)
+
+// enumValues holds the allowed values of each enum type, keyed by the type.
+var enumValues = map[reflect.Type]map[string]struct{}{}
+
+// RegisterEnum sets the values allowed for Enum[T]. Registering T again
+// replaces its values.
+//
+// RegisterEnum is not safe for concurrent use with itself or with the Enum
+// methods; call it during initialization, e.g. from an init func.
+func RegisterEnum[T ~string](allowed ...T) {
+	values := make(map[string]struct{}, len(allowed))
+	for _, v := range allowed {
+		values[string(v)] = struct{}{}
+	}
+	enumValues[reflect.TypeOf(T(""))] = values
+}
+
+// Enum is a nullable string enum T, limited to the values registered for T
+// with RegisterEnum. It supports SQL and JSON serialization.
+// Scan, UnmarshalJSON and EnumFrom reject other values, and MarshalJSON and
+// Value refuse to write them.
+type Enum[T ~string] struct {
+	Val   T
+	Valid bool
+	Set   bool
+}
+
+// NewEnum creates a new Enum. It does not check v; use EnumFrom for that.
+func NewEnum[T ~string](v T, valid, set bool) Enum[T] {
+	return Enum[T]{
+		Val:   v,
+		Valid: valid,
+		Set:   set,
+	}
+}
+
+// EnumFrom creates a new Enum that will always be valid, or returns an
+// error if v is not one of the values registered for T.
+func EnumFrom[T ~string](v T) (Enum[T], error) {
+	if err := checkEnum(v); err != nil {
+		return Enum[T]{}, err
+	}
+	return NewEnum(v, true, true), nil
+}
+
+// EnumFromPtr creates a new Enum that will be null if v is nil, or returns
+// an error if v is not one of the values registered for T.
+func EnumFromPtr[T ~string](v *T) (Enum[T], error) {
+	if v == nil {
+		return NewEnum(T(""), false, true), nil
+	}
+	return EnumFrom(*v)
+}
+
+// EnumUnset creates a new Enum that is null and not Set, as for a field
+// absent from the input. IsSet reports false until a value or an explicit
+// null is unmarshaled into it, or it is changed with SetValid or SetNull.
+func EnumUnset[T ~string]() Enum[T] {
+	return Enum[T]{}
+}
+
+func checkEnum[T ~string](v T) error {
+	values, ok := enumValues[reflect.TypeOf(v)]
+	if !ok {
+		return fmt.Errorf("null: no values registered for null.Enum[%T]", v)
+	}
+	if _, ok := values[string(v)]; !ok {
+		return fmt.Errorf("null: invalid value %q for null.Enum[%T]", string(v), v)
+	}
+	return nil
+}
+
+func (e Enum[T]) IsSet() bool {
+	return e.Set
+}
+
+// Check returns an error if this Enum is valid but holds a value that is
+// not registered for T.
+func (e Enum[T]) Check() error {
+	if !e.Valid {
+		return nil
+	}
+	return checkEnum(e.Val)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *Enum[T]) UnmarshalJSON(data []byte) error {
+	e.Set = true
+	if bytes.Equal(data, NullBytes) {
+		e.Val, e.Valid = "", false
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return e.setChecked(T(s))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e Enum[T]) MarshalJSON() ([]byte, error) {
+	if !e.Valid {
+		return NullBytes, nil
+	}
+	if err := checkEnum(e.Val); err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(e.Val))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// Empty text is read as a null Enum.
+func (e *Enum[T]) UnmarshalText(text []byte) error {
+	e.Set = true
+	if len(text) == 0 {
+		e.Val, e.Valid = "", false
+		return nil
+	}
+	return e.setChecked(T(text))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (e Enum[T]) MarshalText() ([]byte, error) {
+	if !e.Valid {
+		return []byte{}, nil
+	}
+	if err := checkEnum(e.Val); err != nil {
+		return nil, err
+	}
+	return []byte(e.Val), nil
+}
+
+// setChecked sets this Enum to v if it is allowed, and leaves it otherwise.
+func (e *Enum[T]) setChecked(v T) error {
+	if err := checkEnum(v); err != nil {
+		return err
+	}
+	e.Val, e.Valid, e.Set = v, true, true
+	return nil
+}
+
+// SetValid changes this Enum's value and also sets it to be non-null.
+// It does not check v; MarshalJSON and Value will reject a bad one.
+func (e *Enum[T]) SetValid(v T) {
+	e.Val = v
+	e.Valid = true
+	e.Set = true
+}
+
+// SetNull changes this Enum's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (e *Enum[T]) SetNull() {
+	e.Val = ""
+	e.Valid = false
+	e.Set = true
+}
+
+// Ptr returns a pointer to this Enum's value, or a nil pointer if this Enum is null.
+func (e Enum[T]) Ptr() *T {
+	if !e.Valid {
+		return nil
+	}
+	return &e.Val
+}
+
+// IsZero returns true for null Enums, for potential future omitempty support.
+func (e Enum[T]) IsZero() bool {
+	return !e.Valid
+}
+
+// Or returns this Enum if it is valid, or other if it is not.
+func (e Enum[T]) Or(other Enum[T]) Enum[T] {
+	if e.Valid {
+		return e
+	}
+	return other
+}
+
+// Get returns this Enum's value and true, or the zero value and false if it is null.
+func (e Enum[T]) Get() (T, bool) {
+	if !e.Valid {
+		return "", false
+	}
+	return e.Val, true
+}
+
+// Scan implements the Scanner interface.
+func (e *Enum[T]) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		e.Val, e.Valid, e.Set = "", false, false
+		return nil
+	}
+	var s string
+	if err := convert.ConvertAssign(&s, value); err != nil {
+		return err
+	}
+	return e.setChecked(T(s))
+}
+
+// Value implements the driver Valuer interface.
+func (e Enum[T]) Value() (driver.Value, error) {
+	if !e.Valid {
+		return nil, nil
+	}
+	if err := checkEnum(e.Val); err != nil {
+		return nil, err
+	}
+	return string(e.Val), nil
+}
@@ -0,0 +1,185 @@
+package null
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type status string
+
+const (
+	statusActive   status = "active"
+	statusDisabled status = "disabled"
+)
+
+// unregistered is an enum type that is never passed to RegisterEnum.
+type unregistered string
+
+func init() {
+	RegisterEnum(statusActive, statusDisabled)
+}
+
+func TestEnumFrom(t *testing.T) {
+	e, err := EnumFrom(statusActive)
+	maybePanic(err)
+	assertEnum(t, e, statusActive, "EnumFrom()")
+
+	if _, err := EnumFrom(status("deleted")); err == nil || err.Error() != `null: invalid value "deleted" for null.Enum[null.status]` {
+		t.Errorf("EnumFrom() should reject an unknown value, got %v", err)
+	}
+	if _, err := EnumFrom(unregistered("x")); err == nil || err.Error() != "null: no values registered for null.Enum[null.unregistered]" {
+		t.Errorf("EnumFrom() should reject an unregistered type, got %v", err)
+	}
+
+	v := statusDisabled
+	e, err = EnumFromPtr(&v)
+	maybePanic(err)
+	assertEnum(t, e, statusDisabled, "EnumFromPtr()")
+
+	null, err := EnumFromPtr[status](nil)
+	maybePanic(err)
+	assertNullEnum(t, null, "EnumFromPtr(nil)")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+}
+
+func TestEnumUnset(t *testing.T) {
+	e := EnumUnset[status]()
+	assertNullEnum(t, e, "EnumUnset()")
+	if e.IsSet() {
+		t.Error("EnumUnset() should not be Set")
+	}
+}
+
+func TestEnumJSON(t *testing.T) {
+	var s struct {
+		Status Enum[status] `json:"status"`
+		Null   Enum[status] `json:"null"`
+	}
+	err := json.Unmarshal([]byte(`{"status":"active","null":null}`), &s)
+	maybePanic(err)
+	assertEnum(t, s.Status, statusActive, "unmarshaled enum")
+	assertNullEnum(t, s.Null, "unmarshaled null")
+	if !s.Null.Set {
+		t.Error("unmarshaled null should be Set")
+	}
+
+	data, err := json.Marshal(s)
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"status":"active","null":null}`, "enum json marshal")
+
+	var bad Enum[status]
+	if err := json.Unmarshal([]byte(`"deleted"`), &bad); err == nil {
+		t.Error("UnmarshalJSON() should reject an unknown value")
+	}
+	assertNullEnum(t, bad, "unmarshaled unknown value")
+	if err := json.Unmarshal([]byte(`1`), &bad); err == nil {
+		t.Error("UnmarshalJSON() should reject a number")
+	}
+
+	out := NewEnum(status("deleted"), true, true)
+	if _, err := json.Marshal(out); err == nil {
+		t.Error("MarshalJSON() should reject an unknown value")
+	}
+	if err := out.Check(); err == nil {
+		t.Error("Check() should reject an unknown value")
+	}
+	if err := NewEnum(status("deleted"), false, true).Check(); err != nil {
+		t.Errorf("Check() of a null Enum should pass, got %v", err)
+	}
+}
+
+func TestEnumText(t *testing.T) {
+	var e Enum[status]
+	err := e.UnmarshalText([]byte("disabled"))
+	maybePanic(err)
+	assertEnum(t, e, statusDisabled, "UnmarshalText()")
+
+	data, err := e.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, "disabled", "MarshalText()")
+
+	err = e.UnmarshalText([]byte{})
+	maybePanic(err)
+	assertNullEnum(t, e, "UnmarshalText() empty")
+
+	if err := e.UnmarshalText([]byte("deleted")); err == nil {
+		t.Error("UnmarshalText() should reject an unknown value")
+	}
+}
+
+func TestEnumScanValue(t *testing.T) {
+	for _, in := range []interface{}{"active", []byte("active")} {
+		var e Enum[status]
+		err := e.Scan(in)
+		maybePanic(err)
+		assertEnum(t, e, statusActive, "scanned enum")
+		if v, err := e.Value(); v != "active" || err != nil {
+			t.Error("bad value or err:", v, err)
+		}
+	}
+
+	var null Enum[status]
+	err := null.Scan(nil)
+	maybePanic(err)
+	assertNullEnum(t, null, "scanned null")
+	if null.Set {
+		t.Error("scanned null should not be Set")
+	}
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	var bad Enum[status]
+	if err := bad.Scan("deleted"); err == nil {
+		t.Error("Scan() should reject an unknown value")
+	}
+	assertNullEnum(t, bad, "scanned unknown value")
+
+	if _, err := NewEnum(status("deleted"), true, true).Value(); err == nil {
+		t.Error("Value() should reject an unknown value")
+	}
+}
+
+func TestEnumHelpers(t *testing.T) {
+	e := NewEnum(statusActive, true, true)
+	if ptr := e.Ptr(); ptr == nil || *ptr != statusActive {
+		t.Errorf("bad Ptr() result: %v", ptr)
+	}
+	if v, ok := e.Get(); !ok || v != statusActive {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+
+	null := NewEnum(statusActive, false, true)
+	if null.Ptr() != nil || !null.IsZero() || e.IsZero() {
+		t.Error("bad Ptr() or IsZero() result for null")
+	}
+	if v, ok := null.Get(); ok || v != "" {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+	assertEnum(t, null.Or(e), statusActive, "Or()")
+
+	e.SetNull()
+	assertNullEnum(t, e, "SetNull()")
+	if !e.IsSet() {
+		t.Error("should be Set")
+	}
+	e.SetValid(statusDisabled)
+	assertEnum(t, e, statusDisabled, "SetValid()")
+}
+
+func assertEnum(t *testing.T, e Enum[status], v status, from string) {
+	if e.Val != v {
+		t.Errorf("bad %s value: %q ≠ %q\n", from, e.Val, v)
+	}
+	if !e.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullEnum(t *testing.T, e Enum[status], from string) {
+	if e.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
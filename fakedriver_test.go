@@ -0,0 +1,87 @@
+package null
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+)
+
+// fakeDriver is a database/sql driver holding a single value, for tests
+// that round-trip a type through sql.DB. Any Exec stores its first argument
+// as the driver received it, and any Query returns it as a one-row result.
+type fakeDriver struct {
+	stored driver.Value
+}
+
+var fake = &fakeDriver{}
+
+func init() {
+	sql.Register("null-fake", fake)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return fakeConn{d}, nil
+}
+
+type fakeConn struct {
+	d *fakeDriver
+}
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt(c), nil
+}
+
+func (c fakeConn) Close() error {
+	return nil
+}
+
+func (c fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("fake driver: transactions are not supported")
+}
+
+type fakeStmt struct {
+	d *fakeDriver
+}
+
+func (s fakeStmt) Close() error {
+	return nil
+}
+
+func (s fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if len(args) != 1 {
+		return nil, errors.New("fake driver: Exec takes exactly one argument")
+	}
+	s.d.stored = args[0]
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{value: s.d.stored}, nil
+}
+
+type fakeRows struct {
+	value driver.Value
+	done  bool
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"value"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
@@ -38,6 +38,14 @@ func Float32FromPtr(f *float32) Float32 {
 	return NewFloat32(*f, true, true)
 }
 
+// Float32Unset creates a new Float32 that is null and not Set, as for a
+// field absent from the input. IsSet reports false until a value or an
+// explicit null is unmarshaled into it, or it is changed with SetValid or
+// SetNull.
+func Float32Unset() Float32 {
+	return Float32{}
+}
+
 func (f Float32) IsSet() bool {
 	return f.Set
 }
@@ -100,6 +108,14 @@ func (f *Float32) SetValid(n float32) {
 	f.Set = true
 }
 
+// SetNull changes this Float32's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (f *Float32) SetNull() {
+	f.Float32 = 0
+	f.Valid = false
+	f.Set = true
+}
+
 // Ptr returns a pointer to this Float32's value, or a nil pointer if this Float32 is null.
 func (f Float32) Ptr() *float32 {
 	if !f.Valid {
@@ -113,8 +129,28 @@ func (f Float32) IsZero() bool {
 	return !f.Valid
 }
 
+// Or returns this Float32 if it is valid, or other if it is not.
+func (f Float32) Or(other Float32) Float32 {
+	if f.Valid {
+		return f
+	}
+	return other
+}
+
+// Get returns this Float32's value and true, or the zero value and false if it is null.
+func (f Float32) Get() (float32, bool) {
+	if !f.Valid {
+		return 0, false
+	}
+	return f.Float32, true
+}
+
 // Scan implements the Scanner interface.
 func (f *Float32) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
 	if value == nil {
 		f.Float32, f.Valid, f.Set = 0, false, false
 		return nil
@@ -29,6 +29,14 @@ func TestFloat32FromPtr(t *testing.T) {
 	assertNullFloat32(t, null, "Float32FromPtr(nil)")
 }
 
+func TestFloat32Unset(t *testing.T) {
+	v := Float32Unset()
+	assertNullFloat32(t, v, "Float32Unset()")
+	if v.IsSet() {
+		t.Error("Float32Unset() should not be Set")
+	}
+}
+
 func TestUnmarshalFloat32(t *testing.T) {
 	var f Float32
 	err := json.Unmarshal(float32JSON, &f)
@@ -133,6 +141,19 @@ func TestFloat32SetValid(t *testing.T) {
 	assertFloat32(t, change, "SetValid()")
 }
 
+func TestFloat32SetNull(t *testing.T) {
+	change := NewFloat32(0, false, true)
+	change.SetValid(1.2345)
+	change.SetNull()
+	assertNullFloat32(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.Float32 != 0 {
+		t.Errorf("SetNull() should zero the value, got %v", change.Float32)
+	}
+}
+
 func TestFloat32Scan(t *testing.T) {
 	var f Float32
 	err := f.Scan(1.2345)
@@ -145,6 +166,31 @@ func TestFloat32Scan(t *testing.T) {
 	assertNullFloat32(t, null, "scanned null")
 }
 
+func TestFloat32Or(t *testing.T) {
+	a := NewFloat32(1.5, true, true)
+	b := NewFloat32(2.5, true, true)
+	null := NewFloat32(0, false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestFloat32Get(t *testing.T) {
+	if v, ok := Float32From(1.5).Get(); !ok || v != float32(1.5) {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null Float32
+	if v, ok := null.Get(); ok || v != 0 {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
 func assertFloat32(t *testing.T, f Float32, from string) {
 	if f.Float32 != 1.2345 {
 		t.Errorf("bad %s float32: %f ≠ %f\n", from, f.Float32, 1.2345)
@@ -38,6 +38,14 @@ func Float64FromPtr(f *float64) Float64 {
 	return NewFloat64(*f, true, true)
 }
 
+// Float64Unset creates a new Float64 that is null and not Set, as for a
+// field absent from the input. IsSet reports false until a value or an
+// explicit null is unmarshaled into it, or it is changed with SetValid or
+// SetNull.
+func Float64Unset() Float64 {
+	return Float64{}
+}
+
 func (f Float64) IsSet() bool {
 	return f.Set
 }
@@ -95,6 +103,14 @@ func (f *Float64) SetValid(n float64) {
 	f.Set = true
 }
 
+// SetNull changes this Float64's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (f *Float64) SetNull() {
+	f.Float64 = 0
+	f.Valid = false
+	f.Set = true
+}
+
 // Ptr returns a pointer to this Float64's value, or a nil pointer if this Float64 is null.
 func (f Float64) Ptr() *float64 {
 	if !f.Valid {
@@ -108,8 +124,28 @@ func (f Float64) IsZero() bool {
 	return !f.Valid
 }
 
+// Or returns this Float64 if it is valid, or other if it is not.
+func (f Float64) Or(other Float64) Float64 {
+	if f.Valid {
+		return f
+	}
+	return other
+}
+
+// Get returns this Float64's value and true, or the zero value and false if it is null.
+func (f Float64) Get() (float64, bool) {
+	if !f.Valid {
+		return 0, false
+	}
+	return f.Float64, true
+}
+
 // Scan implements the Scanner interface.
 func (f *Float64) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
 	if value == nil {
 		f.Float64, f.Valid, f.Set = 0, false, false
 		return nil
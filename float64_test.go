@@ -29,6 +29,14 @@ func TestFloat64FromPtr(t *testing.T) {
 	assertNullFloat64(t, null, "Float64FromPtr(nil)")
 }
 
+func TestFloat64Unset(t *testing.T) {
+	v := Float64Unset()
+	assertNullFloat64(t, v, "Float64Unset()")
+	if v.IsSet() {
+		t.Error("Float64Unset() should not be Set")
+	}
+}
+
 func TestUnmarshalFloat64(t *testing.T) {
 	var f Float64
 	err := json.Unmarshal(float64JSON, &f)
@@ -133,6 +141,19 @@ func TestFloat64SetValid(t *testing.T) {
 	assertFloat64(t, change, "SetValid()")
 }
 
+func TestFloat64SetNull(t *testing.T) {
+	change := NewFloat64(0, false, true)
+	change.SetValid(1.2345)
+	change.SetNull()
+	assertNullFloat64(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.Float64 != 0 {
+		t.Errorf("SetNull() should zero the value, got %v", change.Float64)
+	}
+}
+
 func TestFloat64Scan(t *testing.T) {
 	var f Float64
 	err := f.Scan(1.2345)
@@ -145,6 +166,31 @@ func TestFloat64Scan(t *testing.T) {
 	assertNullFloat64(t, null, "scanned null")
 }
 
+func TestFloat64Or(t *testing.T) {
+	a := NewFloat64(1.5, true, true)
+	b := NewFloat64(2.5, true, true)
+	null := NewFloat64(0, false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestFloat64Get(t *testing.T) {
+	if v, ok := Float64From(1.5).Get(); !ok || v != 1.5 {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null Float64
+	if v, ok := null.Get(); ok || v != 0 {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
 func assertFloat64(t *testing.T, f Float64, from string) {
 	if f.Float64 != 1.2345 {
 		t.Errorf("bad %s float64: %f ≠ %f\n", from, f.Float64, 1.2345)
@@ -0,0 +1,206 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/volatiletech/null/v9/convert"
+)
+
+// Null is a nullable T. It supports SQL and JSON serialization.
+// JSON is handled by encoding/json on Val, and SQL scanning goes through
+// the same conversions as the concrete types in this package.
+type Null[T any] struct {
+	Val   T
+	Valid bool
+	Set   bool
+}
+
+// NewNull creates a new Null
+func NewNull[T any](v T, valid, set bool) Null[T] {
+	return Null[T]{
+		Val:   v,
+		Valid: valid,
+		Set:   set,
+	}
+}
+
+// From creates a new Null that will always be valid.
+func From[T any](v T) Null[T] {
+	return NewNull(v, true, true)
+}
+
+// FromPtr creates a new Null that will be null if v is nil.
+func FromPtr[T any](v *T) Null[T] {
+	if v == nil {
+		var zero T
+		return NewNull(zero, false, true)
+	}
+	return NewNull(*v, true, true)
+}
+
+// Unset creates a new Null that is null and not Set, as for a field absent
+// from the input. IsSet reports false until a value or an explicit null is
+// unmarshaled into it, or it is changed with SetValid or SetNull.
+func Unset[T any]() Null[T] {
+	return Null[T]{}
+}
+
+func (n Null[T]) IsSet() bool {
+	return n.Set
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *Null[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if bytes.Equal(data, NullBytes) {
+		var zero T
+		n.Val, n.Valid = zero, false
+		return nil
+	}
+
+	if err := json.Unmarshal(data, &n.Val); err != nil {
+		return err
+	}
+
+	n.Valid = true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return NullBytes, nil
+	}
+	return json.Marshal(n.Val)
+}
+
+// SetValid changes this Null's value and also sets it to be non-null.
+func (n *Null[T]) SetValid(v T) {
+	n.Val = v
+	n.Valid = true
+	n.Set = true
+}
+
+// SetNull changes this Null's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (n *Null[T]) SetNull() {
+	var zero T
+	n.Val, n.Valid, n.Set = zero, false, true
+}
+
+// Ptr returns a pointer to this Null's value, or a nil pointer if this Null is null.
+func (n Null[T]) Ptr() *T {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Val
+}
+
+// PtrFrom returns a pointer to a copy of n's value, or nil if n is null. It
+// is the inverse of FromPtr, for code moving between Null[T] and *T fields.
+func PtrFrom[T any](n Null[T]) *T {
+	return n.Ptr()
+}
+
+// IsZero returns true for invalid Nulls, for potential future omitempty support.
+func (n Null[T]) IsZero() bool {
+	return !n.Valid
+}
+
+// Or returns this Null if it is valid, or other if it is not.
+// Calls can be chained to fall through several layers of defaults.
+func (n Null[T]) Or(other Null[T]) Null[T] {
+	if n.Valid {
+		return n
+	}
+	return other
+}
+
+// Coalesce returns the first valid Null in vals,
+// or a zero, invalid Null if none of them are valid.
+func Coalesce[T any](vals ...Null[T]) Null[T] {
+	for _, v := range vals {
+		if v.Valid {
+			return v
+		}
+	}
+	return Null[T]{}
+}
+
+// ordered is satisfied by the types that support the < operator. It mirrors
+// cmp.Ordered, which needs Go 1.21.
+type ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+// Compare returns -1, 0 or 1 as a is less than, equal to, or greater than
+// b, for sorting with slices.SortFunc. Nulls sort before valid values if
+// nullsFirst is true, and after them otherwise; two nulls are equal. Valid
+// values are ordered as cmp.Compare orders them, with NaN first.
+func Compare[T ordered](a, b Null[T], nullsFirst bool) int {
+	if c, ok := compareNulls(a.Valid, b.Valid, nullsFirst); ok {
+		return c
+	}
+	x, y := a.Val, b.Val
+	xNaN, yNaN := x != x, y != y
+	switch {
+	case xNaN && yNaN:
+		return 0
+	case xNaN || x < y:
+		return -1
+	case yNaN || x > y:
+		return 1
+	}
+	return 0
+}
+
+// compareNulls orders a and b by their Valid flags alone, and returns false
+// if both are valid, leaving their values to decide.
+func compareNulls(aValid, bValid, nullsFirst bool) (int, bool) {
+	switch {
+	case aValid && bValid:
+		return 0, false
+	case !aValid && !bValid:
+		return 0, true
+	case !aValid == nullsFirst:
+		return -1, true
+	}
+	return 1, true
+}
+
+// Get returns this Null's value and true, or the zero value and false if it is null.
+func (n Null[T]) Get() (T, bool) {
+	if !n.Valid {
+		var zero T
+		return zero, false
+	}
+	return n.Val, true
+}
+
+// Scan implements the Scanner interface.
+func (n *Null[T]) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		var zero T
+		n.Val, n.Valid, n.Set = zero, false, false
+		return nil
+	}
+	n.Valid, n.Set = true, true
+	return convert.ConvertAssign(&n.Val, value)
+}
+
+// Value implements the driver Valuer interface.
+func (n Null[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(n.Val)
+}
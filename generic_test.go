@@ -0,0 +1,300 @@
+package null
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"testing"
+)
+
+type point struct {
+	X, Y int
+}
+
+func TestNullFrom(t *testing.T) {
+	i := From(12345)
+	assertNull(t, i, 12345, "From()")
+
+	s := From("")
+	assertNull(t, s, "", "From() blank string")
+}
+
+func TestNullFromPtr(t *testing.T) {
+	n := 12345
+	i := FromPtr(&n)
+	assertNull(t, i, 12345, "FromPtr()")
+
+	null := FromPtr[int](nil)
+	assertNullNull(t, null, "FromPtr(nil)")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+}
+
+func TestUnset(t *testing.T) {
+	v := Unset[int]()
+	assertNullNull(t, v, "Unset()")
+	if v.IsSet() {
+		t.Error("Unset() should not be Set")
+	}
+
+	// Fields keep their unset state when omitted, and become Set when sent
+	// as null.
+	patch := struct {
+		Name Null[string] `json:"name"`
+		Age  Null[int]    `json:"age"`
+	}{Unset[string](), Unset[int]()}
+	err := json.Unmarshal([]byte(`{"age":null}`), &patch)
+	maybePanic(err)
+	if patch.Name.IsSet() || !patch.Age.IsSet() || patch.Age.Valid {
+		t.Errorf("bad PATCH state: %#v", patch)
+	}
+}
+
+func TestUnmarshalNull(t *testing.T) {
+	var i Null[int64]
+	err := json.Unmarshal(intJSON, &i)
+	maybePanic(err)
+	assertNull(t, i, 12345, "int json")
+
+	var p Null[point]
+	err = json.Unmarshal([]byte(`{"X":1,"Y":2}`), &p)
+	maybePanic(err)
+	assertNull(t, p, point{1, 2}, "struct json")
+
+	var null Null[string]
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullNull(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var badType Null[int]
+	err = json.Unmarshal(boolJSON, &badType)
+	if err == nil {
+		panic("err should not be nil")
+	}
+	assertNullNull(t, badType, "wrong type json")
+
+	var invalid Null[int]
+	err = invalid.UnmarshalJSON(invalidJSON)
+	if _, ok := err.(*json.SyntaxError); !ok {
+		t.Errorf("expected json.SyntaxError, not %T", err)
+	}
+	assertNullNull(t, invalid, "invalid json")
+}
+
+func TestMarshalNull(t *testing.T) {
+	i := From(12345)
+	data, err := json.Marshal(i)
+	maybePanic(err)
+	assertJSONEquals(t, data, "12345", "non-empty json marshal")
+
+	p := From(point{1, 2})
+	data, err = json.Marshal(p)
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"X":1,"Y":2}`, "struct json marshal")
+
+	// invalid values should be encoded as null
+	null := NewNull("hello", false, true)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+}
+
+func TestNullSetValid(t *testing.T) {
+	change := NewNull(0, false, false)
+	assertNullNull(t, change, "SetValid()")
+	change.SetValid(12345)
+	assertNull(t, change, 12345, "SetValid()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+}
+
+func TestNullSetNull(t *testing.T) {
+	change := From(point{1, 2})
+	change.SetNull()
+	assertNullNull(t, change, "SetNull()")
+	if change.Val != (point{}) {
+		t.Errorf("SetNull() should zero the value, got %#v", change.Val)
+	}
+	if !change.IsSet() {
+		t.Error("SetNull() should keep the Null Set")
+	}
+
+	unset := Unset[int]()
+	unset.SetNull()
+	if !unset.IsSet() {
+		t.Error("SetNull() should mark an unset Null as Set")
+	}
+}
+
+func TestNullPointer(t *testing.T) {
+	i := From(12345)
+	ptr := i.Ptr()
+	if *ptr != 12345 {
+		t.Errorf("bad %s value: %#v ≠ %d\n", "pointer", ptr, 12345)
+	}
+
+	null := NewNull(0, false, true)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s value: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestPtrFrom(t *testing.T) {
+	p := &point{X: 1, Y: 2}
+	n := FromPtr(p)
+	assertNull(t, n, point{1, 2}, "FromPtr() struct")
+
+	back := PtrFrom(n)
+	if back == nil || *back != *p {
+		t.Errorf("bad PtrFrom() result: %#v", back)
+	}
+	if back == p {
+		t.Error("PtrFrom() should point to a copy of the value")
+	}
+
+	null := FromPtr[point](nil)
+	assertNullNull(t, null, "FromPtr(nil) struct")
+	if PtrFrom(null) != nil {
+		t.Error("PtrFrom() of a null should be nil")
+	}
+	if PtrFrom(NewNull(point{1, 2}, false, true)) != nil {
+		t.Error("PtrFrom() of an invalid value should be nil")
+	}
+}
+
+func TestNullIsZero(t *testing.T) {
+	i := From(0)
+	if i.IsZero() {
+		t.Errorf("IsZero() should be false")
+	}
+
+	null := NewNull(0, false, true)
+	if !null.IsZero() {
+		t.Errorf("IsZero() should be true")
+	}
+}
+
+func TestNullScanValue(t *testing.T) {
+	var i Null[int32]
+	err := i.Scan(int64(12345))
+	maybePanic(err)
+	assertNull(t, i, int32(12345), "scanned int")
+	if v, err := i.Value(); v != int64(12345) || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	var s Null[string]
+	err = s.Scan([]byte("hello"))
+	maybePanic(err)
+	assertNull(t, s, "hello", "scanned string")
+
+	var null Null[int]
+	err = null.Scan(nil)
+	maybePanic(err)
+	assertNullNull(t, null, "scanned null")
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	if _, err = From(point{}).Value(); err == nil {
+		t.Error("expected error for a value the driver cannot represent")
+	}
+}
+
+func TestNullOr(t *testing.T) {
+	override := NewNull(0, false, true)
+	config := NewNull(0, false, false)
+	fallback := From(8080)
+	assertNull(t, override.Or(config).Or(fallback), 8080, "Or() fallback")
+	assertNull(t, From(443).Or(fallback), 443, "Or() valid receiver")
+	assertNullNull(t, override.Or(config), "Or() of two nulls")
+}
+
+func TestCoalesce(t *testing.T) {
+	env := NewNull(0, false, true)
+	file := From(8080)
+	assertNull(t, Coalesce(env, file, From(80)), 8080, "Coalesce()")
+
+	none := Coalesce(env, NewNull(0, false, false))
+	assertNullNull(t, none, "Coalesce() of nulls")
+	if none.Set {
+		t.Error("Coalesce() of nulls should not be Set")
+	}
+	assertNullNull(t, Coalesce[string](), "Coalesce() with no values")
+}
+
+func TestCompare(t *testing.T) {
+	one, two := From(1), From(2)
+	null := NewNull(5, false, true)
+	tests := []struct {
+		a, b       Null[int]
+		nullsFirst bool
+		want       int
+	}{
+		{one, two, false, -1},
+		{two, one, true, 1},
+		{one, From(1), false, 0},
+		{null, one, false, 1},
+		{null, one, true, -1},
+		{one, null, true, 1},
+		{null, Null[int]{}, false, 0},
+	}
+	for _, test := range tests {
+		if got := Compare(test.a, test.b, test.nullsFirst); got != test.want {
+			t.Errorf("Compare(%v, %v, %v): got %d, want %d", test.a, test.b, test.nullsFirst, got, test.want)
+		}
+	}
+
+	nan := From(math.NaN())
+	if Compare(nan, From(0.0), false) != -1 || Compare(From(0.0), nan, false) != 1 || Compare(nan, nan, false) != 0 {
+		t.Error("Compare() should order NaN before other floats, like cmp.Compare")
+	}
+	if Compare(From("a"), From("b"), false) != -1 {
+		t.Error("Compare() should order strings")
+	}
+
+	for _, nullsFirst := range []bool{false, true} {
+		ns := []Null[int]{two, null, one, {}}
+		sort.SliceStable(ns, func(i, j int) bool { return Compare(ns[i], ns[j], nullsFirst) < 0 })
+		want := []Null[int]{one, two, null, {}}
+		if nullsFirst {
+			want = []Null[int]{null, {}, one, two}
+		}
+		for i := range ns {
+			if ns[i] != want[i] {
+				t.Errorf("sorted with nullsFirst=%v: got %v, want %v", nullsFirst, ns, want)
+				break
+			}
+		}
+	}
+}
+
+func TestNullGet(t *testing.T) {
+	if v, ok := From(point{1, 2}).Get(); !ok || v != (point{1, 2}) {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	if v, ok := NewNull(point{1, 2}, false, true).Get(); ok || v != (point{}) {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
+func assertNull[T comparable](t *testing.T, n Null[T], v T, from string) {
+	if n.Val != v {
+		t.Errorf("bad %s value: %v ≠ %v\n", from, n.Val, v)
+	}
+	if !n.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullNull[T any](t *testing.T, n Null[T], from string) {
+	if n.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
@@ -1,8 +1,21 @@
 module github.com/volatiletech/null/v9
 
-go 1.14
+go 1.18
 
 require (
-	github.com/volatiletech/null/v8 v8.1.2
+	github.com/fxamacker/cbor/v2 v2.5.0
+	github.com/vmihailenco/msgpack/v5 v5.3.5
 	github.com/volatiletech/randomize v0.0.1
+	go.mongodb.org/mongo-driver/v2 v2.2.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/friendsofgo/errors v0.9.2 // indirect
+	github.com/gofrs/uuid v3.2.0+incompatible // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	github.com/volatiletech/inflect v0.0.1 // indirect
+	github.com/volatiletech/strmangle v0.0.1 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
+	golang.org/x/xerrors v0.0.0-20190717185122-a985d3407aa7 // indirect
 )
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"encoding/json"
+	"fmt"
 	"math"
+	"math/big"
 	"strconv"
 
 	"github.com/volatiletech/null/v9/convert"
@@ -39,6 +41,13 @@ func IntFromPtr(i *int) Int {
 	return NewInt(*i, true, true)
 }
 
+// IntUnset creates a new Int that is null and not Set, as for a field absent
+// from the input. IsSet reports false until a value or an explicit null is
+// unmarshaled into it, or it is changed with SetValid or SetNull.
+func IntUnset() Int {
+	return Int{}
+}
+
 func (i Int) IsSet() bool {
 	return i.Set
 }
@@ -102,6 +111,14 @@ func (i *Int) SetValid(n int) {
 	i.Set = true
 }
 
+// SetNull changes this Int's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (i *Int) SetNull() {
+	i.Int = 0
+	i.Valid = false
+	i.Set = true
+}
+
 // Ptr returns a pointer to this Int's value, or a nil pointer if this Int is null.
 func (i Int) Ptr() *int {
 	if !i.Valid {
@@ -115,14 +132,38 @@ func (i Int) IsZero() bool {
 	return !i.Valid
 }
 
+// Or returns this Int if it is valid, or other if it is not.
+func (i Int) Or(other Int) Int {
+	if i.Valid {
+		return i
+	}
+	return other
+}
+
+// Get returns this Int's value and true, or the zero value and false if it is null.
+func (i Int) Get() (int, bool) {
+	if !i.Valid {
+		return 0, false
+	}
+	return i.Int, true
+}
+
 // Scan implements the Scanner interface.
 func (i *Int) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
 	if value == nil {
 		i.Int, i.Valid, i.Set = 0, false, false
 		return nil
 	}
-	i.Valid, i.Set = true, true
-	return convert.ConvertAssign(&i.Int, value)
+	v, err := scanInt(value, strconv.IntSize, "int")
+	if err != nil {
+		return err
+	}
+	i.Int, i.Valid, i.Set = int(v), true, true
+	return nil
 }
 
 // Value implements the driver Valuer interface.
@@ -143,3 +184,31 @@ func (i *Int) Randomize(nextInt func() int64, fieldType string, shouldBeNull boo
 		i.Valid = true
 	}
 }
+
+// scanInt converts value as ConvertAssign does, and checks that it fits in
+// a signed integer of the given size, so that an out-of-range value is an
+// error naming typ rather than a silently wrong or partial result.
+func scanInt(value interface{}, bits int, typ string) (int64, error) {
+	var v int64
+	if err := convert.ConvertAssign(&v, value); err != nil {
+		return 0, overflowError(value, typ, err)
+	}
+	if bits < 64 && (v < -1<<(bits-1) || v > 1<<(bits-1)-1) {
+		return 0, fmt.Errorf("null: value %d overflows %s", v, typ)
+	}
+	return v, nil
+}
+
+// overflowError returns an overflow error for typ if value, which failed
+// to convert with err, is an integer after all, and err otherwise.
+func overflowError(value interface{}, typ string, err error) error {
+	var n big.Int
+	if convert.ConvertAssign(&n, value) == nil {
+		return fmt.Errorf("null: value %s overflows %s", &n, typ)
+	}
+	var u uint64
+	if convert.ConvertAssign(&u, value) == nil {
+		return fmt.Errorf("null: value %d overflows %s", u, typ)
+	}
+	return err
+}
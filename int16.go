@@ -40,6 +40,13 @@ func Int16FromPtr(i *int16) Int16 {
 	return NewInt16(*i, true, true)
 }
 
+// Int16Unset creates a new Int16 that is null and not Set, as for a field
+// absent from the input. IsSet reports false until a value or an explicit
+// null is unmarshaled into it, or it is changed with SetValid or SetNull.
+func Int16Unset() Int16 {
+	return Int16{}
+}
+
 func (i Int16) IsSet() bool {
 	return i.Set
 }
@@ -104,6 +111,14 @@ func (i *Int16) SetValid(n int16) {
 	i.Set = true
 }
 
+// SetNull changes this Int16's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (i *Int16) SetNull() {
+	i.Int16 = 0
+	i.Valid = false
+	i.Set = true
+}
+
 // Ptr returns a pointer to this Int16's value, or a nil pointer if this Int16 is null.
 func (i Int16) Ptr() *int16 {
 	if !i.Valid {
@@ -117,14 +132,38 @@ func (i Int16) IsZero() bool {
 	return !i.Valid
 }
 
+// Or returns this Int16 if it is valid, or other if it is not.
+func (i Int16) Or(other Int16) Int16 {
+	if i.Valid {
+		return i
+	}
+	return other
+}
+
+// Get returns this Int16's value and true, or the zero value and false if it is null.
+func (i Int16) Get() (int16, bool) {
+	if !i.Valid {
+		return 0, false
+	}
+	return i.Int16, true
+}
+
 // Scan implements the Scanner interface.
 func (i *Int16) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
 	if value == nil {
 		i.Int16, i.Valid, i.Set = 0, false, false
 		return nil
 	}
-	i.Valid, i.Set = true, true
-	return convert.ConvertAssign(&i.Int16, value)
+	v, err := scanInt(value, 16, "int16")
+	if err != nil {
+		return err
+	}
+	i.Int16, i.Valid, i.Set = int16(v), true, true
+	return nil
 }
 
 // Value implements the driver Valuer interface.
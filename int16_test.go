@@ -31,6 +31,14 @@ func TestInt16FromPtr(t *testing.T) {
 	assertNullInt16(t, null, "Int16FromPtr(nil)")
 }
 
+func TestInt16Unset(t *testing.T) {
+	v := Int16Unset()
+	assertNullInt16(t, v, "Int16Unset()")
+	if v.IsSet() {
+		t.Error("Int16Unset() should not be Set")
+	}
+}
+
 func TestUnmarshalInt16(t *testing.T) {
 	var i Int16
 	err := json.Unmarshal(int16JSON, &i)
@@ -159,6 +167,55 @@ func TestInt16SetValid(t *testing.T) {
 	assertInt16(t, change, "SetValid()")
 }
 
+func TestInt16SetNull(t *testing.T) {
+	change := NewInt16(0, false, true)
+	change.SetValid(32766)
+	change.SetNull()
+	assertNullInt16(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.Int16 != 0 {
+		t.Errorf("SetNull() should zero the value, got %v", change.Int16)
+	}
+}
+
+func TestInt16ScanOverflow(t *testing.T) {
+	for _, test := range []struct {
+		in   interface{}
+		want int16
+	}{
+		{int64(-32768), int16(math.MinInt16)},
+		{"-32768", int16(math.MinInt16)},
+		{int64(32767), int16(math.MaxInt16)},
+		{"32767", int16(math.MaxInt16)},
+		{[]byte("32767"), int16(math.MaxInt16)},
+	} {
+		var v Int16
+		err := v.Scan(test.in)
+		maybePanic(err)
+		if v.Int16 != test.want || !v.Valid {
+			t.Errorf("Scan(%v): got %d, want %d", test.in, v.Int16, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		in   interface{}
+		want string
+	}{
+		{int64(-32769), "null: value -32769 overflows int16"},
+		{"-32769", "null: value -32769 overflows int16"},
+		{int64(32768), "null: value 32768 overflows int16"},
+		{"32768", "null: value 32768 overflows int16"},
+	} {
+		var v Int16
+		if err := v.Scan(test.in); errString(err) != test.want {
+			t.Errorf("Scan(%v): got error %v, want %q", test.in, err, test.want)
+		}
+		assertNullInt16(t, v, "scanned overflowing value")
+	}
+}
+
 func TestInt16Scan(t *testing.T) {
 	var i Int16
 	err := i.Scan(32766)
@@ -171,6 +228,31 @@ func TestInt16Scan(t *testing.T) {
 	assertNullInt16(t, null, "scanned null")
 }
 
+func TestInt16Or(t *testing.T) {
+	a := NewInt16(1, true, true)
+	b := NewInt16(2, true, true)
+	null := NewInt16(0, false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestInt16Get(t *testing.T) {
+	if v, ok := Int16From(12345).Get(); !ok || v != int16(12345) {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null Int16
+	if v, ok := null.Get(); ok || v != 0 {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
 func assertInt16(t *testing.T, i Int16, from string) {
 	if i.Int16 != 32766 {
 		t.Errorf("bad %s int16: %d ≠ %d\n", from, i.Int16, 32766)
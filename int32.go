@@ -41,6 +41,13 @@ func Int32FromPtr(i *int32) Int32 {
 	return NewInt32(*i, true, true)
 }
 
+// Int32Unset creates a new Int32 that is null and not Set, as for a field
+// absent from the input. IsSet reports false until a value or an explicit
+// null is unmarshaled into it, or it is changed with SetValid or SetNull.
+func Int32Unset() Int32 {
+	return Int32{}
+}
+
 func (i Int32) IsSet() bool {
 	return i.Set
 }
@@ -107,6 +114,14 @@ func (i *Int32) SetValid(n int32) {
 	i.Set = true
 }
 
+// SetNull changes this Int32's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (i *Int32) SetNull() {
+	i.Int32 = 0
+	i.Valid = false
+	i.Set = true
+}
+
 // Ptr returns a pointer to this Int32's value, or a nil pointer if this Int32 is null.
 func (i Int32) Ptr() *int32 {
 	if !i.Valid {
@@ -120,14 +135,38 @@ func (i Int32) IsZero() bool {
 	return !i.Valid
 }
 
+// Or returns this Int32 if it is valid, or other if it is not.
+func (i Int32) Or(other Int32) Int32 {
+	if i.Valid {
+		return i
+	}
+	return other
+}
+
+// Get returns this Int32's value and true, or the zero value and false if it is null.
+func (i Int32) Get() (int32, bool) {
+	if !i.Valid {
+		return 0, false
+	}
+	return i.Int32, true
+}
+
 // Scan implements the Scanner interface.
 func (i *Int32) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
 	if value == nil {
 		i.Int32, i.Valid, i.Set = 0, false, false
 		return nil
 	}
-	i.Valid, i.Set = true, true
-	return convert.ConvertAssign(&i.Int32, value)
+	v, err := scanInt(value, 32, "int32")
+	if err != nil {
+		return err
+	}
+	i.Int32, i.Valid, i.Set = int32(v), true, true
+	return nil
 }
 
 // Value implements the driver Valuer interface.
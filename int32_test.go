@@ -31,6 +31,14 @@ func TestInt32FromPtr(t *testing.T) {
 	assertNullInt32(t, null, "Int32FromPtr(nil)")
 }
 
+func TestInt32Unset(t *testing.T) {
+	v := Int32Unset()
+	assertNullInt32(t, v, "Int32Unset()")
+	if v.IsSet() {
+		t.Error("Int32Unset() should not be Set")
+	}
+}
+
 func TestUnmarshalInt32(t *testing.T) {
 	var i Int32
 	err := json.Unmarshal(int32JSON, &i)
@@ -160,6 +168,55 @@ func TestInt32SetValid(t *testing.T) {
 	assertInt32(t, change, "SetValid()")
 }
 
+func TestInt32SetNull(t *testing.T) {
+	change := NewInt32(0, false, true)
+	change.SetValid(2147483646)
+	change.SetNull()
+	assertNullInt32(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.Int32 != 0 {
+		t.Errorf("SetNull() should zero the value, got %v", change.Int32)
+	}
+}
+
+func TestInt32ScanOverflow(t *testing.T) {
+	for _, test := range []struct {
+		in   interface{}
+		want int32
+	}{
+		{int64(-2147483648), int32(math.MinInt32)},
+		{"-2147483648", int32(math.MinInt32)},
+		{int64(2147483647), int32(math.MaxInt32)},
+		{"2147483647", int32(math.MaxInt32)},
+		{[]byte("2147483647"), int32(math.MaxInt32)},
+	} {
+		var v Int32
+		err := v.Scan(test.in)
+		maybePanic(err)
+		if v.Int32 != test.want || !v.Valid {
+			t.Errorf("Scan(%v): got %d, want %d", test.in, v.Int32, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		in   interface{}
+		want string
+	}{
+		{int64(-2147483649), "null: value -2147483649 overflows int32"},
+		{"-2147483649", "null: value -2147483649 overflows int32"},
+		{int64(2147483648), "null: value 2147483648 overflows int32"},
+		{"2147483648", "null: value 2147483648 overflows int32"},
+	} {
+		var v Int32
+		if err := v.Scan(test.in); errString(err) != test.want {
+			t.Errorf("Scan(%v): got error %v, want %q", test.in, err, test.want)
+		}
+		assertNullInt32(t, v, "scanned overflowing value")
+	}
+}
+
 func TestInt32Scan(t *testing.T) {
 	var i Int32
 	err := i.Scan(2147483646)
@@ -172,6 +229,31 @@ func TestInt32Scan(t *testing.T) {
 	assertNullInt32(t, null, "scanned null")
 }
 
+func TestInt32Or(t *testing.T) {
+	a := NewInt32(1, true, true)
+	b := NewInt32(2, true, true)
+	null := NewInt32(0, false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestInt32Get(t *testing.T) {
+	if v, ok := Int32From(12345).Get(); !ok || v != int32(12345) {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null Int32
+	if v, ok := null.Get(); ok || v != 0 {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
 func assertInt32(t *testing.T, i Int32, from string) {
 	if i.Int32 != 2147483646 {
 		t.Errorf("bad %s int32: %d ≠ %d\n", from, i.Int32, 2147483646)
@@ -38,6 +38,13 @@ func Int64FromPtr(i *int64) Int64 {
 	return NewInt64(*i, true, true)
 }
 
+// Int64Unset creates a new Int64 that is null and not Set, as for a field
+// absent from the input. IsSet reports false until a value or an explicit
+// null is unmarshaled into it, or it is changed with SetValid or SetNull.
+func Int64Unset() Int64 {
+	return Int64{}
+}
+
 func (i Int64) IsSet() bool {
 	return i.Set
 }
@@ -95,6 +102,14 @@ func (i *Int64) SetValid(n int64) {
 	i.Set = true
 }
 
+// SetNull changes this Int64's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (i *Int64) SetNull() {
+	i.Int64 = 0
+	i.Valid = false
+	i.Set = true
+}
+
 // Ptr returns a pointer to this Int64's value, or a nil pointer if this Int64 is null.
 func (i Int64) Ptr() *int64 {
 	if !i.Valid {
@@ -108,14 +123,38 @@ func (i Int64) IsZero() bool {
 	return !i.Valid
 }
 
+// Or returns this Int64 if it is valid, or other if it is not.
+func (i Int64) Or(other Int64) Int64 {
+	if i.Valid {
+		return i
+	}
+	return other
+}
+
+// Get returns this Int64's value and true, or the zero value and false if it is null.
+func (i Int64) Get() (int64, bool) {
+	if !i.Valid {
+		return 0, false
+	}
+	return i.Int64, true
+}
+
 // Scan implements the Scanner interface.
 func (i *Int64) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
 	if value == nil {
 		i.Int64, i.Valid, i.Set = 0, false, false
 		return nil
 	}
-	i.Valid, i.Set = true, true
-	return convert.ConvertAssign(&i.Int64, value)
+	v, err := scanInt(value, 64, "int64")
+	if err != nil {
+		return err
+	}
+	i.Int64, i.Valid, i.Set = int64(v), true, true
+	return nil
 }
 
 // Value implements the driver Valuer interface.
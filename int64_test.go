@@ -31,6 +31,14 @@ func TestInt64FromPtr(t *testing.T) {
 	assertNullInt64(t, null, "Int64FromPtr(nil)")
 }
 
+func TestInt64Unset(t *testing.T) {
+	v := Int64Unset()
+	assertNullInt64(t, v, "Int64Unset()")
+	if v.IsSet() {
+		t.Error("Int64Unset() should not be Set")
+	}
+}
+
 func TestUnmarshalInt64(t *testing.T) {
 	var i Int64
 	err := json.Unmarshal(int64JSON, &i)
@@ -160,6 +168,53 @@ func TestInt64SetValid(t *testing.T) {
 	assertInt64(t, change, "SetValid()")
 }
 
+func TestInt64SetNull(t *testing.T) {
+	change := NewInt64(0, false, true)
+	change.SetValid(9223372036854775806)
+	change.SetNull()
+	assertNullInt64(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.Int64 != 0 {
+		t.Errorf("SetNull() should zero the value, got %v", change.Int64)
+	}
+}
+
+func TestInt64ScanOverflow(t *testing.T) {
+	for _, test := range []struct {
+		in   interface{}
+		want int64
+	}{
+		{int64(math.MinInt64), int64(math.MinInt64)},
+		{"-9223372036854775808", int64(math.MinInt64)},
+		{int64(9223372036854775807), int64(math.MaxInt64)},
+		{"9223372036854775807", int64(math.MaxInt64)},
+		{[]byte("9223372036854775807"), int64(math.MaxInt64)},
+	} {
+		var v Int64
+		err := v.Scan(test.in)
+		maybePanic(err)
+		if v.Int64 != test.want || !v.Valid {
+			t.Errorf("Scan(%v): got %d, want %d", test.in, v.Int64, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		in   interface{}
+		want string
+	}{
+		{"-9223372036854775809", "null: value -9223372036854775809 overflows int64"},
+		{"9223372036854775808", "null: value 9223372036854775808 overflows int64"},
+	} {
+		var v Int64
+		if err := v.Scan(test.in); errString(err) != test.want {
+			t.Errorf("Scan(%v): got error %v, want %q", test.in, err, test.want)
+		}
+		assertNullInt64(t, v, "scanned overflowing value")
+	}
+}
+
 func TestInt64Scan(t *testing.T) {
 	var i Int64
 	err := i.Scan(9223372036854775806)
@@ -172,6 +227,31 @@ func TestInt64Scan(t *testing.T) {
 	assertNullInt64(t, null, "scanned null")
 }
 
+func TestInt64Or(t *testing.T) {
+	a := NewInt64(1, true, true)
+	b := NewInt64(2, true, true)
+	null := NewInt64(0, false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestInt64Get(t *testing.T) {
+	if v, ok := Int64From(12345).Get(); !ok || v != int64(12345) {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null Int64
+	if v, ok := null.Get(); ok || v != 0 {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
 func assertInt64(t *testing.T, i Int64, from string) {
 	if i.Int64 != 9223372036854775806 {
 		t.Errorf("bad %s int64: %d ≠ %d\n", from, i.Int64, 9223372036854775806)
@@ -40,6 +40,13 @@ func Int8FromPtr(i *int8) Int8 {
 	return NewInt8(*i, true, true)
 }
 
+// Int8Unset creates a new Int8 that is null and not Set, as for a field
+// absent from the input. IsSet reports false until a value or an explicit
+// null is unmarshaled into it, or it is changed with SetValid or SetNull.
+func Int8Unset() Int8 {
+	return Int8{}
+}
+
 func (i Int8) IsSet() bool {
 	return i.Set
 }
@@ -106,6 +113,14 @@ func (i *Int8) SetValid(n int8) {
 	i.Set = true
 }
 
+// SetNull changes this Int8's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (i *Int8) SetNull() {
+	i.Int8 = 0
+	i.Valid = false
+	i.Set = true
+}
+
 // Ptr returns a pointer to this Int8's value, or a nil pointer if this Int8 is null.
 func (i Int8) Ptr() *int8 {
 	if !i.Valid {
@@ -119,14 +134,38 @@ func (i Int8) IsZero() bool {
 	return !i.Valid
 }
 
+// Or returns this Int8 if it is valid, or other if it is not.
+func (i Int8) Or(other Int8) Int8 {
+	if i.Valid {
+		return i
+	}
+	return other
+}
+
+// Get returns this Int8's value and true, or the zero value and false if it is null.
+func (i Int8) Get() (int8, bool) {
+	if !i.Valid {
+		return 0, false
+	}
+	return i.Int8, true
+}
+
 // Scan implements the Scanner interface.
 func (i *Int8) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
 	if value == nil {
 		i.Int8, i.Valid, i.Set = 0, false, false
 		return nil
 	}
-	i.Valid, i.Set = true, true
-	return convert.ConvertAssign(&i.Int8, value)
+	v, err := scanInt(value, 8, "int8")
+	if err != nil {
+		return err
+	}
+	i.Int8, i.Valid, i.Set = int8(v), true, true
+	return nil
 }
 
 // Value implements the driver Valuer interface.
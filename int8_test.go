@@ -31,6 +31,14 @@ func TestInt8FromPtr(t *testing.T) {
 	assertNullInt8(t, null, "Int8FromPtr(nil)")
 }
 
+func TestInt8Unset(t *testing.T) {
+	v := Int8Unset()
+	assertNullInt8(t, v, "Int8Unset()")
+	if v.IsSet() {
+		t.Error("Int8Unset() should not be Set")
+	}
+}
+
 func TestUnmarshalInt8(t *testing.T) {
 	var i Int8
 	err := json.Unmarshal(int8JSON, &i)
@@ -160,6 +168,55 @@ func TestInt8SetValid(t *testing.T) {
 	assertInt8(t, change, "SetValid()")
 }
 
+func TestInt8SetNull(t *testing.T) {
+	change := NewInt8(0, false, true)
+	change.SetValid(126)
+	change.SetNull()
+	assertNullInt8(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.Int8 != 0 {
+		t.Errorf("SetNull() should zero the value, got %v", change.Int8)
+	}
+}
+
+func TestInt8ScanOverflow(t *testing.T) {
+	for _, test := range []struct {
+		in   interface{}
+		want int8
+	}{
+		{int64(-128), int8(math.MinInt8)},
+		{"-128", int8(math.MinInt8)},
+		{int64(127), int8(math.MaxInt8)},
+		{"127", int8(math.MaxInt8)},
+		{[]byte("127"), int8(math.MaxInt8)},
+	} {
+		var v Int8
+		err := v.Scan(test.in)
+		maybePanic(err)
+		if v.Int8 != test.want || !v.Valid {
+			t.Errorf("Scan(%v): got %d, want %d", test.in, v.Int8, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		in   interface{}
+		want string
+	}{
+		{int64(-129), "null: value -129 overflows int8"},
+		{"-129", "null: value -129 overflows int8"},
+		{int64(128), "null: value 128 overflows int8"},
+		{"128", "null: value 128 overflows int8"},
+	} {
+		var v Int8
+		if err := v.Scan(test.in); errString(err) != test.want {
+			t.Errorf("Scan(%v): got error %v, want %q", test.in, err, test.want)
+		}
+		assertNullInt8(t, v, "scanned overflowing value")
+	}
+}
+
 func TestInt8Scan(t *testing.T) {
 	var i Int8
 	err := i.Scan(126)
@@ -172,6 +229,31 @@ func TestInt8Scan(t *testing.T) {
 	assertNullInt8(t, null, "scanned null")
 }
 
+func TestInt8Or(t *testing.T) {
+	a := NewInt8(1, true, true)
+	b := NewInt8(2, true, true)
+	null := NewInt8(0, false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestInt8Get(t *testing.T) {
+	if v, ok := Int8From(123).Get(); !ok || v != int8(123) {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null Int8
+	if v, ok := null.Get(); ok || v != 0 {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
 func assertInt8(t *testing.T, i Int8, from string) {
 	if i.Int8 != 126 {
 		t.Errorf("bad %s int8: %d ≠ %d\n", from, i.Int8, 126)
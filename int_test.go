@@ -1,7 +1,9 @@
 package null
 
 import (
+	"database/sql"
 	"encoding/json"
+	"math"
 	"testing"
 )
 
@@ -29,6 +31,14 @@ func TestIntFromPtr(t *testing.T) {
 	assertNullInt(t, null, "IntFromPtr(nil)")
 }
 
+func TestIntUnset(t *testing.T) {
+	v := IntUnset()
+	assertNullInt(t, v, "IntUnset()")
+	if v.IsSet() {
+		t.Error("IntUnset() should not be Set")
+	}
+}
+
 func TestUnmarshalInt(t *testing.T) {
 	var i Int
 	err := json.Unmarshal(intJSON, &i)
@@ -142,18 +152,118 @@ func TestIntSetValid(t *testing.T) {
 	assertInt(t, change, "SetValid()")
 }
 
+func TestIntSetNull(t *testing.T) {
+	change := NewInt(0, false, true)
+	change.SetValid(12345)
+	change.SetNull()
+	assertNullInt(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.Int != 0 {
+		t.Errorf("SetNull() should zero the value, got %v", change.Int)
+	}
+}
+
+func TestIntScanOverflow(t *testing.T) {
+	for _, test := range []struct {
+		in   interface{}
+		want int
+	}{
+		{int64(math.MinInt64), int(math.MinInt)},
+		{"-9223372036854775808", int(math.MinInt)},
+		{int64(9223372036854775807), int(math.MaxInt)},
+		{"9223372036854775807", int(math.MaxInt)},
+		{[]byte("9223372036854775807"), int(math.MaxInt)},
+	} {
+		var v Int
+		err := v.Scan(test.in)
+		maybePanic(err)
+		if v.Int != test.want || !v.Valid {
+			t.Errorf("Scan(%v): got %d, want %d", test.in, v.Int, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		in   interface{}
+		want string
+	}{
+		{"-9223372036854775809", "null: value -9223372036854775809 overflows int"},
+		{"9223372036854775808", "null: value 9223372036854775808 overflows int"},
+	} {
+		var v Int
+		if err := v.Scan(test.in); errString(err) != test.want {
+			t.Errorf("Scan(%v): got error %v, want %q", test.in, err, test.want)
+		}
+		assertNullInt(t, v, "scanned overflowing value")
+	}
+}
+
 func TestIntScan(t *testing.T) {
 	var i Int
 	err := i.Scan(12345)
 	maybePanic(err)
 	assertInt(t, i, "scanned int")
 
+	var num Int
+	err = num.Scan(json.Number("12345"))
+	maybePanic(err)
+	assertInt(t, num, "scanned json.Number")
+
+	var valuer Int
+	err = valuer.Scan(sql.NullInt64{Int64: 12345, Valid: true})
+	maybePanic(err)
+	assertInt(t, valuer, "scanned sql.NullInt64")
+
+	var nullValuer Int
+	err = nullValuer.Scan(sql.NullInt64{})
+	maybePanic(err)
+	assertNullInt(t, nullValuer, "scanned null sql.NullInt64")
+
+	var inner interface{} = int64(12345)
+	var outer interface{} = &inner
+	var wrapped Int
+	err = wrapped.Scan(&outer)
+	maybePanic(err)
+	assertInt(t, wrapped, "scanned **interface{}")
+
+	var nilInner interface{}
+	wrappedNull := IntFrom(1)
+	err = wrappedNull.Scan(&nilInner)
+	maybePanic(err)
+	assertNullInt(t, wrappedNull, "scanned *interface{} holding nil")
+
 	var null Int
 	err = null.Scan(nil)
 	maybePanic(err)
 	assertNullInt(t, null, "scanned null")
 }
 
+func TestIntOr(t *testing.T) {
+	a := NewInt(1, true, true)
+	b := NewInt(2, true, true)
+	null := NewInt(0, false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestIntGet(t *testing.T) {
+	if v, ok := IntFrom(12345).Get(); !ok || v != 12345 {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null Int
+	if v, ok := null.Get(); ok || v != 0 {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
 func assertInt(t *testing.T, i Int, from string) {
 	if i.Int != 12345 {
 		t.Errorf("bad %s int: %d ≠ %d\n", from, i.Int, 12345)
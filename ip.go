@@ -0,0 +1,219 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/volatiletech/null/v9/convert"
+)
+
+// IP is a nullable net.IP. It supports SQL and JSON serialization.
+// It is written to SQL and JSON as a string in the form net.IP.String uses,
+// so IPv4 addresses stay dotted and IPv6 addresses stay in RFC 5952 form.
+// As with net.IP, IPv4-mapped IPv6 addresses are written in the dotted form.
+type IP struct {
+	IP    net.IP
+	Valid bool
+	Set   bool
+}
+
+// NewIP creates a new IP
+func NewIP(ip net.IP, valid, set bool) IP {
+	return IP{
+		IP:    ip,
+		Valid: valid,
+		Set:   set,
+	}
+}
+
+// IPFrom creates a new IP that will be invalid if nil.
+func IPFrom(ip net.IP) IP {
+	return NewIP(ip, ip != nil, true)
+}
+
+// IPFromPtr creates a new IP that will be invalid if nil.
+func IPFromPtr(ip *net.IP) IP {
+	if ip == nil {
+		return NewIP(nil, false, true)
+	}
+	return NewIP(*ip, true, true)
+}
+
+// IPUnset creates a new IP that is null and not Set, as for a field absent
+// from the input. IsSet reports false until a value or an explicit null is
+// unmarshaled into it, or it is changed with SetValid or SetNull.
+func IPUnset() IP {
+	return IP{}
+}
+
+// IPFromString creates a new valid IP by parsing s,
+// returning an error if s is not an IPv4 or IPv6 address.
+func IPFromString(s string) (IP, error) {
+	ip, err := parseIP(s)
+	if err != nil {
+		return IP{}, err
+	}
+	return NewIP(ip, true, true), nil
+}
+
+func (i IP) IsSet() bool {
+	return i.Set
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *IP) UnmarshalJSON(data []byte) error {
+	i.Set = true
+	if bytes.Equal(data, NullBytes) {
+		i.IP, i.Valid = nil, false
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return i.UnmarshalText([]byte(s))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (i *IP) UnmarshalText(text []byte) error {
+	i.Set = true
+	if len(text) == 0 {
+		i.IP, i.Valid = nil, false
+		return nil
+	}
+	ip, err := parseIP(string(text))
+	if err != nil {
+		return err
+	}
+	i.IP, i.Valid = ip, true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (i IP) MarshalJSON() ([]byte, error) {
+	if !i.Valid {
+		return NullBytes, nil
+	}
+	return json.Marshal(i.IP.String())
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (i IP) MarshalText() ([]byte, error) {
+	if !i.Valid {
+		return []byte{}, nil
+	}
+	return []byte(i.IP.String()), nil
+}
+
+// String returns the text form of this IP, or an empty string if it is null.
+func (i IP) String() string {
+	if !i.Valid {
+		return ""
+	}
+	return i.IP.String()
+}
+
+// SetValid changes this IP's value and also sets it to be non-null.
+func (i *IP) SetValid(v net.IP) {
+	i.IP = v
+	i.Valid = true
+	i.Set = true
+}
+
+// SetNull changes this IP's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (i *IP) SetNull() {
+	i.IP = nil
+	i.Valid = false
+	i.Set = true
+}
+
+// Ptr returns a pointer to this IP's value, or a nil pointer if this IP is null.
+func (i IP) Ptr() *net.IP {
+	if !i.Valid {
+		return nil
+	}
+	return &i.IP
+}
+
+// IsZero returns true for invalid IPs, for potential future omitempty support.
+func (i IP) IsZero() bool {
+	return !i.Valid
+}
+
+// Or returns this IP if it is valid, or other if it is not.
+func (i IP) Or(other IP) IP {
+	if i.Valid {
+		return i
+	}
+	return other
+}
+
+// Get returns a copy of this IP's value and true, or nil and false if it is null.
+func (i IP) Get() (net.IP, bool) {
+	if !i.Valid {
+		return nil, false
+	}
+	return append(net.IP{}, i.IP...), true
+}
+
+// Scan implements the Scanner interface.
+func (i *IP) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
+	switch x := value.(type) {
+	case string:
+		i.IP, err = parseIP(x)
+	case []byte:
+		i.IP, err = parseIP(string(x))
+	case nil:
+		i.IP, i.Valid, i.Set = nil, false, false
+		return nil
+	default:
+		err = fmt.Errorf("null: cannot scan type %T into null.IP: %v", value, value)
+	}
+	if err == nil {
+		i.Valid, i.Set = true, true
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+func (i IP) Value() (driver.Value, error) {
+	if !i.Valid {
+		return nil, nil
+	}
+	return i.IP.String(), nil
+}
+
+// Randomize for sqlboiler
+func (i *IP) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		i.IP = nil
+		i.Valid = false
+	} else {
+		// Keep the first octet in 1-223 so the address is a unicast host.
+		i.IP = net.IPv4(byte(1+nextInt()%223), byte(nextInt()), byte(nextInt()), byte(1+nextInt()%254)).To4()
+		i.Valid = true
+	}
+}
+
+// parseIP parses s, keeping IPv4 addresses in their 4-byte form so that
+// scanned values compare equal to the ones they were written from.
+func parseIP(s string) (net.IP, error) {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("null: invalid IP address %q", s)
+	}
+	if v4 := ip.To4(); v4 != nil && !strings.Contains(s, ":") {
+		return v4, nil
+	}
+	return ip, nil
+}
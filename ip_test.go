@@ -0,0 +1,284 @@
+package null
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+)
+
+var (
+	ipString   = "192.0.2.1"
+	ipJSON     = []byte(`"` + ipString + `"`)
+	ipValue    = net.IPv4(192, 0, 2, 1)
+	ipv6String = "2001:db8::68"
+)
+
+func TestIPFrom(t *testing.T) {
+	i := IPFrom(ipValue)
+	assertIP(t, i, "IPFrom()")
+
+	null := IPFrom(nil)
+	assertNullIP(t, null, "IPFrom(nil)")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+}
+
+func TestIPFromPtr(t *testing.T) {
+	v := ipValue
+	i := IPFromPtr(&v)
+	assertIP(t, i, "IPFromPtr()")
+
+	null := IPFromPtr(nil)
+	assertNullIP(t, null, "IPFromPtr(nil)")
+}
+
+func TestIPUnset(t *testing.T) {
+	v := IPUnset()
+	assertNullIP(t, v, "IPUnset()")
+	if v.IsSet() {
+		t.Error("IPUnset() should not be Set")
+	}
+}
+
+func TestIPFromString(t *testing.T) {
+	i, err := IPFromString(ipString)
+	maybePanic(err)
+	assertIP(t, i, "IPFromString()")
+	if len(i.IP) != net.IPv4len {
+		t.Errorf("IPv4 address should be stored in 4 bytes, got %d", len(i.IP))
+	}
+
+	for _, s := range []string{ipv6String, "::1", "::ffff:192.0.2.1"} {
+		i, err = IPFromString(s)
+		maybePanic(err)
+		if len(i.IP) != net.IPv6len {
+			t.Errorf("%s should be stored in 16 bytes, got %d", s, len(i.IP))
+		}
+	}
+
+	for _, bad := range []string{"", "192.0.2", "192.0.2.256", "2001:db8::68::1", "example.com", "10.0.0.0/8"} {
+		i, err = IPFromString(bad)
+		if err == nil {
+			t.Errorf("IPFromString(%q) should fail", bad)
+		}
+		assertNullIP(t, i, "IPFromString() bad")
+	}
+}
+
+func TestUnmarshalIP(t *testing.T) {
+	var i IP
+	err := json.Unmarshal(ipJSON, &i)
+	maybePanic(err)
+	assertIP(t, i, "ip json")
+
+	var null IP
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullIP(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var badType IP
+	err = json.Unmarshal(intJSON, &badType)
+	if err == nil {
+		panic("err should not be nil")
+	}
+	assertNullIP(t, badType, "wrong type json")
+
+	var badString IP
+	err = json.Unmarshal([]byte(`"not-an-ip"`), &badString)
+	if err == nil {
+		panic("err should not be nil")
+	}
+	assertNullIP(t, badString, "bad string json")
+}
+
+func TestTextUnmarshalIP(t *testing.T) {
+	var i IP
+	err := i.UnmarshalText([]byte(ipString))
+	maybePanic(err)
+	assertIP(t, i, "UnmarshalText() ip")
+
+	var blank IP
+	err = blank.UnmarshalText([]byte(""))
+	maybePanic(err)
+	assertNullIP(t, blank, "UnmarshalText() empty ip")
+}
+
+func TestMarshalIP(t *testing.T) {
+	i := IPFrom(ipValue)
+	data, err := json.Marshal(i)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(ipJSON), "non-empty json marshal")
+
+	data, err = i.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, ipString, "non-empty text marshal")
+
+	// invalid values should be encoded as null
+	null := NewIP(ipValue, false, true)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+}
+
+func TestIPRoundTrip(t *testing.T) {
+	for _, s := range []string{ipString, ipv6String, "::1", "fe80::1:2:3:4"} {
+		var i IP
+		err := json.Unmarshal([]byte(`"`+s+`"`), &i)
+		maybePanic(err)
+		data, err := json.Marshal(i)
+		maybePanic(err)
+		assertJSONEquals(t, data, `"`+s+`"`, "round trip")
+
+		var scanned IP
+		err = scanned.Scan(s)
+		maybePanic(err)
+		if v, err := scanned.Value(); v != s || err != nil {
+			t.Error("bad value or err:", v, err)
+		}
+	}
+}
+
+func TestIPPointer(t *testing.T) {
+	i := IPFrom(ipValue)
+	ptr := i.Ptr()
+	if !ptr.Equal(ipValue) {
+		t.Errorf("bad %s ip: %#v ≠ %v\n", "pointer", ptr, ipValue)
+	}
+
+	null := NewIP(ipValue, false, true)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s ip: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestIPIsZero(t *testing.T) {
+	i := IPFrom(net.IPv4zero)
+	if i.IsZero() {
+		t.Errorf("IsZero() should be false")
+	}
+
+	null := NewIP(ipValue, false, true)
+	if !null.IsZero() {
+		t.Errorf("IsZero() should be true")
+	}
+}
+
+func TestIPSetValid(t *testing.T) {
+	change := NewIP(nil, false, true)
+	assertNullIP(t, change, "SetValid()")
+	change.SetValid(ipValue)
+	assertIP(t, change, "SetValid()")
+}
+
+func TestIPSetNull(t *testing.T) {
+	change := NewIP(nil, false, true)
+	change.SetValid(ipValue)
+	change.SetNull()
+	assertNullIP(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.IP != nil {
+		t.Errorf("SetNull() should zero the value, got %v", change.IP)
+	}
+}
+
+func TestIPScanValue(t *testing.T) {
+	for _, in := range []interface{}{ipString, []byte(ipString)} {
+		var i IP
+		err := i.Scan(in)
+		maybePanic(err)
+		assertIP(t, i, "scanned ip")
+		if v, err := i.Value(); v != ipString || err != nil {
+			t.Error("bad value or err:", v, err)
+		}
+	}
+
+	var null IP
+	err := null.Scan(nil)
+	maybePanic(err)
+	assertNullIP(t, null, "scanned null")
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	for _, in := range []interface{}{"not-an-ip", []byte{192, 0, 2, 1}, int64(1)} {
+		var wrong IP
+		if err = wrong.Scan(in); err == nil {
+			t.Errorf("Scan(%v) should fail", in)
+		}
+		assertNullIP(t, wrong, "scanned wrong")
+	}
+}
+
+func TestIPRandomize(t *testing.T) {
+	var seed int64
+	nextInt := func() int64 {
+		seed++
+		return seed
+	}
+
+	var i IP
+	i.Randomize(nextInt, "inet", false)
+	if !i.Valid || i.IP.To4() == nil || !i.IP.IsGlobalUnicast() {
+		t.Errorf("Randomize() should produce a valid unicast IPv4 address: %s", i)
+	}
+	if _, err := IPFromString(i.String()); err != nil {
+		t.Error(err)
+	}
+
+	i.Randomize(nextInt, "inet", true)
+	assertNullIP(t, i, "Randomize() null")
+}
+
+func TestIPOr(t *testing.T) {
+	a := NewIP(net.IPv4(192, 0, 2, 1), true, true)
+	b := NewIP(net.IPv4(192, 0, 2, 2), true, true)
+	null := NewIP(nil, false, true)
+	if got := a.Or(b); !(got.IP.Equal(a.IP) && got.Valid == a.Valid) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got.IP.Equal(b.IP) && got.Valid == b.Valid) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestIPGet(t *testing.T) {
+	i := IPFrom(net.IPv4(192, 0, 2, 1).To4())
+	v, ok := i.Get()
+	if !ok || !v.Equal(ipValue) {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	v[0] = 10
+	if !i.IP.Equal(ipValue) {
+		t.Error("Get() should return a copy, but the original was modified:", i.IP)
+	}
+
+	var null IP
+	if v, ok := null.Get(); ok || v != nil {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
+func assertIP(t *testing.T, i IP, from string) {
+	if !i.IP.Equal(ipValue) {
+		t.Errorf("bad %s ip: %v ≠ %v\n", from, i.IP, ipValue)
+	}
+	if !i.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullIP(t *testing.T, i IP, from string) {
+	if i.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
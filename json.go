@@ -2,15 +2,97 @@ package null
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/fnv"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/volatiletech/null/v9/convert"
 	"github.com/volatiletech/randomize"
 )
 
+// ValidateJSON makes JSON check that its bytes are well-formed JSON:
+// UnmarshalJSON rejects malformed input and MarshalJSON returns an error
+// rather than emitting it. It is off by default for backward compatibility.
+var ValidateJSON = false
+
+// ScanJSONNullAsNull makes JSON.Scan follow the same rule as UnmarshalJSON:
+// the JSON literal null, from a string or []byte column, scans into a null
+// JSON that is Set. Value then writes it back as SQL NULL, so on both the
+// database and the JSON path a JSON null and a missing value mean the same.
+//
+// It is off by default for backward compatibility. Scan then keeps the
+// literal as a valid JSON holding null, which Value writes back unchanged;
+// only SQL NULL scans as invalid. Use IsNull to treat both as null.
+var ScanJSONNullAsNull = false
+
+// ValueJSONAsString makes JSON.Value return a string rather than a []byte,
+// as ValueString does, for JSON stored in text columns. Some drivers treat
+// a []byte parameter as binary: lib/pq sends it as bytea, so a TEXT column
+// receives its hex escape, and SQLite stores it as a BLOB. It is off by
+// default for backward compatibility and for JSONB and JSON columns, which
+// take []byte as is.
+var ValueJSONAsString = false
+
+// TreatEmptyBytesAsNull makes JSON.Scan and ScanInto read an empty []byte,
+// or sql.RawBytes, as SQL NULL. It is for sources that have no separate
+// NULL, such as ODBC bridges and CSV or other text-backed drivers that
+// return an empty non-nil slice for a NULL column, and for legacy schemas
+// that store an empty string in a TEXT column to mean no document. Without
+// it the value scans as a JSON that is Valid but empty: IsZero reports
+// false, Value writes an empty value back rather than NULL, and Kind and
+// UnmarshalWith(WithStrict()) fail with "invalid JSON: empty value". To
+// tell whether a driver does this, scan a NULL row into a *[]byte and check
+// whether the result is nil. It is off by default for backward
+// compatibility. Set it once at startup:
+//
+//	func init() { null.TreatEmptyBytesAsNull = true }
+var TreatEmptyBytesAsNull = false
+
+// JSONKind is the kind of value a JSON holds, as reported by JSON.Kind.
+type JSONKind int
+
+const (
+	// JSONKindNone is the kind of a null JSON, e.g. one scanned from SQL NULL.
+	JSONKindNone JSONKind = iota
+	// JSONKindNull is the kind of a valid JSON holding the literal null.
+	JSONKindNull
+	JSONKindBool
+	JSONKindNumber
+	JSONKindString
+	JSONKindArray
+	JSONKindObject
+)
+
+var jsonKindNames = [...]string{
+	JSONKindNone:   "none",
+	JSONKindNull:   "null",
+	JSONKindBool:   "bool",
+	JSONKindNumber: "number",
+	JSONKindString: "string",
+	JSONKindArray:  "array",
+	JSONKindObject: "object",
+}
+
+// String implements fmt.Stringer.
+func (k JSONKind) String() string {
+	if k < 0 || int(k) >= len(jsonKindNames) {
+		return fmt.Sprintf("JSONKind(%d)", int(k))
+	}
+	return jsonKindNames[k]
+}
+
+var errTrailingJSON = errors.New("null: invalid JSON: trailing data after value")
+
 // JSON is a nullable []byte.
 type JSON struct {
 	JSON  []byte
@@ -41,6 +123,89 @@ func JSONFromPtr(b *[]byte) JSON {
 	return n
 }
 
+// JSONUnset creates a new JSON that is null and not Set, as for a field
+// absent from the input. IsSet reports false until a value or an explicit
+// null is unmarshaled into it, or it is changed with SetValid or SetNull.
+func JSONUnset() JSON {
+	return JSON{}
+}
+
+// MustJSON creates a new valid JSON from b, panicking if b is not valid JSON.
+// It is meant for tests and package-level fixtures, where the input is known
+// to be good; use JSONFrom and Validate for anything read at runtime.
+func MustJSON(b []byte) JSON {
+	if err := validateJSON(b); err != nil {
+		panic(err)
+	}
+	return JSONFrom(b)
+}
+
+// JSONObject creates a new valid JSON holding an object built from pairs of
+// keys and values, e.g. JSONObject("id", 1, "tags", []string{"a"}). Members
+// keep the order they are given in, and each value is marshaled with
+// encoding/json. An odd number of arguments, a key that is not a string, or
+// a repeated key is an error.
+func JSONObject(pairs ...interface{}) (JSON, error) {
+	if len(pairs)%2 != 0 {
+		return JSON{}, fmt.Errorf("null: JSONObject needs key and value pairs, got %d arguments", len(pairs))
+	}
+
+	seen := make(map[string]struct{}, len(pairs)/2)
+	buf := []byte{'{'}
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return JSON{}, fmt.Errorf("null: JSONObject key at argument %d is %T, not string", i, pairs[i])
+		}
+		if _, ok := seen[key]; ok {
+			return JSON{}, fmt.Errorf("null: JSONObject key %q is repeated", key)
+		}
+		seen[key] = struct{}{}
+
+		k, err := json.Marshal(key)
+		if err != nil {
+			return JSON{}, err
+		}
+		v, err := json.Marshal(pairs[i+1])
+		if err != nil {
+			return JSON{}, err
+		}
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(append(append(buf, k...), ':'), v...)
+	}
+	return JSONFrom(append(buf, '}')), nil
+}
+
+// JSONFromRelaxed creates a new valid JSON from relaxed, JSON5-like input,
+// storing it rewritten as strict JSON. Only these relaxations are accepted:
+//
+//   - strings in single quotes, where \' stands for a quote and a double
+//     quote needs no escape;
+//   - a trailing comma after the last element of an array or object;
+//   - line comments starting with // and block comments in /* */, which are
+//     dropped.
+//
+// Everything else, such as unquoted keys, hex numbers or NaN, must already
+// be strict JSON. An error is returned if the input is not valid once
+// rewritten.
+func JSONFromRelaxed(b []byte) (JSON, error) {
+	out, err := relaxJSON(b)
+	if err != nil {
+		return JSON{}, err
+	}
+	if !json.Valid(out) {
+		return JSON{}, fmt.Errorf("null: invalid JSON: %q", b)
+	}
+	return JSONFrom(out), nil
+}
+
+// JSONFromRawMessage creates a new JSON that will be invalid if r is nil.
+func JSONFromRawMessage(r json.RawMessage) JSON {
+	return JSONFrom([]byte(r))
+}
+
 func (j JSON) IsSet() bool {
 	return j.Set
 }
@@ -64,6 +229,222 @@ func (j JSON) Unmarshal(dest interface{}) error {
 	return json.Unmarshal(res, dest)
 }
 
+// MustUnmarshal is like Unmarshal, but panics if it returns an error. Like
+// MustJSON it is meant for tests and initialization code working on JSON
+// that is known to be good; never use it on request data.
+func (j JSON) MustUnmarshal(dest interface{}) {
+	if err := j.Unmarshal(dest); err != nil {
+		panic(err)
+	}
+}
+
+// UnmarshalWithNumber is like Unmarshal, but decodes the stored bytes
+// directly with UseNumber enabled, so numbers stored into an interface{}
+// are kept as json.Number and do not lose precision as float64. A null or
+// empty JSON does nothing and leaves dest untouched. Like json.Unmarshal,
+// it is an error for anything but whitespace to follow the value.
+func (j JSON) UnmarshalWithNumber(dest interface{}) error {
+	if dest == nil {
+		return errors.New("destination is nil, not a valid pointer to an object")
+	}
+	if !j.Valid || len(j.JSON) == 0 {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(j.JSON))
+	dec.UseNumber()
+	if err := dec.Decode(dest); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errTrailingJSON
+	}
+	return nil
+}
+
+// UnmarshalStrict is like Unmarshal, but decodes the stored bytes directly,
+// without first marshaling them again, and rejects object keys that do not
+// match a field in dest, as json.Decoder.DisallowUnknownFields does. Like
+// json.Unmarshal, it is an error for anything but whitespace to follow the
+// value. A null or empty JSON leaves dest untouched.
+func (j JSON) UnmarshalStrict(dest interface{}) error {
+	if dest == nil {
+		return errors.New("destination is nil, not a valid pointer to an object")
+	}
+	if !j.Valid || len(j.JSON) == 0 {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(j.JSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dest); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errTrailingJSON
+	}
+	return nil
+}
+
+// DecodeOption configures how UnmarshalWith decodes a JSON.
+type DecodeOption func(*decodeOptions)
+
+type decodeOptions struct {
+	useNumber             bool
+	disallowUnknownFields bool
+	strict                bool
+}
+
+// WithUseNumber decodes numbers stored into an interface{} as json.Number
+// rather than float64, as json.Decoder.UseNumber does.
+func WithUseNumber() DecodeOption {
+	return func(o *decodeOptions) { o.useNumber = true }
+}
+
+// WithDisallowUnknownFields makes it an error for an object key to match no
+// field in the destination struct, as json.Decoder.DisallowUnknownFields
+// does.
+func WithDisallowUnknownFields() DecodeOption {
+	return func(o *decodeOptions) { o.disallowUnknownFields = true }
+}
+
+// WithStrict makes it an error to decode a null or empty JSON, which is
+// otherwise decoded as the JSON literal null. A valid JSON holding the
+// literal null is still decoded.
+func WithStrict() DecodeOption {
+	return func(o *decodeOptions) { o.strict = true }
+}
+
+// UnmarshalWith is like Unmarshal, but decodes the stored bytes directly
+// with a json.Decoder configured by opts. With no options it behaves as
+// Unmarshal does: a null or empty JSON is decoded as null, and it is an
+// error for anything but whitespace to follow the value. Unlike Unmarshal it
+// does not compact the bytes first, so json.RawMessage values in dest keep
+// them as they are stored.
+func (j JSON) UnmarshalWith(dest interface{}, opts ...DecodeOption) error {
+	if dest == nil {
+		return errors.New("destination is nil, not a valid pointer to an object")
+	}
+	var o decodeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.strict && !j.Valid {
+		return errors.New("null: cannot decode a null JSON")
+	}
+	data := j.JSON
+	if !j.Valid {
+		data = NullBytes
+	} else if len(data) == 0 {
+		if o.strict {
+			return errors.New("null: invalid JSON: empty value")
+		}
+		data = NullBytes
+	}
+
+	// Find the value and check what follows it before touching dest, which
+	// json.Unmarshal also leaves alone when the input is malformed.
+	dec := json.NewDecoder(bytes.NewReader(data))
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err == io.EOF {
+		return errors.New("null: invalid JSON: empty value")
+	} else if err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errTrailingJSON
+	}
+
+	dec = json.NewDecoder(bytes.NewReader(raw))
+	if o.useNumber {
+		dec.UseNumber()
+	}
+	if o.disallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	return dec.Decode(dest)
+}
+
+// UnmarshalOrDefault is like Unmarshal, but decodes def into dest
+// instead when this JSON is null.
+func (j JSON) UnmarshalOrDefault(dest interface{}, def []byte) error {
+	if !j.Valid {
+		return JSONFrom(def).Unmarshal(dest)
+	}
+	return j.Unmarshal(dest)
+}
+
+// UnmarshalJSONInto decodes the JSON stored in j directly into a new T.
+// It returns false and no error if j is null or empty, which matches the
+// behavior of Unmarshal without the intermediate marshal.
+func UnmarshalJSONInto[T any](j JSON) (T, bool, error) {
+	var v T
+	if !j.Valid || len(j.JSON) == 0 {
+		return v, false, nil
+	}
+	if err := json.Unmarshal(j.JSON, &v); err != nil {
+		var zero T
+		return zero, false, err
+	}
+	return v, true, nil
+}
+
+// ScanJSON scans value as JSON.Scan does and, if the result is valid,
+// unmarshals it straight into dest. SQL NULL, and an empty value, leave dest
+// untouched and return nil. A malformed JSON value is an error from
+// json.Unmarshal, and dest may then be partly filled.
+func ScanJSON(value interface{}, dest interface{}) error {
+	var j JSON
+	if err := j.Scan(value); err != nil {
+		return err
+	}
+	if !j.Valid || len(j.JSON) == 0 {
+		return nil
+	}
+	return json.Unmarshal(j.JSON, dest)
+}
+
+// Map unmarshals this JSON, which must hold an object, into a map.
+// It returns nil and no error if this JSON is null, empty, or holds the
+// literal null. Numbers are decoded as float64, as with Unmarshal.
+func (j JSON) Map() (map[string]interface{}, error) {
+	var m map[string]interface{}
+	if err := j.unmarshalKind(&m, JSONKindObject); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Slice unmarshals this JSON, which must hold an array, into a slice.
+// It returns nil and no error if this JSON is null, empty, or holds the
+// literal null. Numbers are decoded as float64, as with Unmarshal.
+func (j JSON) Slice() ([]interface{}, error) {
+	var s []interface{}
+	if err := j.unmarshalKind(&s, JSONKindArray); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// unmarshalKind unmarshals j into dest if it holds a value of the given kind.
+func (j JSON) unmarshalKind(dest interface{}, want JSONKind) error {
+	if !j.Valid || len(bytes.TrimSpace(j.JSON)) == 0 {
+		return nil
+	}
+	kind, err := j.Kind()
+	if err != nil {
+		return err
+	}
+	switch kind {
+	case JSONKindNull:
+		return nil
+	case want:
+		return json.Unmarshal(j.JSON, dest)
+	}
+	return fmt.Errorf("null: JSON value is %s, not %s", kind, want)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (j *JSON) UnmarshalJSON(data []byte) error {
 	j.Set = true
@@ -77,6 +458,12 @@ func (j *JSON) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
+	if ValidateJSON {
+		if err := validateJSON(data); err != nil {
+			return err
+		}
+	}
+
 	j.Valid = true
 	j.JSON = make([]byte, len(data))
 	copy(j.JSON, data)
@@ -84,20 +471,109 @@ func (j *JSON) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// UnmarshalJSONStrict is like UnmarshalJSON, but decodes exactly one value
+// from data with a json.Decoder. Malformed or empty data, trailing garbage
+// such as {"a":1}garbage, and several concatenated values are all errors,
+// and leave j untouched. Whitespace around the value is dropped.
+//
+// It is opt-in: UnmarshalJSON stays permissive unless ValidateJSON is set.
+// encoding/json always hands UnmarshalJSON a single value, so this is for
+// bytes that come from elsewhere.
+func (j *JSON) UnmarshalJSONStrict(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err == io.EOF {
+		return errors.New("null: invalid JSON: empty value")
+	} else if err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errTrailingJSON
+	}
+
+	return j.UnmarshalJSON(raw)
+}
+
+// ReadFrom implements io.ReaderFrom, reading a single JSON value from r.
+// An empty stream produces a null JSON, and anything but whitespace after
+// the first value is an error.
+func (j *JSON) ReadFrom(r io.Reader) (int64, error) {
+	cr := &countingReader{r: r}
+	dec := json.NewDecoder(cr)
+
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err == io.EOF {
+		j.JSON, j.Valid, j.Set = nil, false, true
+		return cr.n, nil
+	} else if err != nil {
+		return cr.n, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return cr.n, errTrailingJSON
+	}
+
+	return cr.n, j.UnmarshalJSON(raw)
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
 // UnmarshalText implements encoding.TextUnmarshaler.
+// The text null and nil text are read as a null JSON, mirroring MarshalText,
+// while empty non-nil text produces a valid, empty JSON.
 func (j *JSON) UnmarshalText(text []byte) error {
 	j.Set = true
-	if text == nil || len(text) == 0 {
+	if text == nil || bytes.Equal(text, NullBytes) {
 		j.JSON = nil
 		j.Valid = false
 	} else {
 		j.JSON = append(j.JSON[0:0], text...)
+		if j.JSON == nil {
+			j.JSON = []byte{}
+		}
 		j.Valid = true
 	}
 
 	return nil
 }
 
+// MarshalBinary implements encoding.BinaryMarshaler, so that encoding/gob
+// keeps Valid and Set. A valid JSON's bytes follow the header unchanged.
+func (j JSON) MarshalBinary() ([]byte, error) {
+	data := binaryHeader(j.Valid, j.Set)
+	if j.Valid {
+		data = append(data, j.JSON...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (j *JSON) UnmarshalBinary(data []byte) error {
+	flags, rest, err := readBinaryHeader(data, "JSON")
+	if err != nil {
+		return err
+	}
+	if flags&binaryFlagValid == 0 {
+		if len(rest) != 0 {
+			return errors.New("null: JSON.UnmarshalBinary: unexpected data after null value")
+		}
+		j.JSON, j.Valid = nil, false
+	} else {
+		j.JSON, j.Valid = append([]byte{}, rest...), true
+	}
+	j.Set = flags&binaryFlagSet != 0
+	return nil
+}
+
 // Marshal will marshal the passed in object,
 // and store it in the JSON member on the JSON object.
 func (j *JSON) Marshal(obj interface{}) error {
@@ -113,21 +589,96 @@ func (j *JSON) Marshal(obj interface{}) error {
 }
 
 // MarshalJSON implements json.Marshaler.
+// An invalid JSON marshals as null even if it still holds bytes. A JSON
+// holding no bytes marshals as null too, even if it is Valid, so a value set
+// with SetValid([]byte{}) or scanned from an empty column is written as
+// null: no bytes are not a JSON value, and returning them would make
+// encoding/json fail for the whole document. Such a value does not
+// round-trip, as null unmarshals into a null JSON. Any other bytes are
+// returned as they are, and are checked first only if ValidateJSON is set.
 func (j JSON) MarshalJSON() ([]byte, error) {
-	if len(j.JSON) == 0 || j.JSON == nil {
+	if !j.Valid || len(j.JSON) == 0 {
 		return NullBytes, nil
 	}
+	if ValidateJSON {
+		if err := validateJSON(j.JSON); err != nil {
+			return nil, err
+		}
+	}
 	return j.JSON, nil
 }
 
+// AppendJSON appends the JSON encoding of j to dst and returns the extended
+// buffer, for encoders that marshal into a reused buffer. The output is the
+// same as MarshalJSON's, and is copied straight from j without going through
+// encoding/json's compaction. On error dst is returned unchanged.
+func (j JSON) AppendJSON(dst []byte) ([]byte, error) {
+	b, err := j.MarshalJSON()
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, b...), nil
+}
+
+// AppendTo appends j's raw bytes to dst, or null if j is null or empty, and
+// returns the extended buffer. Unlike AppendJSON it never validates, even
+// with ValidateJSON set, so it cannot fail: it is the primitive for
+// hand-rolled encoders whose input is already known to be well-formed.
+func (j JSON) AppendTo(dst []byte) []byte {
+	if !j.Valid || len(j.JSON) == 0 {
+		return append(dst, NullBytes...)
+	}
+	return append(dst, j.JSON...)
+}
+
+// MarshalJSONSlice encodes js as a JSON array into a single buffer, with
+// null for the null elements. Like AppendJSON, each element is copied as it
+// is, so unlike json.Marshal(js) its whitespace is kept.
+func MarshalJSONSlice(js []JSON) ([]byte, error) {
+	if js == nil {
+		return append([]byte{}, NullBytes...), nil
+	}
+	n := 1 + len(js)
+	for _, j := range js {
+		if !j.Valid || len(j.JSON) == 0 {
+			n += len(NullBytes)
+		} else {
+			n += len(j.JSON)
+		}
+	}
+	buf := make([]byte, 0, n)
+	buf = append(buf, '[')
+	for i, j := range js {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		var err error
+		if buf, err = j.AppendJSON(buf); err != nil {
+			return nil, err
+		}
+	}
+	return append(buf, ']'), nil
+}
+
 // MarshalText implements encoding.TextMarshaler.
+// A null JSON marshals to null, like MarshalJSON, while a valid JSON
+// marshals to its raw bytes even when they are empty.
 func (j JSON) MarshalText() ([]byte, error) {
 	if !j.Valid {
-		return nil, nil
+		return NullBytes, nil
 	}
 	return j.JSON, nil
 }
 
+// String implements fmt.Stringer, returning the same text as MarshalJSON:
+// the raw JSON, or null for null and empty JSON's.
+func (j JSON) String() string {
+	if len(j.JSON) == 0 || !j.Valid {
+		return string(NullBytes)
+	}
+	return string(j.JSON)
+}
+
 // SetValid changes this JSON's value and also sets it to be non-null.
 func (j *JSON) SetValid(n []byte) {
 	j.JSON = n
@@ -135,6 +686,61 @@ func (j *JSON) SetValid(n []byte) {
 	j.Set = true
 }
 
+// SetValidStrict is like SetValid but returns an error, leaving
+// the JSON untouched, if n is not well-formed JSON.
+func (j *JSON) SetValidStrict(n []byte) error {
+	if err := validateJSON(n); err != nil {
+		return err
+	}
+	j.SetValid(n)
+	return nil
+}
+
+// SetNull changes this JSON's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (j *JSON) SetNull() {
+	j.JSON = nil
+	j.Valid = false
+	j.Set = true
+}
+
+// Clone returns a copy of this JSON whose byte slice is freshly allocated
+// and never aliases the original, so either can be mutated safely.
+// The slice is nil for null JSON's; Valid and Set are copied as-is.
+func (j JSON) Clone() JSON {
+	c := j
+	c.JSON = nil
+	if j.Valid {
+		c.JSON = append([]byte{}, j.JSON...)
+	}
+	return c
+}
+
+// ValueOrZero returns the inner value if valid, otherwise nil.
+func (j JSON) ValueOrZero() []byte {
+	if !j.Valid {
+		return nil
+	}
+	return j.JSON
+}
+
+// ValueOrDefault returns a copy of this JSON's value, or def if this JSON is null.
+// The returned slice never aliases the JSON's internal buffer.
+func (j JSON) ValueOrDefault(def []byte) []byte {
+	if !j.Valid {
+		return def
+	}
+	return append([]byte{}, j.JSON...)
+}
+
+// RawMessage returns this JSON's value as a json.RawMessage, or nil if this JSON is null.
+func (j JSON) RawMessage() json.RawMessage {
+	if !j.Valid {
+		return nil
+	}
+	return json.RawMessage(j.JSON)
+}
+
 // Ptr returns a pointer to this JSON's value, or a nil pointer if this JSON is null.
 func (j JSON) Ptr() *[]byte {
 	if !j.Valid {
@@ -143,31 +749,923 @@ func (j JSON) Ptr() *[]byte {
 	return &j.JSON
 }
 
+// Len returns the length in bytes of this JSON's value, or 0 if it is null.
+func (j JSON) Len() int {
+	if !j.Valid {
+		return 0
+	}
+	return len(j.JSON)
+}
+
+// Size returns the length in bytes this JSON's value would have after
+// json.Compact, or 0 if it is null. Whitespace inside strings is counted,
+// and the value is not checked for well-formedness. It does not allocate.
+func (j JSON) Size() int {
+	if !j.Valid {
+		return 0
+	}
+	n := 0
+	inString, escaped := false, false
+	for _, c := range j.JSON {
+		switch {
+		case inString:
+			if escaped {
+				escaped = false
+			} else if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inString = false
+			}
+		case c == ' ', c == '\t', c == '\n', c == '\r':
+			continue
+		case c == '"':
+			inString = true
+		}
+		n++
+	}
+	return n
+}
+
+// IsNull returns true if this JSON is null or holds the JSON literal null.
+// Valid alone does not tell the two apart: UnmarshalJSON stores null as an
+// invalid value, but Scan (unless ScanJSONNullAsNull is set) and SetValid
+// keep the literal as a valid one.
+func (j JSON) IsNull() bool {
+	return !j.Valid || bytes.Equal(bytes.TrimSpace(j.JSON), NullBytes)
+}
+
+// Kind returns the kind of value this JSON holds, judged from its first
+// token only, so the rest of the value is not checked for well-formedness.
+// A null JSON is JSONKindNone, while one holding the literal null is
+// JSONKindNull. An error is returned if a valid JSON is empty or does not
+// start with a JSON value.
+func (j JSON) Kind() (JSONKind, error) {
+	if !j.Valid {
+		return JSONKindNone, nil
+	}
+	data := bytes.TrimLeft(j.JSON, " \t\r\n")
+	if len(data) == 0 {
+		return JSONKindNone, errors.New("null: invalid JSON: empty value")
+	}
+	switch c := data[0]; {
+	case c == '{':
+		return JSONKindObject, nil
+	case c == '[':
+		return JSONKindArray, nil
+	case c == '"':
+		return JSONKindString, nil
+	case c == '-' || '0' <= c && c <= '9':
+		return JSONKindNumber, nil
+	case bytes.HasPrefix(data, []byte("true")), bytes.HasPrefix(data, []byte("false")):
+		return JSONKindBool, nil
+	case bytes.HasPrefix(data, NullBytes):
+		return JSONKindNull, nil
+	default:
+		return JSONKindNone, fmt.Errorf("null: invalid JSON: invalid character %q looking for beginning of value", c)
+	}
+}
+
 // IsZero returns true for null or zero JSON's, for future omitempty support (Go 1.4?)
 func (j JSON) IsZero() bool {
 	return !j.Valid
 }
 
+// Or returns this JSON if it is valid, or other if it is not.
+func (j JSON) Or(other JSON) JSON {
+	if j.Valid {
+		return j
+	}
+	return other
+}
+
+// IfValid calls fn with this JSON's value if it is valid, and does nothing otherwise.
+// The slice passed to fn is j.JSON itself, not a copy, so fn must not modify
+// it or keep it past the call; use Get for a copy.
+func (j JSON) IfValid(fn func([]byte)) {
+	if j.Valid {
+		fn(j.JSON)
+	}
+}
+
+// CoalesceJSON returns the first valid JSON in vals,
+// or a zero, invalid JSON if none of them are valid.
+func CoalesceJSON(vals ...JSON) JSON {
+	for _, v := range vals {
+		if v.Valid {
+			return v
+		}
+	}
+	return JSON{}
+}
+
+// Get returns a copy of this JSON's value and true, or nil and false if it is null.
+func (j JSON) Get() ([]byte, bool) {
+	if !j.Valid {
+		return nil, false
+	}
+	return append([]byte{}, j.JSON...), true
+}
+
+// Equal returns true if both JSON's are null, or if both are valid and hold
+// the same document once whitespace and object key order are disregarded,
+// that is if they have the same Canonical form.
+func (j JSON) Equal(other JSON) bool {
+	if !j.Valid || !other.Valid {
+		return j.Valid == other.Valid
+	}
+	if bytes.Equal(j.JSON, other.JSON) {
+		return true
+	}
+
+	a, err := j.Canonical()
+	if err != nil {
+		return false
+	}
+	b, err := other.Canonical()
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(a.JSON, b.JSON)
+}
+
+// Canonical returns a copy of this JSON in a deterministic encoding, so that
+// two documents holding the same data are byte for byte identical. The rules
+// are:
+//
+//   - insignificant whitespace is removed;
+//   - object keys are sorted by their bytes, and when a key is repeated only
+//     its last value is kept;
+//   - strings are written with the escaping of encoding/json, except that
+//     <, > and & are not escaped, so "\u0041" becomes "A";
+//   - numbers keep their exact value and are written the way JavaScript
+//     writes a number, without rounding it to a float64: integers up to 21
+//     digits in full, and other numbers in plain decimal notation if they are
+//     at least 1e-6 and below 1e21 in magnitude, or in exponent notation such
+//     as 1.5e+21 otherwise. So 1.0, 1e0 and 10e-1 all become 1, and -0
+//     becomes 0.
+//
+// A null or empty JSON is returned unchanged. An error is returned if the
+// value is not well-formed JSON.
+func (j JSON) Canonical() (JSON, error) {
+	if !j.Valid || len(j.JSON) == 0 {
+		return j, nil
+	}
+	v, err := decodeJSON(j.JSON)
+	if err != nil {
+		return j, err
+	}
+	var buf bytes.Buffer
+	if err := writeCanonicalJSON(&buf, v); err != nil {
+		return j, err
+	}
+	return NewJSON(buf.Bytes(), true, j.Set), nil
+}
+
+// Hash returns a 64-bit FNV-1a hash of this JSON's Canonical form, so JSON's
+// that are Equal hash the same, or 0 if it is null. Malformed JSON, which
+// has no canonical form, is hashed as it is. The hash is stable across runs
+// and versions of Go, and is meant for cache keys rather than security.
+func (j JSON) Hash() uint64 {
+	if !j.Valid {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write(j.canonicalBytes())
+	return h.Sum64()
+}
+
+// HashBytes returns the SHA-256 digest of this JSON's Canonical form, for
+// content addressing, or all zeros if it is null. Malformed JSON is hashed
+// as it is.
+func (j JSON) HashBytes() [32]byte {
+	if !j.Valid {
+		return [32]byte{}
+	}
+	return sha256.Sum256(j.canonicalBytes())
+}
+
+// canonicalBytes returns j's Canonical form, or its raw bytes if it has none.
+func (j JSON) canonicalBytes() []byte {
+	c, err := j.Canonical()
+	if err != nil {
+		return j.JSON
+	}
+	return c.JSON
+}
+
+// EqualBytes compares this JSON to b as if b had been passed to JSONFrom.
+func (j JSON) EqualBytes(b []byte) bool {
+	return j.Equal(JSONFrom(b))
+}
+
+// Compact removes insignificant whitespace from this JSON's value in place.
+// It is a no-op for null or empty JSON's, and returns an error if the
+// value is not well-formed JSON, in which case the value is left unchanged.
+func (j *JSON) Compact() error {
+	if !j.Valid || len(j.JSON) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, j.JSON); err != nil {
+		return err
+	}
+	j.JSON = buf.Bytes()
+	return nil
+}
+
+// Compacted returns a copy of this JSON with insignificant whitespace removed,
+// leaving the receiver untouched.
+func (j JSON) Compacted() (JSON, error) {
+	c := j
+	if err := c.Compact(); err != nil {
+		return j, err
+	}
+	return c, nil
+}
+
+// Indent returns a copy of this JSON pretty-printed with json.Indent.
+// Null or empty JSON's are returned unchanged, and an error is returned
+// if the value is not well-formed JSON.
+func (j JSON) Indent(prefix, indent string) (JSON, error) {
+	if !j.Valid || len(j.JSON) == 0 {
+		return j, nil
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, j.JSON, prefix, indent); err != nil {
+		return j, err
+	}
+	return NewJSON(buf.Bytes(), j.Valid, j.Set), nil
+}
+
+// ApplyMergePatch applies an RFC 7386 JSON merge patch to this JSON.
+// Object members in the patch are merged recursively into the target,
+// members set to null are removed, and any other patch value replaces the
+// target entirely. A null receiver is treated as an empty document.
+func (j *JSON) ApplyMergePatch(patch []byte) error {
+	p, err := decodeJSON(patch)
+	if err != nil {
+		return err
+	}
+
+	var target interface{}
+	if j.Valid && len(j.JSON) != 0 {
+		if target, err = decodeJSON(j.JSON); err != nil {
+			return err
+		}
+	}
+
+	return j.Marshal(mergePatch(target, p))
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = make(map[string]interface{}, len(p))
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatch(t[k], v)
+	}
+	return t
+}
+
+// Diff returns the RFC 7386 merge patch that turns this JSON into other, so
+// that applying it with ApplyMergePatch yields other. Members missing from
+// other are removed with null, and only the members that changed are set.
+// Null or empty JSON's are treated as empty objects, but a literal JSON null
+// is kept as it is: when either side is not an object, the patch is other
+// as a whole. A merge patch cannot set an object member to null, so an
+// error is returned if other holds one.
+func (j JSON) Diff(other JSON) (JSON, error) {
+	a, err := j.diffOperand()
+	if err != nil {
+		return JSON{}, err
+	}
+	b, err := other.diffOperand()
+	if err != nil {
+		return JSON{}, err
+	}
+
+	patch, err := diffValues(a, b, "$")
+	if err != nil {
+		return JSON{}, err
+	}
+
+	var out JSON
+	if err := out.Marshal(patch); err != nil {
+		return JSON{}, err
+	}
+	return out, nil
+}
+
+func (j JSON) diffOperand() (interface{}, error) {
+	if !j.Valid || len(j.JSON) == 0 {
+		return map[string]interface{}{}, nil
+	}
+	return decodeJSON(j.JSON)
+}
+
+func diffValues(a, b interface{}, path string) (interface{}, error) {
+	y, ok := b.(map[string]interface{})
+	if !ok {
+		return b, nil
+	}
+
+	// When a is not an object, including a JSON null, x is a nil map and
+	// every member of y is added, so the patch is y as a whole.
+	x, _ := a.(map[string]interface{})
+	patch := make(map[string]interface{})
+	for k := range x {
+		if _, ok := y[k]; !ok {
+			patch[k] = nil
+		}
+	}
+	for k, v := range y {
+		if v == nil {
+			return nil, fmt.Errorf("null: cannot diff JSON: a merge patch cannot set %s.%s to null", path, k)
+		}
+		old, ok := x[k]
+		if ok && reflect.DeepEqual(old, v) {
+			continue
+		}
+		d, err := diffValues(old, v, path+"."+k)
+		if err != nil {
+			return nil, err
+		}
+		patch[k] = d
+	}
+	return patch, nil
+}
+
+// MapValue decodes this JSON, passes the value to fn, and returns fn's
+// result marshaled into a new JSON, leaving the receiver untouched. Numbers
+// reach fn as json.Number, so they round-trip exactly. A nil result gives a
+// null JSON, as Marshal(nil) does. Null or empty JSON's are returned
+// unchanged without calling fn, and errors from decoding, fn or marshaling
+// are returned as is.
+func (j JSON) MapValue(fn func(v interface{}) (interface{}, error)) (JSON, error) {
+	if !j.Valid || len(j.JSON) == 0 {
+		return j, nil
+	}
+
+	v, err := decodeJSON(j.JSON)
+	if err != nil {
+		return JSON{}, err
+	}
+	if v, err = fn(v); err != nil {
+		return JSON{}, err
+	}
+
+	var out JSON
+	if err := out.Marshal(v); err != nil {
+		return JSON{}, err
+	}
+	return out, nil
+}
+
+// GetPath walks nested objects by key and returns the value found at the
+// end of path as a new JSON. Every segment is treated as an object key,
+// including numeric ones. A missing key, or a null along the way, yields an
+// unset null JSON; traversing into any other non-object is an error.
+func (j JSON) GetPath(path ...string) (JSON, error) {
+	if !j.Valid || len(j.JSON) == 0 {
+		return JSON{}, nil
+	}
+
+	cur := json.RawMessage(j.JSON)
+	for _, key := range path {
+		cur = bytes.TrimSpace(cur)
+		if bytes.Equal(cur, NullBytes) {
+			return JSON{}, nil
+		}
+		if len(cur) == 0 || cur[0] != '{' {
+			return JSON{}, fmt.Errorf("null: cannot get key %q from non-object JSON", key)
+		}
+
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(cur, &obj); err != nil {
+			return JSON{}, err
+		}
+		v, ok := obj[key]
+		if !ok {
+			return JSON{}, nil
+		}
+		cur = v
+	}
+
+	var out JSON
+	if err := out.UnmarshalJSON(cur); err != nil {
+		return JSON{}, err
+	}
+	return out, nil
+}
+
+// Has reports whether this JSON, which must hold an object, has key at its
+// top level. It reads the object's tokens with a json.Decoder and stops at
+// the first match, skipping over values rather than decoding them. A null or
+// empty JSON, or one holding the literal null, has no keys.
+func (j JSON) Has(key string) (bool, error) {
+	if !j.Valid || len(bytes.TrimSpace(j.JSON)) == 0 {
+		return false, nil
+	}
+	kind, err := j.Kind()
+	if err != nil {
+		return false, err
+	}
+	switch kind {
+	case JSONKindNull:
+		return false, nil
+	case JSONKindObject:
+	default:
+		return false, fmt.Errorf("null: JSON value is %s, not %s", kind, JSONKindObject)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(j.JSON))
+	if _, err := dec.Token(); err != nil {
+		return false, err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return false, err
+		}
+		if tok.(string) == key {
+			return true, nil
+		}
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// Merge deep-merges two JSON documents into a new JSON. Objects are merged
+// key by key and arrays found at the same place are concatenated. Unlike
+// ApplyMergePatch neither side takes precedence, so an error is returned
+// wherever either side holds a non-container value at the same path, or
+// the two sides hold different kinds of container. Null or empty operands
+// are treated as empty objects.
+func (j JSON) Merge(other JSON) (JSON, error) {
+	a, err := j.mergeOperand()
+	if err != nil {
+		return JSON{}, err
+	}
+	b, err := other.mergeOperand()
+	if err != nil {
+		return JSON{}, err
+	}
+
+	merged, err := mergeValues(a, b, "$")
+	if err != nil {
+		return JSON{}, err
+	}
+
+	var out JSON
+	if err := out.Marshal(merged); err != nil {
+		return JSON{}, err
+	}
+	return out, nil
+}
+
+func (j JSON) mergeOperand() (interface{}, error) {
+	if !j.Valid || len(j.JSON) == 0 {
+		return map[string]interface{}{}, nil
+	}
+	v, err := decodeJSON(j.JSON)
+	if v == nil && err == nil {
+		v = map[string]interface{}{}
+	}
+	return v, err
+}
+
+func mergeValues(a, b interface{}, path string) (interface{}, error) {
+	switch x := a.(type) {
+	case map[string]interface{}:
+		if y, ok := b.(map[string]interface{}); ok {
+			for k, v := range y {
+				if existing, ok := x[k]; ok {
+					m, err := mergeValues(existing, v, path+"."+k)
+					if err != nil {
+						return nil, err
+					}
+					v = m
+				}
+				x[k] = v
+			}
+			return x, nil
+		}
+	case []interface{}:
+		if y, ok := b.([]interface{}); ok {
+			return append(x, y...), nil
+		}
+	}
+	return nil, fmt.Errorf("null: cannot merge JSON values at %s", path)
+}
+
 // Scan implements the Scanner interface.
+// []byte values are always copied, since the driver may reuse their memory
+// for the next row. This includes sql.RawBytes, which must never be aliased:
+// it is only valid until the next call to Next, Scan or Close on the rows.
 func (j *JSON) Scan(value interface{}) error {
-	if value == nil {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
+	if raw, ok := value.(sql.RawBytes); ok {
+		value = []byte(raw)
+	}
+	if b, ok := value.([]byte); ok && TreatEmptyBytesAsNull && len(b) == 0 {
 		j.JSON, j.Valid, j.Set = nil, false, false
 		return nil
 	}
+	if ScanJSONNullAsNull && isJSONNullValue(value) {
+		j.JSON, j.Valid, j.Set = nil, false, true
+		return nil
+	}
+	switch v := value.(type) {
+	case nil:
+		j.JSON, j.Valid, j.Set = nil, false, false
+		return nil
+	case []byte:
+		// Drivers may reuse their buffer, so this copy is required,
+		// but it is the only one made.
+		if v == nil {
+			j.JSON = nil
+		} else {
+			j.JSON = make([]byte, len(v))
+			copy(j.JSON, v)
+		}
+	case string:
+		j.JSON = []byte(v)
+	default:
+		j.Valid, j.Set = true, true
+		return convert.ConvertAssign(&j.JSON, value)
+	}
+	j.Valid, j.Set = true, true
+	return nil
+}
+
+// ScanContext is like Scan, but first returns ctx.Err() without touching j
+// if ctx is already done, so a canceled request does not pay for copying a
+// large value. sql.Rows.Scan never calls it: it is an opt-in helper for
+// custom scan loops, which should call it with each column's driver value.
+func (j *JSON) ScanContext(ctx context.Context, value interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return j.Scan(value)
+}
+
+// ScanInto is like Scan, but copies []byte and string values into buf when
+// it has the capacity, instead of allocating. It is meant for loops over
+// sql.Rows that reuse one scratch buffer, for example from a sync.Pool, or
+// the previous row's j.JSON.
+//
+// When buf is used, j.JSON aliases it: writing to buf changes j, and j must
+// not be used once buf has been handed to another ScanInto call or returned
+// to a pool. Copy j with Clone to keep it past that point. When buf is too
+// small a new slice is allocated, which may be kept as the next buf.
+func (j *JSON) ScanInto(value interface{}, buf []byte) error {
+	if raw, ok := value.(sql.RawBytes); ok {
+		value = []byte(raw)
+	}
+	if b, ok := value.([]byte); ok && TreatEmptyBytesAsNull && len(b) == 0 {
+		j.JSON, j.Valid, j.Set = nil, false, false
+		return nil
+	}
+	if ScanJSONNullAsNull && isJSONNullValue(value) {
+		j.JSON, j.Valid, j.Set = nil, false, true
+		return nil
+	}
+	switch v := value.(type) {
+	case []byte:
+		if v == nil {
+			j.JSON = nil
+		} else {
+			j.JSON = append(scratch(buf), v...)
+		}
+	case string:
+		j.JSON = append(scratch(buf), v...)
+	default:
+		return j.Scan(value)
+	}
 	j.Valid, j.Set = true, true
-	return convert.ConvertAssign(&j.JSON, value)
+	return nil
+}
+
+// isJSONNullValue reports whether a scanned string or []byte holds the JSON
+// literal null.
+func isJSONNullValue(value interface{}) bool {
+	switch v := value.(type) {
+	case []byte:
+		return bytes.Equal(bytes.TrimSpace(v), NullBytes)
+	case string:
+		return strings.TrimSpace(v) == "null"
+	}
+	return false
+}
+
+// scratch returns buf emptied, or a non-nil empty slice if buf is nil, so
+// that an empty value still scans as valid, empty JSON.
+func scratch(buf []byte) []byte {
+	if buf == nil {
+		return []byte{}
+	}
+	return buf[:0]
 }
 
 // Value implements the driver Valuer interface.
+// It returns the raw []byte, or a string if ValueJSONAsString is set.
 func (j JSON) Value() (driver.Value, error) {
+	if ValueJSONAsString {
+		return j.ValueString()
+	}
 	if !j.Valid {
 		return nil, nil
 	}
 	return j.JSON, nil
 }
 
+// ValueString is like Value, but returns the JSON as a string, for drivers
+// that would write a []byte to a text column as binary data. See
+// ValueJSONAsString to make Value do the same.
+func (j JSON) ValueString() (driver.Value, error) {
+	if !j.Valid {
+		return nil, nil
+	}
+	return string(j.JSON), nil
+}
+
 // Randomize for sqlboiler
 func (j *JSON) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
-	j.JSON = []byte(`"` + randomize.Str(nextInt, 1) + `"`)
-	j.Valid = true
+	if shouldBeNull {
+		j.JSON = nil
+		j.Valid = false
+	} else {
+		j.JSON, _ = json.Marshal(randomJSONValue(nextInt, 2))
+		j.Valid = true
+	}
+}
+
+// randomJSONValue returns a random string, number, bool, object or array,
+// nesting containers at most depth levels deep.
+func randomJSONValue(nextInt func() int64, depth int) interface{} {
+	kinds := int64(5)
+	if depth <= 0 {
+		kinds = 3
+	}
+
+	switch nextInt() % kinds {
+	case 1:
+		return float64(nextInt()%100000) / 100
+	case 2:
+		return nextInt()%2 == 1
+	case 3:
+		obj := make(map[string]interface{})
+		for n := nextInt() % 4; n >= 0; n-- {
+			obj[randomize.Str(nextInt, 4)] = randomJSONValue(nextInt, depth-1)
+		}
+		return obj
+	case 4:
+		arr := make([]interface{}, nextInt()%4)
+		for i := range arr {
+			arr[i] = randomJSONValue(nextInt, depth-1)
+		}
+		return arr
+	default:
+		return randomize.Str(nextInt, 1)
+	}
+}
+
+func validateJSON(data []byte) error {
+	if !json.Valid(data) {
+		return fmt.Errorf("null: invalid JSON: %q", data)
+	}
+	return nil
+}
+
+// relaxJSON rewrites the relaxations JSONFromRelaxed accepts into strict
+// JSON. It does not check the rest of data, which is copied as it is.
+func relaxJSON(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		switch c := data[i]; {
+		case c == '"':
+			end := stringEnd(data, i)
+			out = append(out, data[i:end]...)
+			i = end - 1
+		case c == '\'':
+			out = append(out, '"')
+			for i++; i < len(data) && data[i] != '\''; i++ {
+				switch {
+				case data[i] == '"':
+					out = append(out, '\\', '"')
+				case data[i] == '\\' && i+1 < len(data) && data[i+1] == '\'':
+					out = append(out, '\'')
+					i++
+				case data[i] == '\\' && i+1 < len(data):
+					out = append(out, data[i], data[i+1])
+					i++
+				default:
+					out = append(out, data[i])
+				}
+			}
+			if i < len(data) {
+				out = append(out, '"')
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			out = append(out, '\n')
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return nil, errors.New("null: invalid JSON: unterminated comment")
+			}
+			out = append(out, ' ')
+			i += end + 3
+		case c == ']' || c == '}':
+			out = append(dropTrailingComma(out), c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// stringEnd returns the index just past the double-quoted string starting
+// at data[start], or len(data) if it is unterminated.
+func stringEnd(data []byte, start int) int {
+	for i := start + 1; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(data)
+}
+
+// dropTrailingComma removes a comma ending out, past any whitespace, if it
+// follows an element. A comma with nothing before it is kept, so that input
+// such as [,] stays invalid.
+func dropTrailingComma(out []byte) []byte {
+	i := lastNonSpace(out)
+	if i < 0 || out[i] != ',' {
+		return out
+	}
+	if j := lastNonSpace(out[:i]); j < 0 || out[j] == '[' || out[j] == '{' || out[j] == ',' {
+		return out
+	}
+	return append(out[:i], out[i+1:]...)
+}
+
+func lastNonSpace(b []byte) int {
+	i := len(b) - 1
+	for i >= 0 && (b[i] == ' ' || b[i] == '\t' || b[i] == '\r' || b[i] == '\n') {
+		i--
+	}
+	return i
+}
+
+func writeCanonicalJSON(buf *bytes.Buffer, v interface{}) error {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, k)
+			buf.WriteByte(':')
+			if err := writeCanonicalJSON(buf, x[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, e := range x {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonicalJSON(buf, e); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case string:
+		writeCanonicalString(buf, x)
+	case json.Number:
+		n, err := canonicalNumber(string(x))
+		if err != nil {
+			return err
+		}
+		buf.WriteString(n)
+	case bool:
+		buf.WriteString(strconv.FormatBool(x))
+	case nil:
+		buf.Write(NullBytes)
+	default:
+		return fmt.Errorf("null: cannot encode %T as canonical JSON", v)
+	}
+	return nil
+}
+
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	// Encoding a string cannot fail.
+	_ = enc.Encode(s)
+	// Encode terminates each value with a newline.
+	buf.Truncate(buf.Len() - 1)
+}
+
+// canonicalNumber rewrites the JSON number s as described by Canonical.
+func canonicalNumber(s string) (string, error) {
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	mantissa, exp := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(strings.TrimPrefix(s[i+1:], "+"))
+		if err != nil || e > 1<<30 || e < -(1<<30) {
+			return "", fmt.Errorf("null: JSON number %s is out of range", s)
+		}
+		mantissa, exp = s[:i], e
+	}
+	// The value is digits × 10^exp.
+	digits := mantissa
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		digits = mantissa[:i] + mantissa[i+1:]
+		exp -= len(mantissa) - i - 1
+	}
+	digits = strings.TrimLeft(digits, "0")
+	for strings.HasSuffix(digits, "0") {
+		digits = digits[:len(digits)-1]
+		exp++
+	}
+	if digits == "" {
+		return "0", nil
+	}
+
+	// With k digits, the value is 0.digits × 10^n.
+	k := len(digits)
+	n := k + exp
+	var out string
+	switch {
+	case k <= n && n <= 21:
+		out = digits + strings.Repeat("0", n-k)
+	case 0 < n && n <= 21:
+		out = digits[:n] + "." + digits[n:]
+	case -6 < n && n <= 0:
+		out = "0." + strings.Repeat("0", -n) + digits
+	default:
+		out = digits[:1]
+		if k > 1 {
+			out += "." + digits[1:]
+		}
+		if n-1 >= 0 {
+			out += "e+" + strconv.Itoa(n-1)
+		} else {
+			out += "e-" + strconv.Itoa(1-n)
+		}
+	}
+	if neg {
+		out = "-" + out
+	}
+	return out, nil
+}
+
+// decodeJSON decodes data into an interface{}, keeping numbers as
+// json.Number so they survive being encoded again unchanged.
+func decodeJSON(data []byte) (interface{}, error) {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errTrailingJSON
+	}
+	return v, nil
 }
@@ -2,7 +2,17 @@ package null
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/gob"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -35,6 +45,145 @@ func TestJSONFromPtr(t *testing.T) {
 	assertNullJSON(t, null, "JSONFromPtr(nil)")
 }
 
+func TestJSONUnset(t *testing.T) {
+	v := JSONUnset()
+	assertNullJSON(t, v, "JSONUnset()")
+	if v.IsSet() {
+		t.Error("JSONUnset() should not be Set")
+	}
+}
+
+func TestMustJSON(t *testing.T) {
+	j := MustJSON(jsonJSON)
+	assertJSON(t, j, "MustJSON()")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustJSON() should panic on invalid JSON")
+		}
+	}()
+	MustJSON(invalidJSON)
+}
+
+func TestJSONMustUnmarshal(t *testing.T) {
+	var m map[string]int
+	MustJSON([]byte(`{"a":1}`)).MustUnmarshal(&m)
+	if m["a"] != 1 {
+		t.Errorf("bad MustUnmarshal() result: %v", m)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustUnmarshal() should panic on a type mismatch")
+		}
+	}()
+	var s []string
+	MustJSON([]byte(`{"a":1}`)).MustUnmarshal(&s)
+}
+
+func TestJSONObject(t *testing.T) {
+	j, err := JSONObject(
+		"name", "ann",
+		"id", 12,
+		"tags", []string{"a", "b"},
+		"meta", map[string]interface{}{"active": true},
+		"parent", JSONFrom([]byte(`{"id":1}`)),
+		"deleted", NewString("", false, true),
+	)
+	maybePanic(err)
+	assertJSONEquals(t, j.JSON, `{"name":"ann","id":12,"tags":["a","b"],"meta":{"active":true},"parent":{"id":1},"deleted":null}`, "JSONObject()")
+	if !j.Valid || !j.Set || !json.Valid(j.JSON) {
+		t.Errorf("JSONObject() should produce a valid JSON, got %#v", j)
+	}
+
+	empty, err := JSONObject()
+	maybePanic(err)
+	assertJSONEquals(t, empty.JSON, `{}`, "JSONObject() empty")
+
+	tests := []struct {
+		pairs []interface{}
+		want  string
+	}{
+		{[]interface{}{"a", 1, "b"}, "null: JSONObject needs key and value pairs, got 3 arguments"},
+		{[]interface{}{"a", 1, 2, 3}, "null: JSONObject key at argument 2 is int, not string"},
+		{[]interface{}{"a", 1, "a", 2}, `null: JSONObject key "a" is repeated`},
+	}
+	for _, test := range tests {
+		if _, err := JSONObject(test.pairs...); errString(err) != test.want {
+			t.Errorf("JSONObject(%v): got error %v, want %q", test.pairs, err, test.want)
+		}
+	}
+	if _, err := JSONObject("ch", make(chan int)); err == nil {
+		t.Error("expected error marshaling an unsupported value")
+	}
+}
+
+func TestJSONFromRelaxed(t *testing.T) {
+	fixtures := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"strict", `{"a": [1, "two"]}`, `{"a": [1, "two"]}`},
+		{"single quotes", `{'a': 'b'}`, `{"a": "b"}`},
+		{"escaped single quote", `'it\'s'`, `"it's"`},
+		{"double quote in single quotes", `'say "hi"'`, `"say \"hi\""`},
+		{"other escapes kept", `'a\n\u00e9\\'`, `"a\n\u00e9\\"`},
+		{"single quote in double quotes", `"it's"`, `"it's"`},
+		{"trailing comma in array", `[1, 2, ]`, `[1, 2 ]`},
+		{"trailing comma in object", `{"a": 1,
+}`, `{"a": 1
+}`},
+		{"nested trailing commas", `{"a": [1,],}`, `{"a": [1]}`},
+		{"line comment", "{\"a\": 1 // one\n}", "{\"a\": 1 \n}"},
+		{"line comment at end", "1 // one", "1 \n"},
+		{"block comment", `[1, /* two, */ 3]`, `[1,   3]`},
+		{"comment before trailing comma", `[1, /* more */ ]`, `[1   ]`},
+		{"comment markers in strings", `{"url": "http://x/*y*/", 'c': '//'}`, `{"url": "http://x/*y*/", "c": "//"}`},
+		{"comma in string", `["a,", 'b,']`, `["a,", "b,"]`},
+	}
+	for _, f := range fixtures {
+		j, err := JSONFromRelaxed([]byte(f.in))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", f.name, err)
+			continue
+		}
+		assertJSONEquals(t, j.JSON, f.want, "JSONFromRelaxed() "+f.name)
+		if !j.Valid || !j.Set {
+			t.Errorf("%s: should be valid and set", f.name)
+		}
+	}
+
+	for _, in := range []string{``, `{a: 1}`, `[,]`, `[1,,]`, `{,}`, `'open`, `[1 /* open`, `NaN`, `0x10`} {
+		if _, err := JSONFromRelaxed([]byte(in)); err == nil {
+			t.Errorf("JSONFromRelaxed(%q) should fail", in)
+		}
+	}
+}
+
+func TestJSONFromRawMessage(t *testing.T) {
+	i := JSONFromRawMessage(json.RawMessage(`"hello"`))
+	assertJSON(t, i, "JSONFromRawMessage()")
+
+	null := JSONFromRawMessage(nil)
+	assertNullJSON(t, null, "JSONFromRawMessage(nil)")
+}
+
+func TestJSONRawMessage(t *testing.T) {
+	i := JSONFrom([]byte(`{"a":1}`))
+	wrapped := struct {
+		A json.RawMessage
+		B JSON
+	}{i.RawMessage(), i}
+	data, err := json.Marshal(wrapped)
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"A":{"a":1},"B":{"a":1}}`, "RawMessage() marshal")
+
+	if NewJSON([]byte("stale"), false, true).RawMessage() != nil {
+		t.Error("RawMessage() should be nil for a null JSON")
+	}
+}
+
 type Test struct {
 	Name string
 	Age  int
@@ -103,6 +252,219 @@ func TestUnmarshal(t *testing.T) {
 	}
 }
 
+func TestJSONUnmarshalStrict(t *testing.T) {
+	type doc struct {
+		N    float64
+		Data json.RawMessage
+	}
+	j := JSONFrom([]byte(`{"N": 1e3, "Data": [1e3, 2]}`))
+
+	var d doc
+	err := j.UnmarshalStrict(&d)
+	maybePanic(err)
+	if d.N != 1000 {
+		t.Errorf("bad UnmarshalStrict() number: %v", d.N)
+	}
+	assertJSONEquals(t, d.Data, `[1e3, 2]`, "UnmarshalStrict() raw bytes")
+
+	// Unmarshal goes through MarshalJSON and encoding/json, which compacts.
+	var loose doc
+	err = j.Unmarshal(&loose)
+	maybePanic(err)
+	assertJSONEquals(t, loose.Data, `[1e3,2]`, "Unmarshal() raw bytes")
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`{"N":1,"Extra":true}`, `json: unknown field "Extra"`},
+		{`{"N":1} {"N":2}`, "null: invalid JSON: trailing data after value"},
+	}
+	for _, test := range tests {
+		var d doc
+		if err := JSONFrom([]byte(test.in)).UnmarshalStrict(&d); errString(err) != test.want {
+			t.Errorf("UnmarshalStrict(%s): got error %v, want %q", test.in, err, test.want)
+		}
+	}
+
+	if err := JSONFrom([]byte(`{"N":"x"}`)).UnmarshalStrict(&d); err == nil {
+		t.Error("expected error on a type mismatch")
+	}
+
+	d = doc{N: 5}
+	err = NewJSON(nil, false, true).UnmarshalStrict(&d)
+	maybePanic(err)
+	if d.N != 5 {
+		t.Error("UnmarshalStrict() should leave dest untouched for a null JSON")
+	}
+	if err = j.UnmarshalStrict(nil); err == nil {
+		t.Error("expected error for nil destination")
+	}
+}
+
+func TestUnmarshalWithNumber(t *testing.T) {
+	i := JSONFrom([]byte(`{"id":12345678901234567890,"amount":0.1}`))
+
+	var m map[string]interface{}
+	err := i.UnmarshalWithNumber(&m)
+	maybePanic(err)
+	if id, ok := m["id"].(json.Number); !ok || id.String() != "12345678901234567890" {
+		t.Errorf("expected id to be preserved as a json.Number, got %#v", m["id"])
+	}
+
+	var out JSON
+	err = out.Marshal(m)
+	maybePanic(err)
+	assertJSONEquals(t, out.JSON, `{"amount":0.1,"id":12345678901234567890}`, "UnmarshalWithNumber() round-trip")
+
+	m = nil
+	err = NewJSON(nil, false, true).UnmarshalWithNumber(&m)
+	maybePanic(err)
+	if m != nil {
+		t.Error("UnmarshalWithNumber() should leave dest untouched for a null JSON")
+	}
+	for _, null := range []JSON{NewJSON([]byte(`{"id":1}`), false, true), NewJSON(nil, true, true), {}} {
+		m = map[string]interface{}{"kept": true}
+		err = null.UnmarshalWithNumber(&m)
+		maybePanic(err)
+		if len(m) != 1 || m["kept"] != true {
+			t.Errorf("UnmarshalWithNumber() of %#v should do nothing, got %v", null, m)
+		}
+	}
+
+	for _, in := range []string{`{"id":1} {"id":2}`, `{"id":1}]`, `1 2`} {
+		var v interface{}
+		if err := JSONFrom([]byte(in)).UnmarshalWithNumber(&v); errString(err) != "null: invalid JSON: trailing data after value" {
+			t.Errorf("UnmarshalWithNumber(%s): got error %v, want trailing data error", in, err)
+		}
+	}
+	var v interface{}
+	err = JSONFrom([]byte(" 12345678901234567890 \n")).UnmarshalWithNumber(&v)
+	maybePanic(err)
+	if v != json.Number("12345678901234567890") {
+		t.Errorf("UnmarshalWithNumber() should allow surrounding whitespace, got %#v", v)
+	}
+
+	if err = i.UnmarshalWithNumber(nil); err == nil {
+		t.Error("expected error for nil destination")
+	}
+}
+
+func TestJSONUnmarshalWith(t *testing.T) {
+	type doc struct {
+		ID   interface{}
+		Name string
+	}
+
+	for _, in := range []JSON{
+		JSONFrom([]byte(`{"ID": 1, "Name": "a", "Extra": true}`)),
+		JSONFrom([]byte(`null`)),
+		NewJSON(nil, false, true),
+		NewJSON([]byte(`{"ID": 1}`), false, true),
+		JSONFrom([]byte(`{"ID": 1} {"ID": 2}`)),
+		JSONFrom([]byte(`{"ID": `)),
+		JSONFrom([]byte(`[1]`)),
+	} {
+		want, got := &doc{Name: "x"}, &doc{Name: "x"}
+		wantErr := in.Unmarshal(want)
+		gotErr := in.UnmarshalWith(got)
+		if (wantErr == nil) != (gotErr == nil) || !reflect.DeepEqual(want, got) {
+			t.Errorf("UnmarshalWith(%q) = %+v, %v; Unmarshal() = %+v, %v", in.JSON, got, gotErr, want, wantErr)
+		}
+	}
+
+	j := JSONFrom([]byte(`{"ID": 12345678901234567890, "Name": "a"}`))
+	var d doc
+	err := j.UnmarshalWith(&d, WithUseNumber(), WithDisallowUnknownFields(), WithStrict())
+	maybePanic(err)
+	if id, ok := d.ID.(json.Number); !ok || id.String() != "12345678901234567890" {
+		t.Errorf("WithUseNumber() should keep a json.Number, got %#v", d.ID)
+	}
+
+	tests := []struct {
+		j    JSON
+		opts []DecodeOption
+		want string
+	}{
+		{JSONFrom([]byte(`{"Extra": 1}`)), []DecodeOption{WithDisallowUnknownFields()}, `json: unknown field "Extra"`},
+		{JSONFrom([]byte(`{"Extra": 1}`)), []DecodeOption{WithUseNumber(), WithDisallowUnknownFields()}, `json: unknown field "Extra"`},
+		{JSONFrom([]byte(`{"ID": 1} 2`)), []DecodeOption{WithStrict(), WithUseNumber()}, "null: invalid JSON: trailing data after value"},
+		{NewJSON(nil, false, true), []DecodeOption{WithStrict()}, "null: cannot decode a null JSON"},
+		{NewJSON([]byte(`{}`), false, true), []DecodeOption{WithStrict(), WithDisallowUnknownFields()}, "null: cannot decode a null JSON"},
+		{NewJSON(nil, true, true), []DecodeOption{WithStrict()}, "null: invalid JSON: empty value"},
+		{JSONFrom([]byte(" ")), nil, "null: invalid JSON: empty value"},
+		{JSONFrom([]byte(`{"Extra": 1}`)), []DecodeOption{WithStrict()}, ""},
+		{JSONFrom([]byte(`null`)), []DecodeOption{WithStrict()}, ""},
+	}
+	for _, test := range tests {
+		var d doc
+		if err := test.j.UnmarshalWith(&d, test.opts...); errString(err) != test.want {
+			t.Errorf("UnmarshalWith(%q): got error %v, want %q", test.j.JSON, err, test.want)
+		}
+	}
+
+	var raw struct{ Data json.RawMessage }
+	err = JSONFrom([]byte(`{"Data": [1, 2]}`)).UnmarshalWith(&raw)
+	maybePanic(err)
+	assertJSONEquals(t, raw.Data, `[1, 2]`, "UnmarshalWith() raw bytes")
+
+	if err := j.UnmarshalWith(nil); err == nil {
+		t.Error("expected error for nil destination")
+	}
+}
+
+func TestUnmarshalOrDefault(t *testing.T) {
+	def := []byte(`{"Name":"default","Age":1}`)
+
+	var test Test
+	err := JSONFrom([]byte(`{"Name":"hello","Age":15}`)).UnmarshalOrDefault(&test, def)
+	maybePanic(err)
+	if test.Name != "hello" || test.Age != 15 {
+		t.Errorf("Mismatch between received and expected, got: %#v", test)
+	}
+
+	test = Test{}
+	err = NewJSON(nil, false, true).UnmarshalOrDefault(&test, def)
+	maybePanic(err)
+	if test.Name != "default" || test.Age != 1 {
+		t.Errorf("Expected default to be used, got: %#v", test)
+	}
+
+	test = Test{Name: "untouched"}
+	err = NewJSON(nil, false, true).UnmarshalOrDefault(&test, nil)
+	maybePanic(err)
+	if test.Name != "untouched" {
+		t.Errorf("Expected nil default to leave dest untouched, got: %#v", test)
+	}
+
+	if err = NewJSON(nil, false, true).UnmarshalOrDefault(&test, []byte(`[`)); err == nil {
+		t.Error("expected error on malformed default")
+	}
+}
+
+func TestUnmarshalJSONInto(t *testing.T) {
+	i := JSONFrom([]byte(`{"Name":"hello","Age":15}`))
+	test, ok, err := UnmarshalJSONInto[Test](i)
+	maybePanic(err)
+	if !ok {
+		t.Error("expected ok to be true")
+	}
+	if test.Name != "hello" || test.Age != 15 {
+		t.Errorf("Mismatch between received and expected, got: %#v", test)
+	}
+
+	m, ok, err := UnmarshalJSONInto[map[string]int](NewJSON(nil, false, true))
+	maybePanic(err)
+	if ok || m != nil {
+		t.Errorf("expected zero value and false for null JSON, got: %#v %v", m, ok)
+	}
+
+	_, ok, err = UnmarshalJSONInto[Test](JSONFrom([]byte(`[1, 2]`)))
+	if err == nil || ok {
+		t.Error("expected error decoding array into struct")
+	}
+}
+
 func TestUnmarshalJSON(t *testing.T) {
 	var i JSON
 	err := json.Unmarshal(jsonJSON, &i)
@@ -131,6 +493,82 @@ func TestUnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestJSONReadFrom(t *testing.T) {
+	var i JSON
+	n, err := i.ReadFrom(strings.NewReader(" \"hello\"\n"))
+	maybePanic(err)
+	assertJSON(t, i, "ReadFrom()")
+	if n != 9 {
+		t.Errorf("expected 9 bytes read, got %d", n)
+	}
+
+	var obj JSON
+	_, err = obj.ReadFrom(strings.NewReader(`{"a": [1, 2]}`))
+	maybePanic(err)
+	assertJSONEquals(t, obj.JSON, `{"a": [1, 2]}`, "ReadFrom() object")
+
+	var empty JSON
+	n, err = empty.ReadFrom(strings.NewReader(""))
+	maybePanic(err)
+	assertNullJSON(t, empty, "ReadFrom() empty")
+	if n != 0 || !empty.Set {
+		t.Error("ReadFrom() empty should read nothing and be Set")
+	}
+
+	var null JSON
+	_, err = null.ReadFrom(strings.NewReader("null"))
+	maybePanic(err)
+	assertNullJSON(t, null, "ReadFrom() null")
+
+	for _, in := range []string{`{"a":1} {"b":2}`, `"hello" garbage`, `{"a":`, `}`} {
+		var bad JSON
+		if _, err = bad.ReadFrom(strings.NewReader(in)); err == nil {
+			t.Errorf("ReadFrom(%s) should fail", in)
+		}
+		assertNullJSON(t, bad, "ReadFrom() bad")
+	}
+}
+
+func TestJSONUnmarshalJSONStrict(t *testing.T) {
+	var i JSON
+	err := i.UnmarshalJSONStrict([]byte(" \"hello\"\n"))
+	maybePanic(err)
+	assertJSON(t, i, "UnmarshalJSONStrict()")
+
+	var null JSON
+	err = null.UnmarshalJSONStrict(NullBytes)
+	maybePanic(err)
+	assertNullJSON(t, null, "UnmarshalJSONStrict() null")
+	if !null.Set {
+		t.Error("UnmarshalJSONStrict() null should be Set")
+	}
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`{"a":1}garbage`, "null: invalid JSON: trailing data after value"},
+		{`"hello" garbage`, "null: invalid JSON: trailing data after value"},
+		{`{"a":1}{"b":2}`, "null: invalid JSON: trailing data after value"},
+		{`1 2 3`, "null: invalid JSON: trailing data after value"},
+		{"  ", "null: invalid JSON: empty value"},
+		{`{"a":`, "unexpected EOF"},
+	}
+	for _, test := range tests {
+		bad := JSONFrom([]byte(`{"a":1}`))
+		if err := bad.UnmarshalJSONStrict([]byte(test.in)); errString(err) != test.want {
+			t.Errorf("UnmarshalJSONStrict(%s): got error %v, want %q", test.in, err, test.want)
+		}
+		assertJSONEquals(t, bad.JSON, `{"a":1}`, "UnmarshalJSONStrict() bad")
+	}
+
+	// The permissive default keeps trailing data verbatim.
+	var loose JSON
+	err = loose.UnmarshalJSON([]byte(`{"a":1}garbage`))
+	maybePanic(err)
+	assertJSONEquals(t, loose.JSON, `{"a":1}garbage`, "UnmarshalJSON() trailing data")
+}
+
 func TestTextUnmarshalJSON(t *testing.T) {
 	var i JSON
 	err := i.UnmarshalText([]byte(`"hello"`))
@@ -140,7 +578,17 @@ func TestTextUnmarshalJSON(t *testing.T) {
 	var blank JSON
 	err = blank.UnmarshalText([]byte(""))
 	maybePanic(err)
-	assertNullJSON(t, blank, "UnmarshalText() empty []byte")
+	if !blank.Valid || blank.JSON == nil || len(blank.JSON) != 0 {
+		t.Errorf("UnmarshalText() empty []byte should be valid and empty, got: %#v", blank)
+	}
+
+	var null JSON
+	err = null.UnmarshalText(nil)
+	maybePanic(err)
+	assertNullJSON(t, null, "UnmarshalText() nil")
+	if !null.Set || !blank.Set {
+		t.Error("should be Set")
+	}
 }
 
 func TestMarshalJSON(t *testing.T) {
@@ -154,6 +602,141 @@ func TestMarshalJSON(t *testing.T) {
 	data, err = json.Marshal(null)
 	maybePanic(err)
 	assertJSONEquals(t, data, "null", "null json marshal")
+
+	// even when they still hold bytes
+	stale := NewJSON([]byte(`"hello"`), false, true)
+	data, err = json.Marshal(stale)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "invalid json with bytes marshal")
+}
+
+func TestMarshalJSONValidEmpty(t *testing.T) {
+	var set JSON
+	set.SetValid([]byte{})
+	empties := []JSON{JSONFrom([]byte{}), NewJSON(nil, true, true), set}
+
+	ValidateJSON = true
+	defer func() { ValidateJSON = false }()
+
+	for _, j := range empties {
+		if !j.Valid {
+			t.Fatalf("%#v should be valid", j)
+		}
+		data, err := j.MarshalJSON()
+		maybePanic(err)
+		assertJSONEquals(t, data, "null", "valid empty MarshalJSON()")
+
+		data, err = j.AppendJSON([]byte("x:"))
+		maybePanic(err)
+		assertJSONEquals(t, data, "x:null", "valid empty AppendJSON()")
+
+		data, err = json.Marshal(struct{ J JSON }{j})
+		maybePanic(err)
+		assertJSONEquals(t, data, `{"J":null}`, "valid empty json marshal")
+
+		var back struct{ J JSON }
+		err = json.Unmarshal(data, &back)
+		maybePanic(err)
+		assertNullJSON(t, back.J, "valid empty round trip")
+		if !back.J.Set {
+			t.Error("valid empty round trip should be Set")
+		}
+	}
+
+	data, err := MarshalJSONSlice(empties)
+	maybePanic(err)
+	assertJSONEquals(t, data, "[null,null,null]", "valid empty MarshalJSONSlice()")
+}
+
+func TestMarshalJSONValidate(t *testing.T) {
+	var i JSON
+	i.SetValid([]byte("not json"))
+
+	// Without validation the bytes are emitted as-is, so encoders that
+	// splice them in unchecked produce a corrupt document.
+	data, err := i.MarshalJSON()
+	maybePanic(err)
+	assertJSONEquals(t, data, "not json", "unvalidated json marshal")
+
+	ValidateJSON = true
+	defer func() { ValidateJSON = false }()
+
+	if _, err = i.MarshalJSON(); err == nil {
+		t.Error("expected error marshaling malformed JSON")
+	}
+	if _, err = json.Marshal(struct{ J JSON }{i}); err == nil {
+		t.Error("expected error marshaling struct holding malformed JSON")
+	}
+
+	data, err = json.Marshal(struct{ J JSON }{JSONFrom([]byte(`{"a":1}`))})
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"J":{"a":1}}`, "validated json marshal")
+}
+
+func TestJSONAppendTo(t *testing.T) {
+	rows := []JSON{JSONFrom([]byte(`{"a":1}`)), NewJSON([]byte(`{"b":2}`), false, true), JSONFrom([]byte(`[true]`))}
+	buf := []byte{'['}
+	for i, row := range rows {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = row.AppendTo(buf)
+	}
+	buf = append(buf, ']')
+	assertJSONEquals(t, buf, `[{"a":1},null,[true]]`, "AppendTo()")
+
+	ValidateJSON = true
+	defer func() { ValidateJSON = false }()
+	got := JSONFrom([]byte(`{"a":`)).AppendTo(nil)
+	assertJSONEquals(t, got, `{"a":`, "AppendTo() does not validate")
+	got = JSONFrom([]byte{}).AppendTo([]byte("x:"))
+	assertJSONEquals(t, got, "x:null", "AppendTo() empty")
+}
+
+func TestMarshalJSONSlice(t *testing.T) {
+	js := []JSON{JSONFrom([]byte(`{"a":1}`)), NewJSON(nil, false, true), JSONFrom([]byte{}), NewJSON([]byte(`1`), false, true), JSONFrom([]byte(`"b"`))}
+	data, err := MarshalJSONSlice(js)
+	maybePanic(err)
+	assertJSONEquals(t, data, `[{"a":1},null,null,null,"b"]`, "MarshalJSONSlice()")
+
+	data, err = MarshalJSONSlice([]JSON{JSONFrom([]byte(`{ "a": 1 }`))})
+	maybePanic(err)
+	assertJSONEquals(t, data, `[{ "a": 1 }]`, "MarshalJSONSlice() keeps whitespace")
+
+	data, err = MarshalJSONSlice(nil)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "MarshalJSONSlice(nil)")
+	data, err = MarshalJSONSlice([]JSON{})
+	maybePanic(err)
+	assertJSONEquals(t, data, "[]", "MarshalJSONSlice() empty")
+
+	ValidateJSON = true
+	defer func() { ValidateJSON = false }()
+	if _, err := MarshalJSONSlice([]JSON{JSONFrom([]byte(`{"a":`))}); err == nil {
+		t.Error("MarshalJSONSlice() should fail for malformed JSON when validating")
+	}
+}
+
+func TestJSONAppendJSON(t *testing.T) {
+	j := JSONFrom([]byte(`{"a": 1}`))
+	got, err := j.AppendJSON([]byte("x:"))
+	maybePanic(err)
+	assertJSONEquals(t, got, `x:{"a": 1}`, "AppendJSON()")
+
+	for _, null := range []JSON{{}, NewJSON(nil, false, true), NewJSON([]byte{}, true, true), NewJSON([]byte(`1`), false, true)} {
+		got, err = null.AppendJSON([]byte("x:"))
+		maybePanic(err)
+		assertJSONEquals(t, got, "x:null", "AppendJSON() null")
+	}
+
+	bad := JSONFrom([]byte(`{`))
+	ValidateJSON = true
+	got, err = bad.AppendJSON([]byte("x:"))
+	ValidateJSON = false
+	if err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+	assertJSONEquals(t, got, "x:", "AppendJSON() error")
 }
 
 func TestMarshalJSONText(t *testing.T) {
@@ -166,7 +749,34 @@ func TestMarshalJSONText(t *testing.T) {
 	null := NewJSON(nil, false, true)
 	data, err = null.MarshalText()
 	maybePanic(err)
-	assertJSONEquals(t, data, "", "null text marshal")
+	assertJSONEquals(t, data, "null", "null text marshal")
+
+	// valid empty values keep their (empty) bytes
+	empty := JSONFrom([]byte{})
+	data, err = empty.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, "", "empty text marshal")
+
+	var roundTrip JSON
+	err = roundTrip.UnmarshalText(NullBytes)
+	maybePanic(err)
+	assertNullJSON(t, roundTrip, "UnmarshalText() null")
+	if !roundTrip.Set {
+		t.Error("should be Set")
+	}
+}
+
+func TestJSONString(t *testing.T) {
+	i := JSONFrom([]byte(`{"a":1}`))
+	if s := fmt.Sprintf("%v %s", i, i); s != `{"a":1} {"a":1}` {
+		t.Errorf("bad String(): %s", s)
+	}
+
+	for _, null := range []JSON{{}, NewJSON(nil, false, true), NewJSON([]byte("stale"), false, true), JSONFrom([]byte{})} {
+		if s := null.String(); s != "null" {
+			t.Errorf("bad String() for %#v: %s", null, s)
+		}
+	}
 }
 
 func TestJSONPointer(t *testing.T) {
@@ -183,53 +793,1329 @@ func TestJSONPointer(t *testing.T) {
 	}
 }
 
-func TestJSONIsZero(t *testing.T) {
+func TestJSONClone(t *testing.T) {
 	i := JSONFrom([]byte(`"hello"`))
-	if i.IsZero() {
-		t.Errorf("IsZero() should be false")
+	c := i.Clone()
+	assertJSON(t, c, "Clone()")
+	if !c.Set {
+		t.Error("Clone() should copy Set")
 	}
+	c.JSON[1] = 'j'
+	assertJSON(t, i, "Clone() original after mutation")
 
-	null := NewJSON(nil, false, true)
-	if !null.IsZero() {
-		t.Errorf("IsZero() should be true")
+	empty := JSONFrom([]byte{}).Clone()
+	if !empty.Valid || empty.JSON == nil {
+		t.Error("Clone() of empty JSON should be valid and non-nil")
 	}
 
-	zero := NewJSON(nil, true, true)
-	if zero.IsZero() {
-		t.Errorf("IsZero() should be false")
+	null := NewJSON([]byte("stale"), false, true).Clone()
+	assertNullJSON(t, null, "Clone() null")
+	if null.JSON != nil || !null.Set {
+		t.Error("Clone() of null JSON should have nil bytes and keep Set")
 	}
 }
 
-func TestJSONSetValid(t *testing.T) {
-	change := NewJSON(nil, false, true)
-	assertNullJSON(t, change, "SetValid()")
-	change.SetValid([]byte(`"hello"`))
-	assertJSON(t, change, "SetValid()")
-}
+func TestJSONValueString(t *testing.T) {
+	j := JSONFrom([]byte(`{"a":1}`))
+	if v, err := j.ValueString(); v != `{"a":1}` || err != nil {
+		t.Error("bad ValueString() or err:", v, err)
+	}
+	if v, err := NewJSON([]byte(`{"a":1}`), false, true).ValueString(); v != nil || err != nil {
+		t.Error("bad null ValueString() or err:", v, err)
+	}
 
-func TestJSONScan(t *testing.T) {
-	var i JSON
-	err := i.Scan(`"hello"`)
+	db, err := sql.Open("null-fake", "")
 	maybePanic(err)
-	assertJSON(t, i, "scanned []byte")
+	defer db.Close()
 
-	var null JSON
-	err = null.Scan(nil)
-	maybePanic(err)
-	assertNullJSON(t, null, "scanned null")
+	roundTrip := func(j JSON) (driver.Value, JSON) {
+		_, err := db.Exec("INSERT", j)
+		maybePanic(err)
+		stored := fake.stored
+		var out JSON
+		err = db.QueryRow("SELECT").Scan(&out)
+		maybePanic(err)
+		return stored, out
+	}
+
+	stored, out := roundTrip(j)
+	if _, ok := stored.([]byte); !ok {
+		t.Errorf("Value() should send a []byte by default, got %T", stored)
+	}
+	assertJSONEquals(t, out.JSON, `{"a":1}`, "round trip")
+
+	ValueJSONAsString = true
+	defer func() { ValueJSONAsString = false }()
+	stored, out = roundTrip(j)
+	if stored != `{"a":1}` {
+		t.Errorf("Value() with ValueJSONAsString should send a string, got %T %v", stored, stored)
+	}
+	assertJSONEquals(t, out.JSON, `{"a":1}`, "round trip as string")
+	if !out.Valid || !out.Set {
+		t.Error("round trip as string should be valid")
+	}
+
+	stored, out = roundTrip(NewJSON(nil, false, true))
+	if stored != nil {
+		t.Errorf("Value() of a null JSON should send nil, got %T %v", stored, stored)
+	}
+	assertNullJSON(t, out, "round trip null")
 }
 
-func assertJSON(t *testing.T, i JSON, from string) {
-	if !bytes.Equal(i.JSON, []byte(`"hello"`)) {
-		t.Errorf("bad %s []byte: %#v ≠ %#v\n", from, string(i.JSON), string([]byte(`"hello"`)))
+func TestJSONValueOrZero(t *testing.T) {
+	valid := JSONFrom([]byte(`"hello"`))
+	assertJSONEquals(t, valid.ValueOrZero(), `"hello"`, "ValueOrZero() valid")
+
+	invalid := NewJSON([]byte(`"hello"`), false, true)
+	if invalid.ValueOrZero() != nil {
+		t.Error("unexpected ValueOrZero", invalid.ValueOrZero())
 	}
-	if !i.Valid {
-		t.Error(from, "is invalid, but should be valid")
+}
+
+func TestJSONValueOrDefault(t *testing.T) {
+	def := []byte(`{}`)
+
+	i := JSONFrom([]byte(`"hello"`))
+	v := i.ValueOrDefault(def)
+	assertJSONEquals(t, v, `"hello"`, "ValueOrDefault() valid")
+	v[1] = 'j'
+	assertJSON(t, i, "ValueOrDefault() original after mutation")
+
+	null := NewJSON([]byte("stale"), false, true)
+	assertJSONEquals(t, null.ValueOrDefault(def), `{}`, "ValueOrDefault() null")
+	if null.ValueOrDefault(nil) != nil {
+		t.Error("ValueOrDefault(nil) should return nil for a null JSON")
 	}
 }
 
-func assertNullJSON(t *testing.T, i JSON, from string) {
-	if i.Valid {
-		t.Error(from, "is valid, but should be invalid")
+func TestJSONLenSize(t *testing.T) {
+	tests := []struct {
+		json      JSON
+		len, size int
+	}{
+		{JSONFrom([]byte(`{"a":1}`)), 7, 7},
+		{JSONFrom([]byte("{\n  \"a b\": [1, 2],\n\t\"c\": \"\\\" x \"\n}\n")), 35, 25},
+		{JSONFrom([]byte{}), 0, 0},
+		{NewJSON([]byte(`{"a":1}`), false, true), 0, 0},
+		{JSON{}, 0, 0},
+	}
+	for _, test := range tests {
+		if got := test.json.Len(); got != test.len {
+			t.Errorf("Len() of %q = %d, want %d", test.json.JSON, got, test.len)
+		}
+		if got := test.json.Size(); got != test.size {
+			t.Errorf("Size() of %q = %d, want %d", test.json.JSON, got, test.size)
+		}
+		if test.json.Valid {
+			var buf bytes.Buffer
+			if len(test.json.JSON) > 0 {
+				maybePanic(json.Compact(&buf, test.json.JSON))
+			}
+			if buf.Len() != test.json.Size() {
+				t.Errorf("Size() of %q = %d, but json.Compact gives %d", test.json.JSON, test.json.Size(), buf.Len())
+			}
+		}
+	}
+
+	j := JSONFrom([]byte(`{ "a" : [ 1 , 2 ] }`))
+	if n := testing.AllocsPerRun(10, func() { j.Size() }); n != 0 {
+		t.Errorf("Size() should not allocate, got %v allocs", n)
+	}
+}
+
+func TestJSONIsNull(t *testing.T) {
+	var unmarshaled JSON
+	err := json.Unmarshal(NullBytes, &unmarshaled)
+	maybePanic(err)
+
+	var scanned JSON
+	err = scanned.Scan([]byte(" null\n"))
+	maybePanic(err)
+
+	for _, null := range []JSON{{}, NewJSON(nil, false, true), unmarshaled, scanned, JSONFrom(NullBytes)} {
+		if !null.IsNull() {
+			t.Errorf("IsNull() should be true for %#v", null)
+		}
+	}
+
+	for _, i := range []JSON{JSONFrom([]byte(`"null"`)), JSONFrom([]byte(`{}`)), JSONFrom([]byte{})} {
+		if i.IsNull() {
+			t.Errorf("IsNull() should be false for %#v", i)
+		}
 	}
 }
+
+func TestJSONMapSlice(t *testing.T) {
+	m, err := JSONFrom([]byte(`{"a":1,"b":[true]}`)).Map()
+	maybePanic(err)
+	if m["a"] != float64(1) || len(m["b"].([]interface{})) != 1 {
+		t.Errorf("bad Map() result: %v", m)
+	}
+	s, err := JSONFrom([]byte(` [1, "two", {}]`)).Slice()
+	maybePanic(err)
+	if len(s) != 3 || s[1] != "two" {
+		t.Errorf("bad Slice() result: %v", s)
+	}
+
+	for _, null := range []JSON{{}, NewJSON([]byte(`{}`), false, true), JSONFrom([]byte{}), JSONFrom(NullBytes)} {
+		if m, err := null.Map(); m != nil || err != nil {
+			t.Errorf("Map() of %#v should be nil, got %v, %v", null, m, err)
+		}
+		if s, err := null.Slice(); s != nil || err != nil {
+			t.Errorf("Slice() of %#v should be nil, got %v, %v", null, s, err)
+		}
+	}
+
+	tests := []struct {
+		json           string
+		mapErr, sliErr string
+	}{
+		{`"hello"`, "null: JSON value is string, not object", "null: JSON value is string, not array"},
+		{`12`, "null: JSON value is number, not object", "null: JSON value is number, not array"},
+		{`true`, "null: JSON value is bool, not object", "null: JSON value is bool, not array"},
+		{`[1]`, "null: JSON value is array, not object", ""},
+		{`{"a":1}`, "", "null: JSON value is object, not array"},
+	}
+	for _, test := range tests {
+		j := JSONFrom([]byte(test.json))
+		if _, err := j.Map(); errString(err) != test.mapErr {
+			t.Errorf("Map() of %s: got error %v, want %q", test.json, err, test.mapErr)
+		}
+		if _, err := j.Slice(); errString(err) != test.sliErr {
+			t.Errorf("Slice() of %s: got error %v, want %q", test.json, err, test.sliErr)
+		}
+	}
+
+	if _, err := JSONFrom([]byte(`{"a":`)).Map(); err == nil {
+		t.Error("Map() of malformed JSON should fail")
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func TestJSONKind(t *testing.T) {
+	tests := []struct {
+		json JSON
+		kind JSONKind
+	}{
+		{JSONFrom([]byte(`{"a":1}`)), JSONKindObject},
+		{JSONFrom([]byte(" \n\t[1, 2]")), JSONKindArray},
+		{JSONFrom([]byte(`"hello"`)), JSONKindString},
+		{JSONFrom([]byte("\r\n-1.5e3")), JSONKindNumber},
+		{JSONFrom([]byte("0")), JSONKindNumber},
+		{JSONFrom([]byte("true")), JSONKindBool},
+		{JSONFrom([]byte("  false ")), JSONKindBool},
+		{JSONFrom([]byte(" null")), JSONKindNull},
+		{NewJSON([]byte(`{"a":1}`), false, true), JSONKindNone},
+		{JSON{}, JSONKindNone},
+	}
+	for _, test := range tests {
+		kind, err := test.json.Kind()
+		maybePanic(err)
+		if kind != test.kind {
+			t.Errorf("Kind() of %q = %v, want %v", test.json.JSON, kind, test.kind)
+		}
+	}
+
+	for _, bad := range []string{"", "  ", "nul", "tru", "fals", "+1", "'a'", "}"} {
+		if kind, err := JSONFrom([]byte(bad)).Kind(); err == nil {
+			t.Errorf("Kind() of %q should be an error, got %v", bad, kind)
+		}
+	}
+
+	if s := JSONKindObject.String(); s != "object" {
+		t.Errorf("bad JSONKind string: %q", s)
+	}
+	if s := JSONKind(42).String(); s != "JSONKind(42)" {
+		t.Errorf("bad JSONKind string: %q", s)
+	}
+}
+
+func TestJSONIsZero(t *testing.T) {
+	i := JSONFrom([]byte(`"hello"`))
+	if i.IsZero() {
+		t.Errorf("IsZero() should be false")
+	}
+
+	null := NewJSON(nil, false, true)
+	if !null.IsZero() {
+		t.Errorf("IsZero() should be true")
+	}
+
+	zero := NewJSON(nil, true, true)
+	if zero.IsZero() {
+		t.Errorf("IsZero() should be false")
+	}
+}
+
+func TestJSONSetValid(t *testing.T) {
+	change := NewJSON(nil, false, true)
+	assertNullJSON(t, change, "SetValid()")
+	change.SetValid([]byte(`"hello"`))
+	assertJSON(t, change, "SetValid()")
+}
+
+func TestJSONSetNull(t *testing.T) {
+	change := NewJSON(nil, false, true)
+	change.SetValid([]byte(`"hello"`))
+	change.SetNull()
+	assertNullJSON(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.JSON != nil {
+		t.Errorf("SetNull() should zero the value, got %v", change.JSON)
+	}
+}
+
+func TestJSONScan(t *testing.T) {
+	var i JSON
+	err := i.Scan(`"hello"`)
+	maybePanic(err)
+	assertJSON(t, i, "scanned []byte")
+
+	var null JSON
+	err = null.Scan(nil)
+	maybePanic(err)
+	assertNullJSON(t, null, "scanned null")
+
+	src := []byte(`"hello"`)
+	var b JSON
+	err = b.Scan(src)
+	maybePanic(err)
+	src[1] = 'j'
+	assertJSON(t, b, "scanned []byte after source mutation")
+
+	var empty JSON
+	err = empty.Scan([]byte{})
+	maybePanic(err)
+	if !empty.Valid || empty.JSON == nil {
+		t.Error("scanned empty []byte should be valid and non-nil")
+	}
+}
+
+func TestJSONScanRawBytes(t *testing.T) {
+	// database/sql reuses the memory behind sql.RawBytes between rows.
+	buf := make(sql.RawBytes, 0, 16)
+	row := func(s string) sql.RawBytes {
+		buf = append(buf[:0], s...)
+		return buf
+	}
+
+	var first, second JSON
+	err := first.Scan(row(`"hello"`))
+	maybePanic(err)
+	err = second.Scan(row(`"world"`))
+	maybePanic(err)
+	assertJSON(t, first, "first row after buffer reuse")
+	assertJSONEquals(t, second.JSON, `"world"`, "second row")
+
+	var into JSON
+	err = into.ScanInto(row(`"hello"`), nil)
+	maybePanic(err)
+	row(`"world"`)
+	assertJSON(t, into, "ScanInto() after buffer reuse")
+
+	var null JSON
+	err = null.Scan(sql.RawBytes(nil))
+	maybePanic(err)
+	if !null.Valid || null.JSON != nil {
+		t.Errorf("nil sql.RawBytes should scan like a nil []byte: %#v", null)
+	}
+}
+
+func TestJSONScanNullLiteral(t *testing.T) {
+	var unmarshaled JSON
+	err := json.Unmarshal(NullBytes, &unmarshaled)
+	maybePanic(err)
+	assertNullJSON(t, unmarshaled, "unmarshaled null literal")
+	if !unmarshaled.Set {
+		t.Error("unmarshaled null literal should be Set")
+	}
+
+	var literal JSON
+	err = literal.Scan([]byte("null"))
+	maybePanic(err)
+	if !literal.Valid || string(literal.JSON) != "null" {
+		t.Errorf("scanned null literal should stay valid by default: %#v", literal)
+	}
+	if v, _ := literal.Value(); string(v.([]byte)) != "null" {
+		t.Errorf("scanned null literal should be written back unchanged, got %#v", v)
+	}
+
+	ScanJSONNullAsNull = true
+	defer func() { ScanJSONNullAsNull = false }()
+
+	for _, v := range []interface{}{[]byte("null"), " null\n", "null"} {
+		var null JSON
+		err := null.Scan(v)
+		maybePanic(err)
+		assertNullJSON(t, null, fmt.Sprintf("scanned %T %q", v, v))
+		if !null.Set {
+			t.Errorf("scanned %T %q should be Set", v, v)
+		}
+		if v, _ := null.Value(); v != nil {
+			t.Errorf("scanned null literal should be written back as SQL NULL, got %#v", v)
+		}
+
+		var into JSON
+		err = into.ScanInto(v, make([]byte, 0, 8))
+		maybePanic(err)
+		assertNullJSON(t, into, fmt.Sprintf("ScanInto() %T %q", v, v))
+	}
+
+	var str JSON
+	err = str.Scan(`"null"`)
+	maybePanic(err)
+	assertJSONEquals(t, str.JSON, `"null"`, "scanned null string")
+
+	var sqlNull JSON
+	err = sqlNull.Scan(nil)
+	maybePanic(err)
+	assertNullJSON(t, sqlNull, "scanned SQL NULL")
+	if sqlNull.Set {
+		t.Error("scanned SQL NULL should not be Set")
+	}
+}
+
+func TestJSONScanContext(t *testing.T) {
+	var j JSON
+	err := j.ScanContext(context.Background(), []byte(`{"a":1}`))
+	maybePanic(err)
+	assertJSONEquals(t, j.JSON, `{"a":1}`, "ScanContext()")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := j.ScanContext(ctx, []byte(`{"b":2}`)); err != context.Canceled {
+		t.Errorf("ScanContext() with a canceled context should return context.Canceled, got %v", err)
+	}
+	assertJSONEquals(t, j.JSON, `{"a":1}`, "ScanContext() canceled")
+	if !j.Valid {
+		t.Error("ScanContext() canceled should leave the JSON unchanged")
+	}
+}
+
+func TestScanJSON(t *testing.T) {
+	type row struct {
+		A int
+		B []string
+	}
+
+	var r row
+	err := ScanJSON([]byte(`{"A":1,"B":["x"]}`), &r)
+	maybePanic(err)
+	if r.A != 1 || len(r.B) != 1 || r.B[0] != "x" {
+		t.Errorf("bad ScanJSON() result: %#v", r)
+	}
+
+	err = ScanJSON(`{"A":2}`, &r)
+	maybePanic(err)
+	if r.A != 2 {
+		t.Errorf("bad ScanJSON() string result: %#v", r)
+	}
+
+	for _, in := range []interface{}{nil, []byte{}} {
+		r := row{A: 3}
+		err := ScanJSON(in, &r)
+		maybePanic(err)
+		if r.A != 3 {
+			t.Errorf("ScanJSON(%v) should leave dest untouched, got %#v", in, r)
+		}
+	}
+
+	if err := ScanJSON([]byte(`{"A":`), &r); err == nil {
+		t.Error("expected error on malformed JSON")
+	}
+	if err := ScanJSON(complex(1, 2), &r); err == nil {
+		t.Error("expected error on an unsupported type")
+	}
+}
+
+func TestJSONScanEmptyBytes(t *testing.T) {
+	for _, in := range []interface{}{[]byte{}, sql.RawBytes{}} {
+		var j JSON
+		err := j.Scan(in)
+		maybePanic(err)
+		if !j.Valid || j.JSON == nil || len(j.JSON) != 0 {
+			t.Errorf("Scan(%#v) should be a valid, empty JSON by default, got %#v", in, j)
+		}
+	}
+
+	TreatEmptyBytesAsNull = true
+	defer func() { TreatEmptyBytesAsNull = false }()
+	for _, in := range []interface{}{[]byte{}, sql.RawBytes{}} {
+		var j, into JSON
+		err := j.Scan(in)
+		maybePanic(err)
+		assertNullJSON(t, j, "scanned empty bytes")
+		err = into.ScanInto(in, make([]byte, 8))
+		maybePanic(err)
+		assertNullJSON(t, into, "ScanInto() empty bytes")
+		if j.Set || into.Set {
+			t.Error("empty bytes should scan like SQL NULL, which is not Set")
+		}
+	}
+
+	var s JSON
+	err := s.Scan("")
+	maybePanic(err)
+	if !s.Valid {
+		t.Error("an empty string should still scan as a valid JSON")
+	}
+	var b JSON
+	err = b.Scan([]byte(`{}`))
+	maybePanic(err)
+	assertJSONEquals(t, b.JSON, `{}`, "scanned non-empty bytes")
+}
+
+func TestJSONScanInto(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	var j JSON
+	err := j.ScanInto([]byte(`"hello"`), buf)
+	maybePanic(err)
+	assertJSON(t, j, "ScanInto() []byte")
+	if &j.JSON[0] != &buf[:1][0] {
+		t.Error("ScanInto() should reuse a large enough buffer")
+	}
+
+	err = j.ScanInto(`"hello"`, j.JSON)
+	maybePanic(err)
+	assertJSON(t, j, "ScanInto() string with previous value")
+	if &j.JSON[0] != &buf[:1][0] {
+		t.Error("ScanInto() should reuse the previous value's buffer")
+	}
+
+	small := make([]byte, 0, 2)
+	err = j.ScanInto([]byte(`"hello"`), small)
+	maybePanic(err)
+	assertJSON(t, j, "ScanInto() small buffer")
+	if cap(small) != 2 || string(small[:2]) == `"h` {
+		t.Error("ScanInto() should not write past a small buffer")
+	}
+
+	var empty JSON
+	err = empty.ScanInto([]byte{}, nil)
+	maybePanic(err)
+	if !empty.Valid || empty.JSON == nil || len(empty.JSON) != 0 {
+		t.Errorf("empty value should scan as valid, empty JSON: %#v", empty)
+	}
+
+	var null JSON
+	err = null.ScanInto(nil, buf)
+	maybePanic(err)
+	assertNullJSON(t, null, "ScanInto() nil")
+
+	var other JSON
+	err = other.ScanInto(int64(1), buf)
+	maybePanic(err)
+	assertJSONEquals(t, other.JSON, "1", "ScanInto() int64")
+}
+
+func TestJSONEqual(t *testing.T) {
+	a := JSONFrom([]byte(`{"a": 1, "b": [1, 2, {"c": null}]}`))
+	b := JSONFrom([]byte(`{"b":[1,2,{"c":null}],"a":1}`))
+	if !a.Equal(b) {
+		t.Error("documents differing only in key order and whitespace should be equal")
+	}
+	if !a.Equal(a) {
+		t.Error("identical documents should be equal")
+	}
+
+	c := JSONFrom([]byte(`{"a": 1, "b": [2, 1, {"c": null}]}`))
+	if a.Equal(c) {
+		t.Error("array order should be significant")
+	}
+
+	null := NewJSON(nil, false, true)
+	if !null.Equal(NewJSON([]byte("garbage"), false, false)) {
+		t.Error("two null JSON's should be equal")
+	}
+	if a.Equal(null) || null.Equal(a) {
+		t.Error("valid and null JSON's should not be equal")
+	}
+
+	bad := JSONFrom([]byte(`{"a":`))
+	if bad.Equal(a) {
+		t.Error("malformed JSON should not equal a document")
+	}
+	if !bad.Equal(bad) {
+		t.Error("byte-identical JSON should be equal")
+	}
+}
+
+func TestJSONCanonical(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{`{ "b": [1, 2, {"d": null, "c": true}], "a": "x" }`, `{"a":"x","b":[1,2,{"c":true,"d":null}]}`},
+		{`{"a": 1, "a": 2}`, `{"a":2}`},
+		{`{"é": 1, "Z": 2, "a": 3, "": 4}`, `{"":4,"Z":2,"a":3,"é":1}`},
+		{`"\u0041<&>\"\n\u00e9"`, `"A<&>\"\né"`},
+		{`[1.0, 1e0, 10e-1, 100E-2, 0.1e1]`, `[1,1,1,1,1]`},
+		{`[-0, 0.0, -0e10]`, `[0,0,0]`},
+		{`[12.50, -3.14159, 1e2, 1.5E+3, 0.000001, 1e-7, 12e-8]`, `[12.5,-3.14159,100,1500,0.000001,1e-7,1.2e-7]`},
+		{`[123456789012345678901, 1e21, 1.5e21, -2.5e-10]`, `[123456789012345678901,1e+21,1.5e+21,-2.5e-10]`},
+		{`[12345678901234567890123456789]`, `[1.2345678901234567890123456789e+28]`},
+		{`9007199254740993`, `9007199254740993`},
+		{` true `, `true`},
+	}
+	for _, test := range tests {
+		c, err := JSONFrom([]byte(test.in)).Canonical()
+		maybePanic(err)
+		assertJSONEquals(t, c.JSON, test.want, "Canonical() of "+test.in)
+		again, err := c.Canonical()
+		maybePanic(err)
+		assertJSONEquals(t, again.JSON, test.want, "Canonical() of its own output")
+	}
+
+	for _, j := range []JSON{{}, NewJSON([]byte("garbage"), false, true), JSONFrom([]byte{})} {
+		c, err := j.Canonical()
+		if err != nil || !bytes.Equal(c.JSON, j.JSON) || c.Valid != j.Valid {
+			t.Errorf("Canonical() of %#v should return it unchanged, got %#v, %v", j, c, err)
+		}
+	}
+
+	for _, bad := range []string{`{"a":`, `[1] [2]`, `1e99999999999`} {
+		if _, err := JSONFrom([]byte(bad)).Canonical(); err == nil {
+			t.Errorf("Canonical() of %s should fail", bad)
+		}
+	}
+
+	orig := []byte(`{"b":1, "a":2}`)
+	j := JSONFrom(orig)
+	c, err := j.Canonical()
+	maybePanic(err)
+	if string(j.JSON) != `{"b":1, "a":2}` || !c.Set {
+		t.Errorf("Canonical() should leave the receiver untouched and keep Set: %#v", c)
+	}
+}
+
+func TestJSONEqualNumbers(t *testing.T) {
+	if !JSONFrom([]byte(`[1.0, 2e1]`)).EqualBytes([]byte(`[1, 20]`)) {
+		t.Error("numbers with the same value should be equal")
+	}
+	if JSONFrom([]byte(`9007199254740993`)).EqualBytes([]byte(`9007199254740992`)) {
+		t.Error("integers beyond float64 precision should be compared exactly")
+	}
+}
+
+func TestJSONHash(t *testing.T) {
+	a := JSONFrom([]byte(`{"a": 1, "b": [1.0, "x"]}`))
+	b := JSONFrom([]byte(`{"b":[1,"x"],"a":1}`))
+	c := JSONFrom([]byte(`{"a":1,"b":["x",1]}`))
+	if a.Hash() != b.Hash() || a.HashBytes() != b.HashBytes() {
+		t.Error("equal documents should hash the same")
+	}
+	if a.Hash() == c.Hash() || a.HashBytes() == c.HashBytes() {
+		t.Error("different documents should hash differently")
+	}
+
+	// Pinned so that changes to the canonical form or hash are noticed.
+	if h := a.Hash(); h != 0xbcdbc9c4aa8492dc {
+		t.Errorf("bad Hash() of %s: %#x", a.JSON, h)
+	}
+	if h := fmt.Sprintf("%x", a.HashBytes()); h != "677ba3966fe15b482a9751f84bc33a0571252aae740dd82f55c8b9dcc2554334" {
+		t.Errorf("bad HashBytes() of %s: %s", a.JSON, h)
+	}
+
+	null := NewJSON([]byte(`{}`), false, true)
+	if null.Hash() != 0 || null.HashBytes() != [32]byte{} {
+		t.Error("a null JSON should hash to zero")
+	}
+
+	bad := JSONFrom([]byte(`{"a":`))
+	if bad.Hash() == 0 || bad.Hash() != JSONFrom([]byte(`{"a":`)).Hash() {
+		t.Error("malformed JSON should hash its bytes")
+	}
+}
+
+func TestJSONEqualBytes(t *testing.T) {
+	i := JSONFrom([]byte(`[1, "two"]`))
+	if !i.EqualBytes([]byte(`[1,"two"]`)) {
+		t.Error("expected EqualBytes to match")
+	}
+	if i.EqualBytes([]byte(`[1,"three"]`)) {
+		t.Error("expected EqualBytes not to match")
+	}
+	if i.EqualBytes(nil) {
+		t.Error("nil bytes should not equal a valid JSON")
+	}
+
+	null := NewJSON(nil, false, true)
+	if !null.EqualBytes(nil) {
+		t.Error("nil bytes should equal a null JSON")
+	}
+}
+
+func TestJSONCompact(t *testing.T) {
+	i := JSONFrom([]byte("{\n  \"a\": [1, 2],\n  \"b\": \"x y\"\n}"))
+	err := i.Compact()
+	maybePanic(err)
+	assertJSONEquals(t, i.JSON, `{"a":[1,2],"b":"x y"}`, "Compact()")
+	if !i.Valid || !i.Set {
+		t.Error("Compact() should preserve Valid and Set")
+	}
+
+	orig := []byte("garbage")
+	null := NewJSON(orig, false, true)
+	err = null.Compact()
+	maybePanic(err)
+	if &null.JSON[0] != &orig[0] || !null.Set {
+		t.Error("Compact() should not touch a null JSON")
+	}
+
+	bad := JSONFrom([]byte(`{"a": `))
+	if err = bad.Compact(); err == nil {
+		t.Error("expected error compacting malformed JSON")
+	}
+	assertJSONEquals(t, bad.JSON, `{"a": `, "Compact() malformed")
+}
+
+func TestJSONCompacted(t *testing.T) {
+	i := JSONFrom([]byte(`[ 1, 2 ]`))
+	c, err := i.Compacted()
+	maybePanic(err)
+	assertJSONEquals(t, c.JSON, `[1,2]`, "Compacted()")
+	assertJSONEquals(t, i.JSON, `[ 1, 2 ]`, "Compacted() receiver")
+
+	if _, err = JSONFrom([]byte(`[1,`)).Compacted(); err == nil {
+		t.Error("expected error compacting malformed JSON")
+	}
+}
+
+func TestJSONIndent(t *testing.T) {
+	i := JSONFrom([]byte(`{"a":[1,{"b":null}],"c":{}}`))
+	ind, err := i.Indent("", "  ")
+	maybePanic(err)
+	expected := "{\n  \"a\": [\n    1,\n    {\n      \"b\": null\n    }\n  ],\n  \"c\": {}\n}"
+	assertJSONEquals(t, ind.JSON, expected, "Indent()")
+	if !ind.Valid || !ind.Set {
+		t.Error("Indent() should preserve Valid and Set")
+	}
+
+	ind, err = JSONFrom([]byte(`[[1],[]]`)).Indent("> ", "\t")
+	maybePanic(err)
+	assertJSONEquals(t, ind.JSON, "[\n> \t[\n> \t\t1\n> \t],\n> \t[]\n> ]", "Indent() prefixed")
+
+	null := NewJSON(nil, false, true)
+	ind, err = null.Indent("", "  ")
+	maybePanic(err)
+	assertNullJSON(t, ind, "Indent() null")
+	if !ind.Set {
+		t.Error("Indent() should preserve Set")
+	}
+
+	empty := JSONFrom([]byte{})
+	ind, err = empty.Indent("", "  ")
+	maybePanic(err)
+	if !ind.Valid || len(ind.JSON) != 0 {
+		t.Error("Indent() should leave empty JSON untouched")
+	}
+
+	if _, err = JSONFrom([]byte(`{"a"}`)).Indent("", "  "); err == nil {
+		t.Error("expected error indenting malformed JSON")
+	}
+}
+
+func TestJSONValidate(t *testing.T) {
+	ValidateJSON = true
+	defer func() { ValidateJSON = false }()
+
+	for _, in := range []string{`{}`, `"bare"`, `[1, 2]`, `null`} {
+		var i JSON
+		if err := i.UnmarshalJSON([]byte(in)); err != nil {
+			t.Errorf("UnmarshalJSON(%s) should succeed: %v", in, err)
+		}
+	}
+
+	for _, in := range []string{`{} trailing`, `{"a":1}{"b":2}`, `not json`, ``} {
+		var i JSON
+		if err := i.UnmarshalJSON([]byte(in)); err == nil {
+			t.Errorf("UnmarshalJSON(%s) should fail", in)
+		}
+		assertNullJSON(t, i, "UnmarshalJSON() invalid")
+	}
+
+	ValidateJSON = false
+	var lax JSON
+	err := lax.UnmarshalJSON([]byte(`{} trailing`))
+	maybePanic(err)
+	if !lax.Valid {
+		t.Error("UnmarshalJSON() should be permissive by default")
+	}
+}
+
+func TestJSONSetValidStrict(t *testing.T) {
+	var i JSON
+	err := i.SetValidStrict([]byte(`{}`))
+	maybePanic(err)
+	assertJSONEquals(t, i.JSON, `{}`, "SetValidStrict() object")
+
+	err = i.SetValidStrict([]byte(`"hello"`))
+	maybePanic(err)
+	assertJSON(t, i, "SetValidStrict() string")
+
+	if err = i.SetValidStrict([]byte(`"hello" garbage`)); err == nil {
+		t.Error("expected error on trailing garbage")
+	}
+	assertJSON(t, i, "SetValidStrict() unchanged")
+}
+
+func TestJSONApplyMergePatch(t *testing.T) {
+	tests := []struct {
+		target string
+		patch  string
+		want   string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":{"b":"c","d":{"e":1}}}`, `{"a":{"d":{"e":null},"f":2}}`, `{"a":{"b":"c","d":{},"f":2}}`},
+		{`{"a":{"b":"c"}}`, `{"a":12345678901234567890}`, `{"a":12345678901234567890}`},
+		{`{"a":{"b":"c"}}`, `"scalar"`, `"scalar"`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`["a","b"]`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+	}
+
+	for _, test := range tests {
+		i := JSONFrom([]byte(test.target))
+		err := i.ApplyMergePatch([]byte(test.patch))
+		maybePanic(err)
+		assertJSONEquals(t, i.JSON, test.want, "ApplyMergePatch("+test.target+", "+test.patch+")")
+		if !i.Valid || !i.Set {
+			t.Error("ApplyMergePatch() should produce a valid JSON")
+		}
+	}
+
+	var null JSON
+	err := null.ApplyMergePatch([]byte(`{"a":{"b":null,"c":1}}`))
+	maybePanic(err)
+	assertJSONEquals(t, null.JSON, `{"a":{"c":1}}`, "ApplyMergePatch() null target")
+
+	replace := JSONFrom([]byte(`{"a":1}`))
+	err = replace.ApplyMergePatch(NullBytes)
+	maybePanic(err)
+	assertNullJSON(t, replace, "ApplyMergePatch() null patch")
+
+	bad := JSONFrom([]byte(`{"a":1}`))
+	if err = bad.ApplyMergePatch([]byte(`{"a":`)); err == nil {
+		t.Error("expected error on malformed patch")
+	}
+	assertJSONEquals(t, bad.JSON, `{"a":1}`, "ApplyMergePatch() malformed patch")
+
+	bad = JSONFrom([]byte(`{"a":`))
+	if err = bad.ApplyMergePatch([]byte(`{"a":1}`)); err == nil {
+		t.Error("expected error on malformed target")
+	}
+}
+
+func TestJSONDiff(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+		want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"a":"b","b":"c"}`, `{"b":"c"}`},
+		{`{"a":"b","b":"c"}`, `{"b":"c"}`, `{"a":null}`},
+		{`{"a":{"b":"c","d":{"e":1}}}`, `{"a":{"b":"c","d":{},"f":2}}`, `{"a":{"d":{"e":null},"f":2}}`},
+		{`{"a":{"b":"c"}}`, `{"a":[1,null]}`, `{"a":[1,null]}`},
+		{`{"a":[1,2]}`, `{"a":[1,2]}`, `{}`},
+		{`{"a":{"b":"c"}}`, `"scalar"`, `"scalar"`},
+		{`["a","b"]`, `{"a":{"b":1}}`, `{"a":{"b":1}}`},
+		{`{"a":12345678901234567890}`, `{"a":12345678901234567891}`, `{"a":12345678901234567891}`},
+		{`null`, `{"a":{"b":1}}`, `{"a":{"b":1}}`},
+	}
+
+	for _, test := range tests {
+		from := JSONFrom([]byte(test.from))
+		patch, err := from.Diff(JSONFrom([]byte(test.to)))
+		maybePanic(err)
+		assertJSONEquals(t, patch.JSON, test.want, "Diff("+test.from+", "+test.to+")")
+
+		err = from.ApplyMergePatch(patch.JSON)
+		maybePanic(err)
+		if !from.Equal(JSONFrom([]byte(test.to))) {
+			t.Errorf("applying Diff(%s, %s) gave %s", test.from, test.to, from.JSON)
+		}
+	}
+
+	patch, err := NewJSON(nil, false, true).Diff(JSONFrom([]byte(`{"a":1}`)))
+	maybePanic(err)
+	assertJSONEquals(t, patch.JSON, `{"a":1}`, "Diff() from null")
+	patch, err = JSONFrom([]byte(`{"a":1}`)).Diff(JSON{})
+	maybePanic(err)
+	assertJSONEquals(t, patch.JSON, `{"a":null}`, "Diff() to null")
+
+	for _, from := range []string{`{"a":1}`, `null`} {
+		base := JSONFrom([]byte(from))
+		patch, err = base.Diff(JSONFrom([]byte(`null`)))
+		maybePanic(err)
+		assertJSONEquals(t, patch.JSON, `null`, "Diff() to a JSON null")
+		err = base.ApplyMergePatch(patch.JSON)
+		maybePanic(err)
+		if !base.IsNull() {
+			t.Errorf("applying Diff(%s, null) gave %s", from, base.JSON)
+		}
+	}
+
+	_, err = JSONFrom([]byte(`{}`)).Diff(JSONFrom([]byte(`{"a":{"b":null}}`)))
+	if want := "null: cannot diff JSON: a merge patch cannot set $.a.b to null"; errString(err) != want {
+		t.Errorf("got error %v, want %q", err, want)
+	}
+	if _, err = JSONFrom([]byte(`{"a":`)).Diff(JSONFrom([]byte(`{}`))); err == nil {
+		t.Error("expected error on malformed JSON")
+	}
+}
+
+func TestJSONMapValue(t *testing.T) {
+	redact := func(v interface{}) (interface{}, error) {
+		if m, ok := v.(map[string]interface{}); ok {
+			if _, ok := m["password"]; ok {
+				m["password"] = "***"
+			}
+		}
+		return v, nil
+	}
+
+	j := JSONFrom([]byte(`{"user":"ann","password":"hunter2","id":12345678901234567890}`))
+	got, err := j.MapValue(redact)
+	maybePanic(err)
+	assertJSONEquals(t, got.JSON, `{"id":12345678901234567890,"password":"***","user":"ann"}`, "MapValue()")
+	if !got.Valid || !got.Set {
+		t.Error("MapValue() should produce a valid JSON")
+	}
+	assertJSONEquals(t, j.JSON, `{"user":"ann","password":"hunter2","id":12345678901234567890}`, "MapValue() receiver")
+
+	got, err = j.MapValue(func(interface{}) (interface{}, error) { return nil, nil })
+	maybePanic(err)
+	assertNullJSON(t, got, "MapValue() nil result")
+	assertJSONEquals(t, got.JSON, "null", "MapValue() nil result")
+
+	for _, null := range []JSON{{}, NewJSON(nil, false, true), JSONFrom([]byte{})} {
+		got, err := null.MapValue(func(interface{}) (interface{}, error) {
+			t.Error("MapValue() should not call fn for a null or empty JSON")
+			return nil, nil
+		})
+		maybePanic(err)
+		if !got.Equal(null) || got.Set != null.Set {
+			t.Errorf("MapValue() should return a null JSON unchanged, got %#v", got)
+		}
+	}
+
+	errRedact := errors.New("cannot redact")
+	if _, err := j.MapValue(func(interface{}) (interface{}, error) { return nil, errRedact }); err != errRedact {
+		t.Errorf("MapValue() should return fn's error, got %v", err)
+	}
+	if _, err := JSONFrom([]byte(`{"a":`)).MapValue(redact); err == nil {
+		t.Error("expected error on malformed JSON")
+	}
+	if _, err := j.MapValue(func(interface{}) (interface{}, error) { return make(chan int), nil }); err == nil {
+		t.Error("expected error marshaling an unsupported result")
+	}
+}
+
+func TestJSONHas(t *testing.T) {
+	cfg := JSONFrom([]byte(`{"name": "a", "server": {"port": 80, "tls": true}, "name": "b", "list": [{"tls": 1}]}`))
+
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"name", true},
+		{"server", true},
+		{"list", true},
+		{"port", false},
+		{"tls", false},
+		{"", false},
+	}
+	for _, test := range tests {
+		has, err := cfg.Has(test.key)
+		maybePanic(err)
+		if has != test.want {
+			t.Errorf("Has(%q) = %v, want %v", test.key, has, test.want)
+		}
+	}
+
+	for _, j := range []JSON{{}, NewJSON(nil, true, true), JSONFrom([]byte(`null`)), JSONFrom([]byte(`{}`))} {
+		has, err := j.Has("name")
+		maybePanic(err)
+		if has {
+			t.Errorf("Has() of %q should be false", j.JSON)
+		}
+	}
+
+	for _, j := range []JSON{JSONFrom([]byte(`["name"]`)), JSONFrom([]byte(`"name"`)), JSONFrom([]byte(`nope`))} {
+		if _, err := j.Has("name"); err == nil {
+			t.Errorf("Has() of %q should fail", j.JSON)
+		}
+	}
+	if _, err := JSONFrom([]byte(`{"a": `)).Has("name"); err == nil {
+		t.Error("Has() of a truncated object should fail")
+	}
+}
+
+func TestJSONGetPath(t *testing.T) {
+	cfg := JSONFrom([]byte(`{"server": {"tls": {"enabled": true}, "ports": [80, 443], "0": "zero", "none": null}}`))
+
+	v, err := cfg.GetPath("server", "tls", "enabled")
+	maybePanic(err)
+	assertJSONEquals(t, v.JSON, `true`, "GetPath() leaf")
+	if !v.Valid || !v.Set {
+		t.Error("GetPath() leaf should be valid and set")
+	}
+
+	v, err = cfg.GetPath("server", "tls")
+	maybePanic(err)
+	assertJSONEquals(t, v.JSON, `{"enabled": true}`, "GetPath() object")
+
+	v, err = cfg.GetPath("server", "0")
+	maybePanic(err)
+	assertJSONEquals(t, v.JSON, `"zero"`, "GetPath() numeric-like key")
+
+	v, err = cfg.GetPath()
+	maybePanic(err)
+	if !v.Equal(cfg) {
+		t.Error("GetPath() with no path should return the whole document")
+	}
+
+	for _, path := range [][]string{{"client"}, {"server", "tls", "missing"}, {"server", "none", "deeper"}} {
+		v, err = cfg.GetPath(path...)
+		maybePanic(err)
+		assertNullJSON(t, v, "GetPath() missing")
+		if v.Set {
+			t.Error("GetPath() missing should not be set")
+		}
+	}
+
+	v, err = cfg.GetPath("server", "none")
+	maybePanic(err)
+	assertNullJSON(t, v, "GetPath() null leaf")
+
+	if _, err = cfg.GetPath("server", "ports", "0"); err == nil {
+		t.Error("expected error traversing an array")
+	}
+	if _, err = cfg.GetPath("server", "tls", "enabled", "x"); err == nil {
+		t.Error("expected error traversing a scalar")
+	}
+
+	v, err = NewJSON(nil, false, true).GetPath("server")
+	maybePanic(err)
+	assertNullJSON(t, v, "GetPath() null receiver")
+}
+
+func TestJSONRandomize(t *testing.T) {
+	var seed int64
+	nextInt := func() int64 {
+		seed++
+		return seed
+	}
+
+	kinds := make(map[byte]bool)
+	for n := 0; n < 100; n++ {
+		var i JSON
+		i.Randomize(nextInt, "json", false)
+		if !i.Valid {
+			t.Fatal("Randomize() should produce a valid JSON")
+		}
+		if !json.Valid(i.JSON) {
+			t.Fatalf("Randomize() produced malformed JSON: %s", i.JSON)
+		}
+		switch c := i.JSON[0]; c {
+		case '"', '{', '[', 't':
+			kinds[c] = true
+		case 'f':
+			kinds['t'] = true
+		default:
+			kinds['0'] = true
+		}
+	}
+	if len(kinds) != 5 {
+		t.Errorf("Randomize() should produce strings, numbers, bools, objects and arrays, got: %v", kinds)
+	}
+
+	j := JSONFrom([]byte(`{"a":1}`))
+	j.Randomize(nextInt, "json", true)
+	assertNullJSON(t, j, "Randomize() null")
+	if j.JSON != nil {
+		t.Errorf("Randomize() null should clear the value, got %s", j.JSON)
+	}
+}
+
+func TestJSONMerge(t *testing.T) {
+	defaults := JSONFrom([]byte(`{"server":{"port":80,"tags":["a"]},"debug":false}`))
+	overrides := JSONFrom([]byte(`{"server":{"host":"x","tags":["b","c"]},"extra":{}}`))
+
+	merged, err := defaults.Merge(overrides)
+	maybePanic(err)
+	assertJSONEquals(t, merged.JSON, `{"debug":false,"extra":{},"server":{"host":"x","port":80,"tags":["a","b","c"]}}`, "Merge()")
+	if !merged.Valid || !merged.Set {
+		t.Error("Merge() should produce a valid JSON")
+	}
+
+	merged, err = JSONFrom([]byte(`[1]`)).Merge(JSONFrom([]byte(`[2, 3]`)))
+	maybePanic(err)
+	assertJSONEquals(t, merged.JSON, `[1,2,3]`, "Merge() arrays")
+
+	merged, err = NewJSON(nil, false, true).Merge(defaults)
+	maybePanic(err)
+	if !merged.Equal(defaults) {
+		t.Errorf("Merge() with null receiver should equal the other side, got %s", merged.JSON)
+	}
+
+	merged, err = defaults.Merge(JSONFrom(NullBytes))
+	maybePanic(err)
+	if !merged.Equal(defaults) {
+		t.Errorf("Merge() with null operand should equal the receiver, got %s", merged.JSON)
+	}
+
+	conflicts := [][2]string{
+		{`{"a":1}`, `{"a":2}`},
+		{`{"a":{"b":1}}`, `{"a":{"b":"x"}}`},
+		{`{"a":{}}`, `{"a":[]}`},
+		{`{"a":1}`, `[1]`},
+		{`"scalar"`, `{}`},
+	}
+	for _, c := range conflicts {
+		if _, err = JSONFrom([]byte(c[0])).Merge(JSONFrom([]byte(c[1]))); err == nil {
+			t.Errorf("Merge(%s, %s) should fail", c[0], c[1])
+		}
+	}
+
+	if _, err = JSONFrom([]byte(`{`)).Merge(defaults); err == nil {
+		t.Error("expected error merging malformed JSON")
+	}
+}
+
+func TestJSONOr(t *testing.T) {
+	a := NewJSON([]byte(`"a"`), true, true)
+	b := NewJSON([]byte(`"b"`), true, true)
+	null := NewJSON(nil, false, true)
+	if got := a.Or(b); !(string(got.JSON) == string(a.JSON) && got.Valid == a.Valid) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(string(got.JSON) == string(b.JSON) && got.Valid == b.Valid) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestJSONIfValid(t *testing.T) {
+	j := JSONFrom([]byte(`"a"`))
+	var got []byte
+	j.IfValid(func(v []byte) { got = v })
+	assertJSONEquals(t, got, `"a"`, "IfValid()")
+	if &got[0] != &j.JSON[0] {
+		t.Error("IfValid() should pass the live buffer")
+	}
+	NewJSON([]byte(`"a"`), false, true).IfValid(func([]byte) {
+		t.Error("IfValid() should not be called for a null JSON")
+	})
+}
+
+func TestCoalesceJSON(t *testing.T) {
+	null := NewJSON(nil, false, true)
+	got := CoalesceJSON(null, JSONFrom([]byte(`"a"`)), JSONFrom([]byte(`"b"`)))
+	assertJSONEquals(t, got.JSON, `"a"`, "CoalesceJSON()")
+	if got := CoalesceJSON(null, null); got.Valid || got.Set || got.JSON != nil {
+		t.Errorf("CoalesceJSON() of nulls should be a zero JSON, got %v", got)
+	}
+	if got := CoalesceJSON(); got.Valid {
+		t.Error("CoalesceJSON() with no values should be null")
+	}
+}
+
+func TestJSONGet(t *testing.T) {
+	b := JSONFrom([]byte(`{"a":1}`))
+	v, ok := b.Get()
+	if !ok || string(v) != `{"a":1}` {
+		t.Errorf("bad Get() result: %s, %v", v, ok)
+	}
+	v[0] = 'x'
+	if string(b.JSON) != `{"a":1}` {
+		t.Error("Get() should return a copy, but the original was modified:", string(b.JSON))
+	}
+
+	var null JSON
+	if v, ok := null.Get(); ok || v != nil {
+		t.Errorf("bad null Get() result: %s, %v", v, ok)
+	}
+}
+
+func TestJSONGob(t *testing.T) {
+	type row struct {
+		A, B, C, D JSON
+	}
+	in := row{
+		A: JSONFrom([]byte(`{"a":1}`)),
+		B: NewJSON(nil, false, true),
+		C: NewJSON(nil, false, false),
+		D: NewJSON([]byte{}, true, true),
+	}
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(in)
+	maybePanic(err)
+
+	var out row
+	err = gob.NewDecoder(&buf).Decode(&out)
+	maybePanic(err)
+	if !out.A.Valid || !out.A.Set || string(out.A.JSON) != `{"a":1}` {
+		t.Errorf("bad gob valid: %+v", out.A)
+	}
+	if out.B.Valid || !out.B.Set {
+		t.Errorf("bad gob set but invalid: %+v", out.B)
+	}
+	if out.C.Valid || out.C.Set {
+		t.Errorf("bad gob unset: %+v", out.C)
+	}
+	if !out.D.Valid || out.D.JSON == nil || len(out.D.JSON) != 0 {
+		t.Errorf("bad gob valid empty: %+v", out.D)
+	}
+}
+
+func TestJSONUnmarshalBinaryErrors(t *testing.T) {
+	for _, data := range [][]byte{
+		nil,
+		{binaryVersion},
+		{binaryVersion + 1, binaryFlagValid},
+		{binaryVersion, 0, '1'},
+	} {
+		var j JSON
+		if err := j.UnmarshalBinary(data); err == nil {
+			t.Errorf("UnmarshalBinary(%v) should fail", data)
+		}
+	}
+}
+
+func assertJSON(t *testing.T, i JSON, from string) {
+	if !bytes.Equal(i.JSON, []byte(`"hello"`)) {
+		t.Errorf("bad %s []byte: %#v ≠ %#v\n", from, string(i.JSON), string([]byte(`"hello"`)))
+	}
+	if !i.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullJSON(t *testing.T, i JSON, from string) {
+	if i.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func BenchmarkJSONNested(b *testing.B) {
+	row := struct {
+		ID   int  `json:"id"`
+		Data JSON `json:"data"`
+	}{12345, JSONFrom([]byte(`{"name":"hello","tags":["a","b","c"],"n":1.5}`))}
+
+	b.Run("Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal(row); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("AppendJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]byte, 0, 128)
+		for i := 0; i < b.N; i++ {
+			buf = append(buf[:0], `{"id":`...)
+			buf = strconv.AppendInt(buf, int64(row.ID), 10)
+			buf = append(buf, `,"data":`...)
+			var err error
+			if buf, err = row.Data.AppendJSON(buf); err != nil {
+				b.Fatal(err)
+			}
+			buf = append(buf, '}')
+		}
+	})
+}
+
+func BenchmarkJSONScanInto(b *testing.B) {
+	src := bytes.Repeat([]byte(`{"k":"vvvvvvvvvvvvvvvv"},`), 400)
+	src = append(append([]byte{'['}, src[:len(src)-1]...), ']')
+
+	b.Run("Scan", func(b *testing.B) {
+		b.ReportAllocs()
+		var j JSON
+		for i := 0; i < b.N; i++ {
+			maybePanic(j.Scan(src))
+		}
+	})
+	b.Run("ScanInto", func(b *testing.B) {
+		b.ReportAllocs()
+		var j JSON
+		for i := 0; i < b.N; i++ {
+			maybePanic(j.ScanInto(src, j.JSON))
+		}
+	})
+	b.Run("ScanIntoPool", func(b *testing.B) {
+		b.ReportAllocs()
+		pool := sync.Pool{New: func() interface{} { return new([]byte) }}
+		for i := 0; i < b.N; i++ {
+			buf := pool.Get().(*[]byte)
+			var j JSON
+			maybePanic(j.ScanInto(src, *buf))
+			*buf = j.JSON
+			pool.Put(buf)
+		}
+	})
+}
+
+func BenchmarkJSONScanBytes(b *testing.B) {
+	src := bytes.Repeat([]byte(`{"key":"value"},`), 4096)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(src)))
+	for n := 0; n < b.N; n++ {
+		var j JSON
+		if err := j.Scan(src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkJSONScanString(b *testing.B) {
+	src := strings.Repeat(`{"key":"value"},`, 4096)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(src)))
+	for n := 0; n < b.N; n++ {
+		var j JSON
+		if err := j.Scan(src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSONSlice(b *testing.B) {
+	js := make([]JSON, 1000)
+	for i := range js {
+		if i%10 != 0 {
+			js[i] = JSONFrom([]byte(`{"id":` + strconv.Itoa(i) + `,"tags":["a","b"]}`))
+		}
+	}
+
+	b.Run("json.Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, err := json.Marshal(js)
+			maybePanic(err)
+		}
+	})
+	b.Run("MarshalJSONSlice", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, err := MarshalJSONSlice(js)
+			maybePanic(err)
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package null
+
+import (
+	"database/sql/driver"
+)
+
+// JSONText is a nullable JSON value held as a string rather than a []byte.
+// It behaves exactly like JSON, and converts to and from it with Bytes and
+// JSON.Text, for code that passes JSON around as strings.
+type JSONText struct {
+	JSONText string
+	Valid    bool
+	Set      bool
+}
+
+// NewJSONText creates a new JSONText
+func NewJSONText(s string, valid, set bool) JSONText {
+	return JSONText{
+		JSONText: s,
+		Valid:    valid,
+		Set:      set,
+	}
+}
+
+// JSONTextFrom creates a new JSONText that will always be valid.
+func JSONTextFrom(s string) JSONText {
+	return NewJSONText(s, true, true)
+}
+
+// JSONTextFromPtr creates a new JSONText that will be null if s is nil.
+func JSONTextFromPtr(s *string) JSONText {
+	if s == nil {
+		return NewJSONText("", false, true)
+	}
+	return NewJSONText(*s, true, true)
+}
+
+// JSONTextUnset creates a new JSONText that is null and not Set, as for a
+// field absent from the input. IsSet reports false until a value or an
+// explicit null is unmarshaled into it, or it is changed with SetValid or
+// SetNull.
+func JSONTextUnset() JSONText {
+	return JSONText{}
+}
+
+// Text converts this JSON to a JSONText with the same value and flags.
+func (j JSON) Text() JSONText {
+	return NewJSONText(string(j.JSON), j.Valid, j.Set)
+}
+
+// Bytes converts this JSONText to a JSON with the same value and flags.
+// A null JSONText converts to a JSON holding nil.
+func (t JSONText) Bytes() JSON {
+	if !t.Valid && t.JSONText == "" {
+		return NewJSON(nil, false, t.Set)
+	}
+	return NewJSON([]byte(t.JSONText), t.Valid, t.Set)
+}
+
+func (t JSONText) IsSet() bool {
+	return t.Set
+}
+
+// Unmarshal will unmarshal the JSON stored in this
+// JSONText into the value pointed to by dest.
+func (t JSONText) Unmarshal(dest interface{}) error {
+	return t.Bytes().Unmarshal(dest)
+}
+
+// Marshal will marshal the passed in object,
+// and store it in the JSONText.
+func (t *JSONText) Marshal(obj interface{}) error {
+	j := t.Bytes()
+	if err := j.Marshal(obj); err != nil {
+		return err
+	}
+	*t = j.Text()
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *JSONText) UnmarshalJSON(data []byte) error {
+	j := t.Bytes()
+	err := j.UnmarshalJSON(data)
+	*t = j.Text()
+	return err
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *JSONText) UnmarshalText(text []byte) error {
+	var j JSON
+	err := j.UnmarshalText(text)
+	*t = j.Text()
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t JSONText) MarshalJSON() ([]byte, error) {
+	return t.Bytes().MarshalJSON()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t JSONText) MarshalText() ([]byte, error) {
+	return t.Bytes().MarshalText()
+}
+
+// String implements fmt.Stringer, returning the same text as JSON.String.
+func (t JSONText) String() string {
+	if t.JSONText == "" || !t.Valid {
+		return string(NullBytes)
+	}
+	return t.JSONText
+}
+
+// SetValid changes this JSONText's value and also sets it to be non-null.
+func (t *JSONText) SetValid(s string) {
+	t.JSONText = s
+	t.Valid = true
+	t.Set = true
+}
+
+// SetNull changes this JSONText's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (t *JSONText) SetNull() {
+	t.JSONText = ""
+	t.Valid = false
+	t.Set = true
+}
+
+// Ptr returns a pointer to this JSONText's value, or a nil pointer if this JSONText is null.
+func (t JSONText) Ptr() *string {
+	if !t.Valid {
+		return nil
+	}
+	return &t.JSONText
+}
+
+// IsZero returns true for null JSONText's, for potential future omitempty support.
+func (t JSONText) IsZero() bool {
+	return !t.Valid
+}
+
+// Or returns this JSONText if it is valid, or other if it is not.
+func (t JSONText) Or(other JSONText) JSONText {
+	if t.Valid {
+		return t
+	}
+	return other
+}
+
+// Get returns this JSONText's value and true, or the zero value and false if it is null.
+func (t JSONText) Get() (string, bool) {
+	if !t.Valid {
+		return "", false
+	}
+	return t.JSONText, true
+}
+
+// Scan implements the Scanner interface, following the same rules as JSON.Scan.
+func (t *JSONText) Scan(value interface{}) error {
+	if s, ok := value.(string); ok && !(ScanJSONNullAsNull && isJSONNullValue(s)) {
+		t.JSONText, t.Valid, t.Set = s, true, true
+		return nil
+	}
+	var j JSON
+	err := j.Scan(value)
+	*t = j.Text()
+	return err
+}
+
+// Value implements the driver Valuer interface.
+func (t JSONText) Value() (driver.Value, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	return t.JSONText, nil
+}
+
+// Randomize for sqlboiler
+func (t *JSONText) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	var j JSON
+	j.Randomize(nextInt, fieldType, shouldBeNull)
+	t.JSONText, t.Valid = string(j.JSON), j.Valid
+}
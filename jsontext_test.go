@@ -0,0 +1,181 @@
+package null
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONTextFrom(t *testing.T) {
+	assertJSONText(t, JSONTextFrom(`"hello"`), "JSONTextFrom()")
+
+	empty := JSONTextFrom("")
+	if !empty.Valid {
+		t.Error("JSONTextFrom() of an empty string should be valid")
+	}
+
+	s := `"hello"`
+	assertJSONText(t, JSONTextFromPtr(&s), "JSONTextFromPtr()")
+	null := JSONTextFromPtr(nil)
+	assertNullJSONText(t, null, "JSONTextFromPtr(nil)")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+}
+
+func TestJSONTextUnset(t *testing.T) {
+	v := JSONTextUnset()
+	assertNullJSONText(t, v, "JSONTextUnset()")
+	if v.IsSet() {
+		t.Error("JSONTextUnset() should not be Set")
+	}
+}
+
+func TestJSONTextConversions(t *testing.T) {
+	j := JSONFrom([]byte(`{"a":1}`))
+	text := j.Text()
+	if text.JSONText != `{"a":1}` || !text.Valid || !text.Set {
+		t.Errorf("bad Text() result: %#v", text)
+	}
+	back := text.Bytes()
+	if !back.Equal(j) || !back.Set {
+		t.Errorf("bad Bytes() result: %#v", back)
+	}
+
+	null := NewJSON(nil, false, true).Text()
+	assertNullJSONText(t, null, "Text() of null")
+	if b := null.Bytes(); b.Valid || b.JSON != nil || !b.Set {
+		t.Errorf("Bytes() of null should be a null JSON holding nil: %#v", b)
+	}
+}
+
+func TestJSONTextMarshal(t *testing.T) {
+	data, err := json.Marshal(JSONTextFrom(`{"a":1}`))
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"a":1}`, "non-empty json marshal")
+
+	data, err = json.Marshal(NewJSONText("", false, true))
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+
+	data, err = JSONTextFrom(`"hello"`).MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, `"hello"`, "text marshal")
+
+	var obj JSONText
+	err = obj.Marshal(map[string]int{"a": 1})
+	maybePanic(err)
+	if obj.JSONText != `{"a":1}` || !obj.Valid {
+		t.Errorf("bad Marshal() result: %#v", obj)
+	}
+	var m map[string]int
+	err = obj.Unmarshal(&m)
+	maybePanic(err)
+	if m["a"] != 1 {
+		t.Errorf("bad Unmarshal() result: %v", m)
+	}
+}
+
+func TestJSONTextUnmarshal(t *testing.T) {
+	var s struct {
+		Doc  JSONText `json:"doc"`
+		Null JSONText `json:"null"`
+	}
+	err := json.Unmarshal([]byte(`{"doc":"hello","null":null}`), &s)
+	maybePanic(err)
+	if s.Doc.JSONText != `"hello"` || !s.Doc.Valid || !s.Doc.Set {
+		t.Errorf("bad unmarshaled doc: %#v", s.Doc)
+	}
+	assertNullJSONText(t, s.Null, "unmarshaled null")
+	if !s.Null.Set {
+		t.Error("unmarshaled null should be Set")
+	}
+
+	var text JSONText
+	err = text.UnmarshalText([]byte(`"hello"`))
+	maybePanic(err)
+	assertJSONText(t, text, "UnmarshalText()")
+	err = text.UnmarshalText(NullBytes)
+	maybePanic(err)
+	assertNullJSONText(t, text, "UnmarshalText() null")
+}
+
+func TestJSONTextScanValue(t *testing.T) {
+	for _, in := range []interface{}{`"hello"`, []byte(`"hello"`)} {
+		var text JSONText
+		err := text.Scan(in)
+		maybePanic(err)
+		assertJSONText(t, text, "scanned json text")
+		if v, err := text.Value(); v != `"hello"` || err != nil {
+			t.Error("bad value or err:", v, err)
+		}
+	}
+
+	var num JSONText
+	err := num.Scan(int64(1))
+	maybePanic(err)
+	if num.JSONText != "1" || !num.Valid {
+		t.Errorf("bad scanned int64: %#v", num)
+	}
+
+	var null JSONText
+	err = null.Scan(nil)
+	maybePanic(err)
+	assertNullJSONText(t, null, "scanned null")
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	ScanJSONNullAsNull = true
+	defer func() { ScanJSONNullAsNull = false }()
+	var literal JSONText
+	err = literal.Scan("null")
+	maybePanic(err)
+	assertNullJSONText(t, literal, "scanned null literal")
+}
+
+func TestJSONTextHelpers(t *testing.T) {
+	text := JSONTextFrom(`"hello"`)
+	if ptr := text.Ptr(); ptr == nil || *ptr != `"hello"` {
+		t.Errorf("bad Ptr() result: %v", ptr)
+	}
+	if v, ok := text.Get(); !ok || v != `"hello"` {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	if s := text.String(); s != `"hello"` {
+		t.Errorf("bad String() result: %q", s)
+	}
+
+	null := NewJSONText("", false, true)
+	if null.Ptr() != nil || !null.IsZero() || text.IsZero() {
+		t.Error("bad Ptr() or IsZero() result for null")
+	}
+	if s := null.String(); s != "null" {
+		t.Errorf("bad String() result for null: %q", s)
+	}
+	if got := null.Or(text); got != text {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+
+	text.SetNull()
+	assertNullJSONText(t, text, "SetNull()")
+	if !text.IsSet() {
+		t.Error("should be Set")
+	}
+	text.SetValid(`"hello"`)
+	assertJSONText(t, text, "SetValid()")
+}
+
+func assertJSONText(t *testing.T, text JSONText, from string) {
+	if text.JSONText != `"hello"` {
+		t.Errorf("bad %s value: %q ≠ %q\n", from, text.JSONText, `"hello"`)
+	}
+	if !text.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullJSONText(t *testing.T, text JSONText, from string) {
+	if text.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
@@ -0,0 +1,91 @@
+//go:build msgpack
+
+package null
+
+import (
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+)
+
+// MessagePack support is only built with the msgpack build tag, so that
+// github.com/vmihailenco/msgpack is not a dependency for everyone else.
+//
+// Invalid values encode as msgpack nil. When decoding a struct field the
+// msgpack library handles nil itself and leaves the zero value, so a decoded
+// nil is invalid and not Set, as with a missing key.
+
+// EncodeMsgpack implements msgpack.CustomEncoder.
+// A valid JSON is encoded as a bin value holding its bytes.
+func (j JSON) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if !j.Valid {
+		return enc.EncodeNil()
+	}
+	return enc.EncodeBytes(j.JSON)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder.
+// Both bin and str values are accepted.
+func (j *JSON) DecodeMsgpack(dec *msgpack.Decoder) error {
+	isNil, err := decodeMsgpackNil(dec)
+	if err != nil {
+		return err
+	}
+	j.Set = true
+	if isNil {
+		j.JSON, j.Valid = nil, false
+		return nil
+	}
+
+	b, err := dec.DecodeBytes()
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		b = []byte{}
+	}
+	j.JSON, j.Valid = b, true
+	return nil
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder.
+// A valid Time is encoded with the msgpack timestamp extension.
+func (t Time) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if !t.Valid {
+		return enc.EncodeNil()
+	}
+	return enc.EncodeTime(t.Time)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder.
+func (t *Time) DecodeMsgpack(dec *msgpack.Decoder) error {
+	isNil, err := decodeMsgpackNil(dec)
+	if err != nil {
+		return err
+	}
+	t.Set = true
+	if isNil {
+		t.Time, t.Valid = time.Time{}, false
+		return nil
+	}
+
+	v, err := dec.DecodeTime()
+	if err != nil {
+		return err
+	}
+	t.Time, t.Valid = v, true
+	return nil
+}
+
+// decodeMsgpackNil consumes the next value if it is nil, and reports whether it was.
+func decodeMsgpackNil(dec *msgpack.Decoder) (bool, error) {
+	c, err := dec.PeekCode()
+	if err != nil {
+		return false, err
+	}
+	if c != msgpcode.Nil {
+		return false, nil
+	}
+	return true, dec.DecodeNil()
+}
@@ -0,0 +1,107 @@
+//go:build msgpack
+
+package null
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type msgpackRecord struct {
+	JSON JSON
+	Time Time
+}
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	in := msgpackRecord{
+		JSON: JSONFrom([]byte(`{"a":1}`)),
+		Time: TimeFrom(timeValue),
+	}
+	data, err := msgpack.Marshal(in)
+	maybePanic(err)
+
+	var out msgpackRecord
+	err = msgpack.Unmarshal(data, &out)
+	maybePanic(err)
+	assertJSONEquals(t, out.JSON.JSON, `{"a":1}`, "msgpack json")
+	if !out.JSON.Valid || !out.JSON.Set {
+		t.Error("msgpack json should be valid and Set")
+	}
+	if !out.Time.Valid || !out.Time.Time.Equal(timeValue) {
+		t.Errorf("bad msgpack time: %v", out.Time)
+	}
+}
+
+func TestMsgpackNull(t *testing.T) {
+	data, err := msgpack.Marshal(msgpackRecord{})
+	maybePanic(err)
+
+	var generic map[string]interface{}
+	err = msgpack.Unmarshal(data, &generic)
+	maybePanic(err)
+	if v, ok := generic["JSON"]; !ok || v != nil {
+		t.Errorf("null JSON should encode as msgpack nil, got %#v", v)
+	}
+	if v, ok := generic["Time"]; !ok || v != nil {
+		t.Errorf("null Time should encode as msgpack nil, got %#v", v)
+	}
+
+	out := msgpackRecord{
+		JSON: JSONFrom([]byte(`1`)),
+		Time: TimeFrom(timeValue),
+	}
+	err = msgpack.Unmarshal(data, &out)
+	maybePanic(err)
+	assertNullJSON(t, out.JSON, "msgpack nil json")
+	assertNullTime(t, out.Time, "msgpack nil time")
+}
+
+func TestMsgpackInterop(t *testing.T) {
+	data, err := msgpack.Marshal(map[string]interface{}{
+		"JSON": `"hello"`,
+		"Time": timeValue,
+	})
+	maybePanic(err)
+
+	var out msgpackRecord
+	err = msgpack.Unmarshal(data, &out)
+	maybePanic(err)
+	assertJSONEquals(t, out.JSON.JSON, `"hello"`, "msgpack json from str")
+	if !out.Time.Valid || !out.Time.Time.Equal(timeValue) {
+		t.Errorf("bad msgpack time: %v", out.Time)
+	}
+
+	var wrong Time
+	err = msgpack.Unmarshal([]byte{0x01}, &wrong)
+	if err == nil {
+		t.Error("expected error decoding an integer into Time")
+	}
+}
+
+func BenchmarkMsgpackJSON(b *testing.B) {
+	r := msgpackRecord{
+		JSON: JSONFrom([]byte(`{"id":12345,"name":"hello","tags":["a","b","c"]}`)),
+		Time: TimeFrom(time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)),
+	}
+	b.Run("msgpack", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			data, err := msgpack.Marshal(r)
+			maybePanic(err)
+			var out msgpackRecord
+			maybePanic(msgpack.Unmarshal(data, &out))
+		}
+	})
+	b.Run("json", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			data, err := json.Marshal(r)
+			maybePanic(err)
+			var out msgpackRecord
+			maybePanic(json.Unmarshal(data, &out))
+		}
+	})
+}
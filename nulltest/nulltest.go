@@ -0,0 +1,205 @@
+// Package nulltest provides test helpers for values that hold null types.
+//
+// reflect.DeepEqual is unreliable on these: a Time carries a monotonic
+// clock reading and a location pointer that differ between equal instants,
+// and a JSON compares its bytes rather than the document they encode.
+// AssertEqual compares such fields with their Equal methods instead.
+package nulltest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// AssertEqual reports an error on t, naming the first field that differs,
+// unless a and b are semantically equal. Values whose type has a method
+// Equal(T) bool, such as null.Time, null.JSON and time.Time, are compared
+// with it. Otherwise structs, arrays, slices and maps are compared element
+// by element, pointers and interfaces by what they hold, and other values
+// with ==. A nil slice or map equals an empty one.
+//
+// Values with an IsSet() bool method, which all the null types have, must
+// also agree on it before Equal is called, since Equal ignores it: an
+// explicit null differs from an absent field.
+//
+// Unexported fields are compared too, but Equal methods cannot be called
+// on them, so values inside unexported fields are compared structurally.
+// Cyclic values are handled: a pair of pointers, maps or slices already
+// being compared is taken as equal where it recurs.
+func AssertEqual(t testing.TB, a, b interface{}) {
+	t.Helper()
+	c := comparer{seen: map[visit]bool{}}
+	if path, ok := c.diff(reflect.ValueOf(a), reflect.ValueOf(b), ""); !ok {
+		switch {
+		case path == "":
+			t.Errorf("nulltest: values differ: %v != %v", a, b)
+			return
+		case strings.HasPrefix(path, ":"):
+			// The top-level values themselves differ.
+			t.Errorf("nulltest: values differ%s", path)
+			return
+		}
+		t.Errorf("nulltest: values differ at %s", path)
+	}
+}
+
+// visit is a pair of pointers, maps or slices of one type being compared.
+type visit struct {
+	a, b uintptr
+	len  int
+	typ  reflect.Type
+}
+
+type comparer struct {
+	seen map[visit]bool
+}
+
+// diff returns the path of the first difference between a and b, and false,
+// or true if there is none.
+func (c comparer) diff(a, b reflect.Value, path string) (string, bool) {
+	if !a.IsValid() || !b.IsValid() {
+		return path, a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return fmt.Sprintf("%s: type %s != %s", path, a.Type(), b.Type()), false
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if !a.IsNil() && !b.IsNil() {
+			v := visit{a.Pointer(), b.Pointer(), 0, a.Type()}
+			if a.Kind() == reflect.Slice {
+				v.len = a.Len()
+			}
+			if c.seen[v] {
+				return "", true
+			}
+			c.seen[v] = true
+		}
+	}
+
+	if as, bs, ok := isSetMethod(a, b); ok && as != bs {
+		return fmt.Sprintf("%s: IsSet() %t != %t", path, as, bs), false
+	}
+	if eq, ok := equalMethod(a, b); ok {
+		if !eq {
+			return fmt.Sprintf("%s: %v != %v", path, a, b), false
+		}
+		return "", true
+	}
+
+	switch a.Kind() {
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			name := a.Type().Field(i).Name
+			if p, ok := c.diff(a.Field(i), b.Field(i), join(path, name)); !ok {
+				return p, false
+			}
+		}
+		return "", true
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				return fmt.Sprintf("%s: %v != %v", path, a, b), false
+			}
+			return "", true
+		}
+		return c.diff(a.Elem(), b.Elem(), path)
+	case reflect.Array, reflect.Slice:
+		if a.Len() != b.Len() {
+			return fmt.Sprintf("%s: length %d != %d", path, a.Len(), b.Len()), false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if p, ok := c.diff(a.Index(i), b.Index(i), fmt.Sprintf("%s[%d]", path, i)); !ok {
+				return p, false
+			}
+		}
+		return "", true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return fmt.Sprintf("%s: length %d != %d", path, a.Len(), b.Len()), false
+		}
+		iter := a.MapRange()
+		for iter.Next() {
+			k := iter.Key()
+			p := fmt.Sprintf("%s[%v]", path, k)
+			bv := b.MapIndex(k)
+			if !bv.IsValid() {
+				return p + ": missing", false
+			}
+			if p, ok := c.diff(iter.Value(), bv, p); !ok {
+				return p, false
+			}
+		}
+		return "", true
+	}
+
+	if !equalScalar(a, b) {
+		return fmt.Sprintf("%s: %v != %v", path, a, b), false
+	}
+	return "", true
+}
+
+// equalMethod calls a.Equal(b) if a's type has such a method, and reports
+// whether it did.
+func equalMethod(a, b reflect.Value) (bool, bool) {
+	if !a.CanInterface() {
+		return false, false
+	}
+	m, ok := a.Type().MethodByName("Equal")
+	if !ok {
+		return false, false
+	}
+	mt := m.Type
+	if mt.NumIn() != 2 || mt.In(1) != a.Type() || mt.NumOut() != 1 || mt.Out(0).Kind() != reflect.Bool {
+		return false, false
+	}
+	return m.Func.Call([]reflect.Value{a, b})[0].Bool(), true
+}
+
+// isSetMethod calls a.IsSet() and b.IsSet() if their type has such a
+// method, and reports whether it did. Pointers and interfaces are left to
+// be compared by what they hold.
+func isSetMethod(a, b reflect.Value) (bool, bool, bool) {
+	if !a.CanInterface() || a.Kind() == reflect.Ptr || a.Kind() == reflect.Interface {
+		return false, false, false
+	}
+	m, ok := a.Type().MethodByName("IsSet")
+	if !ok {
+		return false, false, false
+	}
+	mt := m.Type
+	if mt.NumIn() != 1 || mt.NumOut() != 1 || mt.Out(0).Kind() != reflect.Bool {
+		return false, false, false
+	}
+	return m.Func.Call([]reflect.Value{a})[0].Bool(), m.Func.Call([]reflect.Value{b})[0].Bool(), true
+}
+
+func equalScalar(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	}
+	return false
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
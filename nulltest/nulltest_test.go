@@ -0,0 +1,168 @@
+package nulltest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/volatiletech/null/v9"
+)
+
+type account struct {
+	ID        int
+	Name      null.String
+	CreatedAt null.Time
+	Settings  null.JSON
+	Parent    *account
+	Tags      []string
+	Extra     map[string]null.Int
+	note      string
+}
+
+// recorder is a testing.TB that keeps the errors reported to it.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func sample() account {
+	now := time.Now()
+	return account{
+		ID:        1,
+		Name:      null.StringFrom("ann"),
+		CreatedAt: null.TimeFrom(now),
+		Settings:  null.JSONFrom([]byte(`{"theme":"dark","size":12}`)),
+		Parent:    &account{ID: 2, CreatedAt: null.NewTime(time.Time{}, false, true)},
+		Tags:      []string{"a", "b"},
+		Extra:     map[string]null.Int{"score": null.IntFrom(3)},
+		note:      "x",
+	}
+}
+
+func equivalent(a account) account {
+	// Strip the monotonic reading and move the location: reflect.DeepEqual
+	// tells these apart, but they are the same instant.
+	a.CreatedAt = null.TimeFrom(a.CreatedAt.Time.Round(0).In(time.FixedZone("UTC+2", 2*60*60)))
+	a.Settings = null.JSONFrom([]byte(`{ "size": 12, "theme": "dark" }`))
+	a.Parent = &account{ID: 2, CreatedAt: null.NewTime(time.Now(), false, true)}
+	a.Tags = append([]string(nil), a.Tags...)
+	return a
+}
+
+func TestAssertEqual(t *testing.T) {
+	a := sample()
+	b := equivalent(a)
+	if reflect.DeepEqual(a, b) {
+		t.Fatal("the sample values should not be reflect.DeepEqual")
+	}
+
+	r := &recorder{}
+	AssertEqual(r, a, b)
+	AssertEqual(r, &a, &b)
+	AssertEqual(r, []account{a}, []account{b})
+	AssertEqual(r, account{}, account{Tags: []string{}, Extra: map[string]null.Int{}})
+	if len(r.errors) != 0 {
+		t.Errorf("AssertEqual() should accept semantically equal values, got %q", r.errors)
+	}
+}
+
+func TestAssertEqualDiffers(t *testing.T) {
+	tests := []struct {
+		change func(*account)
+		want   string
+	}{
+		{func(a *account) { a.ID = 3 }, "nulltest: values differ at ID: 1 != 3"},
+		{func(a *account) { a.Name = null.NewString("ann", false, true) }, "nulltest: values differ at Name.Valid: true != false"},
+		{func(a *account) { a.CreatedAt = a.CreatedAt.Add(time.Second) }, "nulltest: values differ at CreatedAt: "},
+		{func(a *account) { a.Settings = null.JSONFrom([]byte(`{"theme":"light","size":12}`)) }, "nulltest: values differ at Settings: "},
+		{func(a *account) { a.Parent.ID = 4 }, "nulltest: values differ at Parent.ID: 2 != 4"},
+		{func(a *account) { a.Parent = nil }, "nulltest: values differ at Parent: "},
+		{func(a *account) { a.Tags = a.Tags[:1] }, "nulltest: values differ at Tags: length 2 != 1"},
+		{func(a *account) { a.Tags = []string{"a", "c"} }, "nulltest: values differ at Tags[1]: b != c"},
+		{func(a *account) { a.Extra = map[string]null.Int{"rank": null.IntFrom(3)} }, "nulltest: values differ at Extra[score]: missing"},
+		{func(a *account) { a.Extra["score"] = null.IntFrom(4) }, "nulltest: values differ at Extra[score].Int: 3 != 4"},
+		{func(a *account) { a.note = "y" }, "nulltest: values differ at note: x != y"},
+	}
+
+	for _, test := range tests {
+		a, b := sample(), sample()
+		b.CreatedAt = a.CreatedAt
+		b.Extra = map[string]null.Int{"score": null.IntFrom(3)}
+		test.change(&b)
+
+		r := &recorder{}
+		AssertEqual(r, a, b)
+		if len(r.errors) != 1 || len(r.errors[0]) < len(test.want) || r.errors[0][:len(test.want)] != test.want {
+			t.Errorf("got errors %q, want one starting with %q", r.errors, test.want)
+		}
+	}
+
+	r := &recorder{}
+	AssertEqual(r, 1, "1")
+	AssertEqual(r, nil, 1)
+	if len(r.errors) != 2 {
+		t.Errorf("AssertEqual() should reject values of different types, got %q", r.errors)
+	}
+}
+
+func TestAssertEqualSet(t *testing.T) {
+	tests := []struct {
+		a, b interface{}
+		want string
+	}{
+		{null.NewTime(time.Time{}, false, true), null.Time{}, "nulltest: values differ: IsSet() true != false"},
+		{null.NewJSON(nil, false, true), null.JSONUnset(), "nulltest: values differ: IsSet() true != false"},
+		{account{Name: null.StringUnset()}, account{Name: null.NewString("", false, true)}, "nulltest: values differ at Name: IsSet() false != true"},
+		{account{CreatedAt: null.NewTime(time.Time{}, false, true)}, account{}, "nulltest: values differ at CreatedAt: IsSet() true != false"},
+	}
+	for _, test := range tests {
+		r := &recorder{}
+		AssertEqual(r, test.a, test.b)
+		if len(r.errors) != 1 || r.errors[0] != test.want {
+			t.Errorf("got errors %q, want %q", r.errors, test.want)
+		}
+	}
+
+	r := &recorder{}
+	AssertEqual(r, null.NewTime(time.Time{}, false, true), null.NewTime(time.Now(), false, true))
+	AssertEqual(r, &account{}, &account{})
+	var none *account
+	AssertEqual(r, none, none)
+	if len(r.errors) != 0 {
+		t.Errorf("AssertEqual() should accept nulls that are both Set, got %q", r.errors)
+	}
+}
+
+func TestAssertEqualCycle(t *testing.T) {
+	a, b := sample(), sample()
+	b.CreatedAt = a.CreatedAt
+	a.Parent, b.Parent = &a, &b
+
+	r := &recorder{}
+	AssertEqual(r, a, b)
+	if len(r.errors) != 0 {
+		t.Errorf("AssertEqual() should accept equal cyclic values, got %q", r.errors)
+	}
+
+	b.Tags = []string{"a", "c"}
+	AssertEqual(r, a, b)
+	if want := "nulltest: values differ at Parent.Tags[1]: b != c"; len(r.errors) != 1 || r.errors[0] != want {
+		t.Errorf("got errors %q, want %q", r.errors, want)
+	}
+
+	type node struct{ Next []interface{} }
+	x := []interface{}{1}
+	x = append(x, x)
+	r = &recorder{}
+	AssertEqual(r, node{x}, node{x})
+	if len(r.errors) != 0 {
+		t.Errorf("AssertEqual() should accept a slice holding itself, got %q", r.errors)
+	}
+}
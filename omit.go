@@ -0,0 +1,139 @@
+package null
+
+import (
+	"reflect"
+	"strings"
+)
+
+// encoding/json only omits empty values of basic kinds, so a struct field
+// such as a null.Time tagged ",omitempty" is always written, even when it is
+// null. Go 1.24 and later also understand the ",omitzero" option, which
+// omits a field when its IsZero method reports true; every type in this
+// package is zero when it is null. OmitEmpty does the same for older Go
+// versions.
+
+// OmitEmpty returns the fields of the struct v as a map keyed by their JSON
+// names, leaving out fields tagged ",omitempty" that are empty and fields
+// tagged ",omitzero" that are zero. A field is empty if encoding/json would
+// consider it so, or if it has an IsZero method that reports true, as null
+// values of this package do. A field is zero, as for encoding/json, if its
+// IsZero method reports true or, without one, if it is its type's zero
+// value; unlike for ",omitempty", an empty non-nil slice or map is kept.
+// Marshaling the map gives the JSON of v with those fields omitted.
+//
+// Fields tagged "-" and unexported fields are skipped. Fields of untagged
+// embedded structs are promoted as they are by encoding/json, and lose to a
+// field of the same name in the outer struct. The ",string" option is not
+// supported. OmitEmpty returns nil if v is not a struct or a non-nil pointer
+// to one.
+func OmitEmpty(v interface{}) map[string]interface{} {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	m := make(map[string]interface{}, rv.NumField())
+	omitEmptyFields(m, rv)
+	return m
+}
+
+func omitEmptyFields(m map[string]interface{}, rv reflect.Value) {
+	rt := rv.Type()
+	var embedded []reflect.Value
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		fv := rv.Field(i)
+		if sf.Anonymous && name == "" {
+			if fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct {
+				if !fv.IsNil() {
+					embedded = append(embedded, fv.Elem())
+				}
+				continue
+			}
+			if fv.Kind() == reflect.Struct {
+				embedded = append(embedded, fv)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		omitEmpty, omitZero := omitOptions(opts)
+		if omitEmpty && isEmptyValue(fv) || omitZero && isZeroValue(fv) {
+			continue
+		}
+		m[name] = fv.Interface()
+	}
+
+	for _, ev := range embedded {
+		promoted := make(map[string]interface{}, ev.NumField())
+		omitEmptyFields(promoted, ev)
+		for name, v := range promoted {
+			if _, ok := m[name]; !ok {
+				m[name] = v
+			}
+		}
+	}
+}
+
+func omitOptions(opts string) (omitEmpty, omitZero bool) {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		switch opt {
+		case "omitempty":
+			omitEmpty = true
+		case "omitzero":
+			omitZero = true
+		}
+	}
+	return omitEmpty, omitZero
+}
+
+// isZeroValue reports whether encoding/json would leave out v for
+// ",omitzero".
+func isZeroValue(v reflect.Value) bool {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return true
+	}
+	if z, ok := v.Interface().(interface{ IsZero() bool }); ok {
+		return z.IsZero()
+	}
+	return v.IsZero()
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		if v.Len() == 0 {
+			return true
+		}
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	if z, ok := v.Interface().(interface{ IsZero() bool }); ok {
+		return z.IsZero()
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package null
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type omitAudit struct {
+	CreatedBy String `json:"created_by,omitempty"`
+	UpdatedBy String `json:"updated_by,omitempty"`
+}
+
+type omitRecord struct {
+	omitAudit
+	ID        int    `json:"id"`
+	Name      String `json:"name,omitempty"`
+	DeletedAt Time   `json:"deleted_at,omitempty"`
+	Payload   JSON   `json:"payload,omitzero"`
+	Note      String `json:"note"`
+	Tags      []string
+	Count     int    `json:",omitempty"`
+	UpdatedBy String `json:"updated_by"`
+	Secret    string `json:"-"`
+	internal  int
+}
+
+type omitReview struct {
+	Reviewer String `json:"reviewer,omitempty"`
+}
+
+type omitPromoted struct {
+	*omitReview
+	ID int `json:"id"`
+}
+
+func TestOmitEmpty(t *testing.T) {
+	r := omitRecord{
+		ID:        1,
+		Name:      NewString("", false, true),
+		DeletedAt: NewTime(timeValue, false, true),
+		Payload:   NewJSON(nil, false, true),
+		Note:      NewString("", false, true),
+		UpdatedBy: StringFrom("bob"),
+		omitAudit: omitAudit{
+			CreatedBy: StringFrom("ann"),
+			UpdatedBy: StringFrom("ann"),
+		},
+		Secret:   "hunter2",
+		internal: 1,
+	}
+	data, err := json.Marshal(OmitEmpty(r))
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"Tags":null,"created_by":"ann","id":1,"note":null,"updated_by":"bob"}`, "OmitEmpty() nulls")
+
+	r.Name = StringFrom("")
+	r.DeletedAt = TimeFrom(timeValue)
+	r.Payload = JSONFrom([]byte(`{"a":1}`))
+	r.Count = 2
+	r.CreatedBy.SetNull()
+	data, err = json.Marshal(OmitEmpty(&r))
+	maybePanic(err)
+	assertJSONEquals(t, data,
+		`{"Count":2,"Tags":null,"deleted_at":"2012-12-21T21:21:21Z","id":1,"name":"","note":null,"payload":{"a":1},"updated_by":"bob"}`,
+		"OmitEmpty() valid values")
+}
+
+func TestOmitEmptyOmitZero(t *testing.T) {
+	type options struct {
+		EmptyTags  []string          `json:"empty_tags,omitempty"`
+		ZeroTags   []string          `json:"zero_tags,omitzero"`
+		EmptyAttrs map[string]string `json:"empty_attrs,omitempty"`
+		ZeroAttrs  map[string]string `json:"zero_attrs,omitzero"`
+		ZeroArray  [2]int            `json:"zero_array,omitzero"`
+		ZeroStruct struct{ A int }   `json:"zero_struct,omitzero"`
+		ZeroTime   *Time             `json:"zero_time,omitzero"`
+		Both       []string          `json:"both,omitempty,omitzero"`
+	}
+
+	v := options{
+		EmptyTags:  []string{},
+		ZeroTags:   []string{},
+		EmptyAttrs: map[string]string{},
+		ZeroAttrs:  map[string]string{},
+		Both:       []string{},
+	}
+	data, err := json.Marshal(OmitEmpty(v))
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"zero_attrs":{},"zero_tags":[]}`, "OmitEmpty() empty non-nil")
+
+	data, err = json.Marshal(OmitEmpty(options{}))
+	maybePanic(err)
+	assertJSONEquals(t, data, `{}`, "OmitEmpty() zero")
+
+	null := NewTime(timeValue, false, true)
+	v = options{ZeroArray: [2]int{0, 1}, ZeroTime: &null}
+	v.ZeroStruct.A = 1
+	data, err = json.Marshal(OmitEmpty(v))
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"zero_array":[0,1],"zero_struct":{"A":1}}`, "OmitEmpty() non-zero")
+}
+
+func TestOmitEmptyPromoted(t *testing.T) {
+	data, err := json.Marshal(OmitEmpty(omitPromoted{ID: 1}))
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"id":1}`, "OmitEmpty() nil embedded struct")
+
+	p := omitPromoted{omitReview: &omitReview{Reviewer: StringFrom("ann")}, ID: 1}
+	data, err = json.Marshal(OmitEmpty(p))
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"id":1,"reviewer":"ann"}`, "OmitEmpty() embedded struct")
+}
+
+func TestOmitEmptyNotStruct(t *testing.T) {
+	if m := OmitEmpty(StringFrom("test").String); m != nil {
+		t.Errorf("OmitEmpty() of a string should be nil, got %v", m)
+	}
+	if m := OmitEmpty((*omitRecord)(nil)); m != nil {
+		t.Errorf("OmitEmpty() of a nil pointer should be nil, got %v", m)
+	}
+}
@@ -29,6 +29,13 @@ func StringFromPtr(s *string) String {
 	return NewString(*s, true, true)
 }
 
+// StringUnset creates a new String that is null and not Set, as for a field
+// absent from the input. IsSet reports false until a value or an explicit
+// null is unmarshaled into it, or it is changed with SetValid or SetNull.
+func StringUnset() String {
+	return String{}
+}
+
 // NewString creates a new String
 func NewString(s string, valid, set bool) String {
 	return String{
@@ -95,6 +102,14 @@ func (s *String) SetValid(v string) {
 	s.Set = true
 }
 
+// SetNull changes this String's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (s *String) SetNull() {
+	s.String = ""
+	s.Valid = false
+	s.Set = true
+}
+
 // Ptr returns a pointer to this String's value, or a nil pointer if this String is null.
 func (s String) Ptr() *string {
 	if !s.Valid {
@@ -108,8 +123,28 @@ func (s String) IsZero() bool {
 	return !s.Valid
 }
 
+// Or returns this String if it is valid, or other if it is not.
+func (s String) Or(other String) String {
+	if s.Valid {
+		return s
+	}
+	return other
+}
+
+// Get returns this String's value and true, or the zero value and false if it is null.
+func (s String) Get() (string, bool) {
+	if !s.Valid {
+		return "", false
+	}
+	return s.String, true
+}
+
 // Scan implements the Scanner interface.
 func (s *String) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
 	if value == nil {
 		s.String, s.Valid, s.Set = "", false, false
 		return nil
@@ -2,7 +2,11 @@ package null
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
+	"time"
+
+	"github.com/volatiletech/null/v9/convert"
 )
 
 var (
@@ -37,6 +41,14 @@ func TestStringFromPtr(t *testing.T) {
 	assertNullStr(t, null, "StringFromPtr(nil)")
 }
 
+func TestStringUnset(t *testing.T) {
+	v := StringUnset()
+	assertNullStr(t, v, "StringUnset()")
+	if v.IsSet() {
+		t.Error("StringUnset() should not be Set")
+	}
+}
+
 func TestUnmarshalString(t *testing.T) {
 	var str String
 	err := json.Unmarshal(stringJSON, &str)
@@ -163,6 +175,19 @@ func TestStringSetValid(t *testing.T) {
 	assertStr(t, change, "SetValid()")
 }
 
+func TestStringSetNull(t *testing.T) {
+	change := NewString("", false, true)
+	change.SetValid("test")
+	change.SetNull()
+	assertNullStr(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.String != "" {
+		t.Errorf("SetNull() should zero the value, got %v", change.String)
+	}
+}
+
 func TestStringScan(t *testing.T) {
 	var str String
 	err := str.Scan("test")
@@ -175,12 +200,92 @@ func TestStringScan(t *testing.T) {
 	assertNullStr(t, null, "scanned null")
 }
 
+// driverText stands in for a proprietary type returned by a custom driver.
+type driverText struct {
+	text string
+}
+
+func TestStringScanRegisteredConverter(t *testing.T) {
+	convert.RegisterConverter(func(dest, src interface{}) (bool, error) {
+		v, ok := src.(driverText)
+		if !ok {
+			return false, nil
+		}
+		d, ok := dest.(*string)
+		if !ok {
+			return true, fmt.Errorf("cannot convert driverText into %T", dest)
+		}
+		*d = v.text
+		return true, nil
+	})
+
+	var str String
+	err := str.Scan(driverText{"test"})
+	maybePanic(err)
+	assertStr(t, str, "scanned driverText")
+
+	var i Int
+	if err := i.Scan(driverText{"1"}); err == nil {
+		t.Error("expected the registered converter's error")
+	}
+}
+
+// stringerValue is a driver value that is only usable through fmt.Stringer.
+type stringerValue struct{ id int }
+
+func (v stringerValue) String() string { return fmt.Sprintf("id-%d", v.id) }
+
+func TestStringScanStringer(t *testing.T) {
+	var str String
+	err := str.Scan(stringerValue{7})
+	maybePanic(err)
+	if str.String != "id-7" || !str.Valid {
+		t.Errorf("bad scanned fmt.Stringer: %#v", str)
+	}
+
+	err = str.Scan(1500 * time.Millisecond)
+	maybePanic(err)
+	if str.String != "1500000000" || !str.Valid {
+		t.Errorf("a time.Duration should scan as its integer text: %#v", str)
+	}
+
+	var i Int
+	err = i.Scan(time.Duration(12345))
+	maybePanic(err)
+	assertInt(t, i, "scanned time.Duration")
+}
+
 func maybePanic(err error) {
 	if err != nil {
 		panic(err)
 	}
 }
 
+func TestStringOr(t *testing.T) {
+	a := NewString("a", true, true)
+	b := NewString("b", true, true)
+	null := NewString("", false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestStringGet(t *testing.T) {
+	if v, ok := StringFrom("test").Get(); !ok || v != "test" {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null String
+	if v, ok := null.Get(); ok || v != "" {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
 func assertStr(t *testing.T, s String, from string) {
 	if s.String != "test" {
 		t.Errorf("bad %s string: %s ≠ %s\n", from, s.String, "test")
@@ -2,18 +2,67 @@ package null
 
 import (
 	"bytes"
+	"database/sql"
 	"database/sql/driver"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"strconv"
 	"time"
 
+	"github.com/volatiletech/null/v9/convert"
 	"github.com/volatiletech/randomize"
 )
 
+// DefaultLocation, when non-nil, is the location Time.Scan converts every
+// scanned time to, e.g. time.UTC to normalize times a driver returns in the
+// server's local zone. The instant is unchanged. Value is not affected and
+// returns the time as stored.
+var DefaultLocation *time.Location
+
+// UnixPrecision is the unit of an integer Unix timestamp, see ScanUnixPrecision.
+type UnixPrecision int
+
+const (
+	// UnixSeconds reads integers as seconds since the epoch.
+	UnixSeconds UnixPrecision = iota
+	// UnixMillis reads integers as milliseconds since the epoch.
+	UnixMillis
+	// UnixMicros reads integers as microseconds since the epoch.
+	UnixMicros
+)
+
+// ScanUnixPrecision is the unit Time.Scan reads an int64 driver value in.
+// A single integer does not say which it is, so it defaults to UnixSeconds;
+// set it to UnixMillis for columns written by JavaScript's Date.now(), for
+// example. Floats are always read as seconds.
+var ScanUnixPrecision = UnixSeconds
+
+// timeValidators are the checks added with RegisterTimeValidator.
+var timeValidators []func(time.Time) error
+
+// RegisterTimeValidator adds fn to the checks Time.UnmarshalJSON runs, in
+// registration order, on every time it parses; the first error is returned
+// from UnmarshalJSON. Scan and UnmarshalText do not consult them.
+//
+// RegisterTimeValidator is not safe for concurrent use with itself or with
+// UnmarshalJSON; call it during initialization, e.g. from an init func.
+func RegisterTimeValidator(fn func(time.Time) error) {
+	timeValidators = append(timeValidators, fn)
+}
+
 // Time is a nullable time.Time. It supports SQL and JSON serialization.
+// Its unexported layout field means it must be built with keyed fields or a
+// constructor, and two Times with different layouts are never ==.
 type Time struct {
 	Time  time.Time
 	Valid bool
 	Set   bool
+
+	// layout overrides RFC 3339 for JSON and text (un)marshaling, see NewTimeWithLayout.
+	layout string
 }
 
 // NewTime creates a new Time.
@@ -25,6 +74,16 @@ func NewTime(t time.Time, valid, set bool) Time {
 	}
 }
 
+// NewTimeWithLayout creates a new Time that marshals to and unmarshals from
+// JSON and text using layout instead of RFC 3339. Null values still marshal
+// to null. The layout is kept when the Time is modified in place, so a
+// value created this way can be used as an unmarshal target.
+func NewTimeWithLayout(t time.Time, valid, set bool, layout string) Time {
+	n := NewTime(t, valid, set)
+	n.layout = layout
+	return n
+}
+
 // TimeFrom creates a new Time that will always be valid.
 func TimeFrom(t time.Time) Time {
 	return NewTime(t, true, true)
@@ -38,6 +97,59 @@ func TimeFromPtr(t *time.Time) Time {
 	return NewTime(*t, true, true)
 }
 
+// TimeUnset creates a new Time that is null and not Set, as for a field
+// absent from the input. IsSet reports false until a value or an explicit
+// null is unmarshaled into it, or it is changed with SetValid or SetNull.
+func TimeUnset() Time {
+	return Time{}
+}
+
+// TimeFromUnixMilli creates a new valid Time, in UTC, ms milliseconds after
+// the Unix epoch.
+func TimeFromUnixMilli(ms int64) Time {
+	return TimeFrom(time.UnixMilli(ms).UTC())
+}
+
+// TimeFromUnixMicro creates a new valid Time, in UTC, us microseconds after
+// the Unix epoch.
+func TimeFromUnixMicro(us int64) Time {
+	return TimeFrom(time.UnixMicro(us).UTC())
+}
+
+// MustTime creates a new valid Time by parsing s as RFC 3339, panicking if
+// s does not parse. It is meant for tests and package-level fixtures, where
+// the input is known to be good; use time.Parse for anything read at runtime.
+func MustTime(s string) Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return TimeFrom(t)
+}
+
+// ParseTime creates a new Time by parsing value with layout, as
+// time.ParseInLocation does in loc, or in UTC if loc is nil. An empty value
+// gives a null Time rather than an error.
+func ParseTime(layout, value string, loc *time.Location) (Time, error) {
+	if value == "" {
+		return NewTime(time.Time{}, false, true), nil
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	t, err := time.ParseInLocation(layout, value, loc)
+	if err != nil {
+		return Time{}, err
+	}
+	return TimeFrom(t), nil
+}
+
+// TimeFromSQL creates a new Time from a sql.NullTime. sql.NullTime has no
+// notion of Set, so the result is always Set.
+func TimeFromSQL(n sql.NullTime) Time {
+	return NewTime(n.Time, n.Valid, true)
+}
+
 func (t Time) IsSet() bool {
 	return t.Set
 }
@@ -47,10 +159,64 @@ func (t Time) MarshalJSON() ([]byte, error) {
 	if !t.Valid {
 		return NullBytes, nil
 	}
-	return t.Time.MarshalJSON()
+	return t.AppendJSON(make([]byte, 0, len(time.RFC3339Nano)+2))
+}
+
+// AppendJSON appends the JSON encoding of t to dst and returns the extended
+// buffer, for encoders that marshal into a reused buffer. The output is the
+// same as MarshalJSON's. On error dst is returned unchanged.
+func (t Time) AppendJSON(dst []byte) ([]byte, error) {
+	if !t.Valid {
+		return append(dst, NullBytes...), nil
+	}
+	if t.layout != "" {
+		// Custom layouts may contain characters that need escaping.
+		b, err := json.Marshal(t.Time.Format(t.layout))
+		if err != nil {
+			return dst, err
+		}
+		return append(dst, b...), nil
+	}
+	if y := t.Time.Year(); y < 0 || y >= 10000 {
+		return dst, errors.New("null: Time.MarshalJSON: year outside of range [0,9999]")
+	}
+	dst = append(dst, '"')
+	dst = t.Time.AppendFormat(dst, time.RFC3339Nano)
+	return append(dst, '"'), nil
+}
+
+// MarshalTimeSlice encodes ts as a JSON array into a single buffer, with
+// null for the null elements. The output is the same as json.Marshal(ts),
+// but without an allocation per element.
+func MarshalTimeSlice(ts []Time) ([]byte, error) {
+	if ts == nil {
+		return append([]byte{}, NullBytes...), nil
+	}
+	buf := make([]byte, 0, 2+len(ts)*(len(time.RFC3339Nano)+3))
+	buf = append(buf, '[')
+	for i, t := range ts {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		var err error
+		if buf, err = t.AppendJSON(buf); err != nil {
+			return nil, err
+		}
+	}
+	return append(buf, ']'), nil
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
+// null, and an empty string for a Time with a layout, are read as a null
+// Time. Otherwise, a Time with a layout parses a JSON string with it.
+// Without one, an RFC 3339 string is tried first, and then Unix seconds in
+// UTC: an integer, or a number with a fraction, either bare or quoted as in
+// "1700000000".
+//
+// A parsed time is then passed to the validators added with
+// RegisterTimeValidator. Time and Valid are only changed once they all
+// accept it; if one fails, its error is returned and they are left as they
+// were. Set is true either way, and a null is never validated.
 func (t *Time) UnmarshalJSON(data []byte) error {
 	t.Set = true
 	if bytes.Equal(data, NullBytes) {
@@ -59,19 +225,77 @@ func (t *Time) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
-	if err := t.Time.UnmarshalJSON(data); err != nil {
-		return err
+	var v time.Time
+	if t.layout != "" {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		if s == "" {
+			t.Valid, t.Time = false, time.Time{}
+			return nil
+		}
+		var err error
+		if v, err = time.Parse(t.layout, s); err != nil {
+			return err
+		}
+	} else if err := v.UnmarshalJSON(data); err != nil {
+		var ok bool
+		if v, ok = parseUnixJSON(data); !ok {
+			return err
+		}
 	}
 
-	t.Valid = true
+	for _, fn := range timeValidators {
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+
+	t.Time, t.Valid = v, true
 	return nil
 }
 
+// parseUnixJSON reads a JSON number, or a JSON string holding one, as Unix
+// seconds.
+func parseUnixJSON(data []byte) (time.Time, bool) {
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+	}
+	if len(data) == 0 || data[0] != '-' && (data[0] < '0' || data[0] > '9') || !json.Valid(data) {
+		return time.Time{}, false
+	}
+	if sec, err := strconv.ParseInt(string(data), 10, 64); err == nil {
+		return time.Unix(sec, 0).UTC(), true
+	}
+	f, err := strconv.ParseFloat(string(data), 64)
+	if err != nil || !unixFloatInRange(f) {
+		return time.Time{}, false
+	}
+	return unixFloat(f), true
+}
+
+// unixFloatInRange reports whether f is a number of Unix seconds that
+// unixFloat can convert, which NaN, ±Inf and values beyond int64 are not.
+func unixFloatInRange(f float64) bool {
+	return !math.IsNaN(f) && f < math.MaxInt64 && f > math.MinInt64
+}
+
+// unixFloat returns the UTC time f Unix seconds after the epoch, rounded to
+// the nanosecond.
+func unixFloat(f float64) time.Time {
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(math.Round(frac*1e9))).UTC()
+}
+
 // MarshalText implements encoding.TextMarshaler.
 func (t Time) MarshalText() ([]byte, error) {
 	if !t.Valid {
 		return NullBytes, nil
 	}
+	if t.layout != "" {
+		return []byte(t.Time.Format(t.layout)), nil
+	}
 	return t.Time.MarshalText()
 }
 
@@ -82,6 +306,14 @@ func (t *Time) UnmarshalText(text []byte) error {
 		t.Valid = false
 		return nil
 	}
+	if t.layout != "" {
+		v, err := time.Parse(t.layout, string(text))
+		if err != nil {
+			return err
+		}
+		t.Time, t.Valid = v, true
+		return nil
+	}
 	if err := t.Time.UnmarshalText(text); err != nil {
 		return err
 	}
@@ -89,6 +321,57 @@ func (t *Time) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// MarshalBinary implements encoding.BinaryMarshaler, so that encoding/gob
+// keeps Valid, Set and any custom layout. The layout is written even for a
+// null Time; the time itself is only stored for a valid one, with
+// time.Time's own binary encoding.
+func (t Time) MarshalBinary() ([]byte, error) {
+	data := binaryHeader(t.Valid, t.Set)
+	if t.layout != "" {
+		data[1] |= binaryFlagLayout
+		var buf [binary.MaxVarintLen64]byte
+		data = append(data, buf[:binary.PutUvarint(buf[:], uint64(len(t.layout)))]...)
+		data = append(data, t.layout...)
+	}
+	if !t.Valid {
+		return data, nil
+	}
+	b, err := t.Time.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return append(data, b...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (t *Time) UnmarshalBinary(data []byte) error {
+	flags, rest, err := readBinaryHeader(data, "Time")
+	if err != nil {
+		return err
+	}
+
+	var v Time
+	if flags&binaryFlagLayout != 0 {
+		n, size := binary.Uvarint(rest)
+		if size <= 0 || uint64(len(rest)-size) < n {
+			return errors.New("null: Time.UnmarshalBinary: invalid layout length")
+		}
+		v.layout = string(rest[size : size+int(n)])
+		rest = rest[size+int(n):]
+	}
+	if flags&binaryFlagValid != 0 {
+		if err := v.Time.UnmarshalBinary(rest); err != nil {
+			return err
+		}
+		v.Valid = true
+	} else if len(rest) != 0 {
+		return errors.New("null: Time.UnmarshalBinary: unexpected data after null value")
+	}
+	v.Set = flags&binaryFlagSet != 0
+	*t = v
+	return nil
+}
+
 // SetValid changes this Time's value and sets it to be non-null.
 func (t *Time) SetValid(v time.Time) {
 	t.Time = v
@@ -96,6 +379,20 @@ func (t *Time) SetValid(v time.Time) {
 	t.Set = true
 }
 
+// SetNull changes this Time's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (t *Time) SetNull() {
+	t.Time = time.Time{}
+	t.Valid = false
+	t.Set = true
+}
+
+// ToSQL converts this Time to a sql.NullTime. The Set flag is dropped,
+// so an unset Time converts the same way as a null one.
+func (t Time) ToSQL() sql.NullTime {
+	return sql.NullTime{Time: t.Time, Valid: t.Valid}
+}
+
 // Ptr returns a pointer to this Time's value, or a nil pointer if this Time is null.
 func (t Time) Ptr() *time.Time {
 	if !t.Valid {
@@ -104,17 +401,148 @@ func (t Time) Ptr() *time.Time {
 	return &t.Time
 }
 
+// ValueOrZero returns the inner value if valid, otherwise zero.
+func (t Time) ValueOrZero() time.Time {
+	if !t.Valid {
+		return time.Time{}
+	}
+	return t.Time
+}
+
 // IsZero returns true for an invalid Time's value, for potential future omitempty support.
 func (t Time) IsZero() bool {
 	return !t.Valid
 }
 
+// Or returns this Time if it is valid, or other if it is not.
+func (t Time) Or(other Time) Time {
+	if t.Valid {
+		return t
+	}
+	return other
+}
+
+// IfValid calls fn with this Time's value if it is valid, and does nothing otherwise.
+func (t Time) IfValid(fn func(time.Time)) {
+	if t.Valid {
+		fn(t.Time)
+	}
+}
+
+// CoalesceTime returns the first valid Time in vals,
+// or a zero, invalid Time if none of them are valid.
+func CoalesceTime(vals ...Time) Time {
+	for _, v := range vals {
+		if v.Valid {
+			return v
+		}
+	}
+	return Time{}
+}
+
+// CompareTime returns -1, 0 or 1 as a is before, equal to, or after b, for
+// sorting with slices.SortFunc. Nulls sort before valid Times if nullsFirst
+// is true, and after them otherwise; two nulls are equal.
+func CompareTime(a, b Time, nullsFirst bool) int {
+	if c, ok := compareNulls(a.Valid, b.Valid, nullsFirst); ok {
+		return c
+	}
+	switch {
+	case a.Time.Before(b.Time):
+		return -1
+	case a.Time.After(b.Time):
+		return 1
+	}
+	return 0
+}
+
+// Get returns this Time's value and true, or the zero value and false if it is null.
+func (t Time) Get() (time.Time, bool) {
+	if !t.Valid {
+		return time.Time{}, false
+	}
+	return t.Time, true
+}
+
+// Equal returns true if both Times are null, or if both are valid and
+// represent the same instant. Like time.Time.Equal it ignores location and
+// monotonic clock readings, so prefer it over ==.
+func (t Time) Equal(other Time) bool {
+	if !t.Valid || !other.Valid {
+		return t.Valid == other.Valid
+	}
+	return t.Time.Equal(other.Time)
+}
+
+// After reports whether this Time is valid and after u.
+func (t Time) After(u time.Time) bool {
+	return t.Valid && t.Time.After(u)
+}
+
+// Before reports whether this Time is valid and before u.
+func (t Time) Before(u time.Time) bool {
+	return t.Valid && t.Time.Before(u)
+}
+
+// Add returns this Time plus d. A null Time is returned unchanged.
+func (t Time) Add(d time.Duration) Time {
+	if t.Valid {
+		t.Time = t.Time.Add(d)
+	}
+	return t
+}
+
+// Truncate returns this Time rounded down to a multiple of d, as
+// time.Time.Truncate does. A null Time is returned unchanged.
+func (t Time) Truncate(d time.Duration) Time {
+	if t.Valid {
+		t.Time = t.Time.Truncate(d)
+	}
+	return t
+}
+
+// Round returns this Time rounded to the nearest multiple of d, as
+// time.Time.Round does. A null Time is returned unchanged.
+func (t Time) Round(d time.Duration) Time {
+	if t.Valid {
+		t.Time = t.Time.Round(d)
+	}
+	return t
+}
+
+// In returns this Time with its value set to the same instant in loc, as
+// time.Time.In does, e.g. to show a stored UTC time in a user's zone. A null
+// Time is returned unchanged. Like time.Time.In, it panics if loc is nil.
+func (t Time) In(loc *time.Location) Time {
+	if t.Valid {
+		t.Time = t.Time.In(loc)
+	}
+	return t
+}
+
 // Scan implements the Scanner interface.
+// Strings and []byte are parsed with the layouts in TimeScanLayouts.
 func (t *Time) Scan(value interface{}) error {
-	var err error
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
 	switch x := value.(type) {
 	case time.Time:
 		t.Time = x
+	case int64:
+		// Only numeric driver values are read as Unix timestamps;
+		// numeric-looking strings are not reinterpreted here.
+		t.Time = unixInt(x, ScanUnixPrecision)
+	case float64:
+		if !unixFloatInRange(x) {
+			return fmt.Errorf("null: cannot scan %v into null.Time: out of range for Unix seconds", x)
+		}
+		t.Time = unixFloat(x)
+	case string:
+		t.Time, err = parseScanTime(x)
+	case []byte:
+		t.Time, err = parseScanTime(string(x))
 	case nil:
 		t.Valid, t.Set = false, false
 		return nil
@@ -122,11 +550,50 @@ func (t *Time) Scan(value interface{}) error {
 		err = fmt.Errorf("null: cannot scan type %T into null.Time: %v", value, value)
 	}
 	if err == nil {
+		if DefaultLocation != nil {
+			t.Time = t.Time.In(DefaultLocation)
+		}
 		t.Valid, t.Set = true, true
 	}
 	return err
 }
 
+// unixInt returns the UTC time x units of p after the Unix epoch.
+func unixInt(x int64, p UnixPrecision) time.Time {
+	switch p {
+	case UnixMillis:
+		return time.UnixMilli(x).UTC()
+	case UnixMicros:
+		return time.UnixMicro(x).UTC()
+	}
+	return time.Unix(x, 0).UTC()
+}
+
+// TimeScanLayouts are the layouts tried, in order, on a string or []byte
+// value by Time.Scan and Date.Scan, and by convert.ConvertAssign into a
+// time.Time. Parsing stops at the first layout that succeeds, and if none
+// does the error lists every layout tried. Applications with their own
+// format can prepend it during initialization, before any scans run; the
+// slice is read without locking.
+var TimeScanLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+func init() {
+	convert.TimeLayoutsFunc = func() []string { return TimeScanLayouts }
+}
+
+func parseScanTime(s string) (time.Time, error) {
+	for _, layout := range TimeScanLayouts {
+		if v, err := time.Parse(layout, s); err == nil {
+			return v, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("null: cannot parse %q into null.Time, tried layouts %q", s, TimeScanLayouts)
+}
+
 // Value implements the driver Valuer interface.
 func (t Time) Value() (driver.Value, error) {
 	if !t.Valid {
@@ -1,9 +1,20 @@
 package null
 
 import (
+	"bytes"
+	"database/sql"
+	"encoding/gob"
 	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
+	_ "time/tzdata"
+
+	"github.com/volatiletech/null/v9/convert"
 )
 
 var (
@@ -30,8 +41,8 @@ func TestUnmarshalTimeJSON(t *testing.T) {
 
 	var invalid Time
 	err = invalid.UnmarshalJSON(invalidJSON)
-	if _, ok := err.(*time.ParseError); !ok {
-		t.Errorf("expected json.ParseError, not %T", err)
+	if err == nil {
+		t.Error("expected error: invalid json")
 	}
 	assertNullTime(t, invalid, "invalid from object json")
 
@@ -43,13 +54,90 @@ func TestUnmarshalTimeJSON(t *testing.T) {
 	assertNullTime(t, bad, "bad from object json")
 
 	var wrongType Time
-	err = json.Unmarshal(intJSON, &wrongType)
+	err = json.Unmarshal(boolJSON, &wrongType)
 	if err == nil {
 		t.Errorf("expected error: wrong type JSON")
 	}
 	assertNullTime(t, wrongType, "wrong type object json")
 }
 
+func TestTimeValidator(t *testing.T) {
+	defer func(fns []func(time.Time) error) { timeValidators = fns }(timeValidators)
+
+	errBeforeEpoch := errors.New("time is before the Unix epoch")
+	RegisterTimeValidator(func(v time.Time) error {
+		if v.Before(time.Unix(0, 0)) {
+			return errBeforeEpoch
+		}
+		return nil
+	})
+
+	var ti Time
+	err := json.Unmarshal(timeJSON, &ti)
+	maybePanic(err)
+	assertTime(t, ti, "UnmarshalJSON() validated")
+
+	for _, in := range []string{`"1969-12-31T23:59:59Z"`, `-1`} {
+		if err := ti.UnmarshalJSON([]byte(in)); err != errBeforeEpoch {
+			t.Errorf("UnmarshalJSON(%s) should return the validator's error, got %v", in, err)
+		}
+		assertTime(t, ti, "UnmarshalJSON() rejected")
+	}
+
+	var s struct{ T Time }
+	if err := json.Unmarshal([]byte(`{"T":"1900-01-01T00:00:00Z"}`), &s); err != errBeforeEpoch {
+		t.Errorf("json.Unmarshal() should return the validator's error, got %v", err)
+	}
+
+	layout := NewTimeWithLayout(time.Time{}, false, false, "2006-01-02")
+	if err := layout.UnmarshalJSON([]byte(`"1969-07-20"`)); err != errBeforeEpoch {
+		t.Errorf("UnmarshalJSON() with a layout should validate, got %v", err)
+	}
+	assertNullTime(t, layout, "UnmarshalJSON() with a layout rejected")
+
+	var null Time
+	err = null.UnmarshalJSON(nullJSON)
+	maybePanic(err)
+	assertNullTime(t, null, "UnmarshalJSON() null")
+}
+
+func TestUnmarshalTimeJSONUnix(t *testing.T) {
+	unix := timeValue.Unix()
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{strconv.FormatInt(unix, 10), timeValue},
+		{`"` + strconv.FormatInt(unix, 10) + `"`, timeValue},
+		{strconv.FormatInt(unix, 10) + ".25", timeValue.Add(250 * time.Millisecond)},
+		{`"` + strconv.FormatInt(unix, 10) + `.5"`, timeValue.Add(500 * time.Millisecond)},
+		{"1.356124881e9", timeValue},
+		{"-1", time.Unix(-1, 0)},
+		{`"0"`, time.Unix(0, 0)},
+	}
+	for _, test := range tests {
+		var ti Time
+		err := json.Unmarshal([]byte(test.in), &ti)
+		maybePanic(err)
+		if !ti.Valid || !ti.Time.Equal(test.want) || ti.Time.Location() != time.UTC {
+			t.Errorf("UnmarshalJSON(%s) = %v (valid %v), want %v in UTC", test.in, ti.Time, ti.Valid, test.want)
+		}
+	}
+
+	for _, bad := range []string{`""`, `"+1"`, `"0x10"`, `"1e400"`, `"12 "`, `"NaN"`, `"2012-12-21"`, `1e400`} {
+		var ti Time
+		if err := ti.UnmarshalJSON([]byte(bad)); err == nil {
+			t.Errorf("UnmarshalJSON(%s) should fail, got %v", bad, ti.Time)
+		}
+		assertNullTime(t, ti, "bad unix json "+bad)
+	}
+
+	layout := NewTimeWithLayout(time.Time{}, false, false, "2006-01-02")
+	if err := layout.UnmarshalJSON([]byte(strconv.FormatInt(unix, 10))); err == nil {
+		t.Error("a Time with a layout should not read Unix seconds")
+	}
+}
+
 func TestUnmarshalTimeText(t *testing.T) {
 	ti := TimeFrom(timeValue)
 	txt, err := ti.MarshalText()
@@ -81,6 +169,69 @@ func TestMarshalTime(t *testing.T) {
 	assertJSONEquals(t, data, string(nullJSON), "null json marshal")
 }
 
+func TestTimeWithLayout(t *testing.T) {
+	const layout = "2006-01-02"
+	date := time.Date(2012, 12, 21, 0, 0, 0, 0, time.UTC)
+
+	ti := NewTimeWithLayout(date, true, true, layout)
+	data, err := json.Marshal(ti)
+	maybePanic(err)
+	assertJSONEquals(t, data, `"2012-12-21"`, "layout json marshal")
+
+	txt, err := ti.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, txt, "2012-12-21", "layout text marshal")
+
+	target := struct{ At Time }{NewTimeWithLayout(time.Time{}, false, false, layout)}
+	err = json.Unmarshal([]byte(`{"At":"2012-12-21"}`), &target)
+	maybePanic(err)
+	if !target.At.Valid || !target.At.Time.Equal(date) {
+		t.Errorf("bad layout json unmarshal: %v", target.At.Time)
+	}
+	data, err = json.Marshal(target)
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"At":"2012-12-21"}`, "layout json round-trip")
+
+	text := NewTimeWithLayout(time.Time{}, false, false, layout)
+	err = text.UnmarshalText([]byte("2012-12-21"))
+	maybePanic(err)
+	if !text.Valid || !text.Time.Equal(date) {
+		t.Errorf("bad layout text unmarshal: %v", text.Time)
+	}
+
+	bad := NewTimeWithLayout(time.Time{}, false, false, layout)
+	if err = json.Unmarshal(timeJSON, &bad); err == nil {
+		t.Error("expected error unmarshaling RFC 3339 with a date layout")
+	}
+	assertNullTime(t, bad, "layout bad json")
+
+	null := NewTimeWithLayout(time.Time{}, false, true, layout)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "layout null json marshal")
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullTime(t, null, "layout null json unmarshal")
+
+	reused := NewTimeWithLayout(date, true, false, layout)
+	err = json.Unmarshal([]byte(`""`), &reused)
+	maybePanic(err)
+	assertNullTime(t, reused, "layout empty string json unmarshal")
+	if !reused.Time.IsZero() {
+		t.Errorf("an empty string should clear the old time, got %v", reused.Time)
+	}
+	if !reused.Set {
+		t.Error("an empty string should be Set")
+	}
+
+	scanned := NewTimeWithLayout(time.Time{}, false, false, layout)
+	err = scanned.Scan(date)
+	maybePanic(err)
+	txt, err = scanned.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, txt, "2012-12-21", "layout kept by Scan()")
+}
+
 func TestTimeFrom(t *testing.T) {
 	ti := TimeFrom(timeValue)
 	assertTime(t, ti, "TimeFrom() time.Time")
@@ -94,6 +245,87 @@ func TestTimeFromPtr(t *testing.T) {
 	assertNullTime(t, null, "TimeFromPtr(nil)")
 }
 
+func TestTimeUnset(t *testing.T) {
+	v := TimeUnset()
+	assertNullTime(t, v, "TimeUnset()")
+	if v.IsSet() {
+		t.Error("TimeUnset() should not be Set")
+	}
+}
+
+func TestMustTime(t *testing.T) {
+	ti := MustTime(timeString)
+	assertTime(t, ti, "MustTime()")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustTime() should panic on a bad timestamp")
+		}
+	}()
+	MustTime("2012-12-21")
+}
+
+func TestParseTime(t *testing.T) {
+	ti, err := ParseTime(time.RFC3339, timeString, nil)
+	maybePanic(err)
+	assertTime(t, ti, "ParseTime()")
+
+	loc := time.FixedZone("UTC+9", 9*60*60)
+	ti, err = ParseTime("2006-01-02 15:04:05", "2012-12-22 06:21:21", loc)
+	maybePanic(err)
+	if !ti.Valid || !ti.Time.Equal(timeValue) {
+		t.Errorf("bad ParseTime() zoned time: %v ≠ %v", ti.Time, timeValue)
+	}
+	if ti.Time.Location() != loc {
+		t.Errorf("ParseTime() should parse in the given location, got %v", ti.Time.Location())
+	}
+
+	null, err := ParseTime(time.RFC3339, "", loc)
+	maybePanic(err)
+	assertNullTime(t, null, "ParseTime() empty")
+	if !null.Set {
+		t.Error("ParseTime() empty should be Set")
+	}
+
+	if _, err := ParseTime(time.RFC3339, "2012-12-21", nil); err == nil {
+		t.Error("ParseTime() should fail on a bad format")
+	}
+}
+
+func TestNewTimeSet(t *testing.T) {
+	for _, set := range []bool{true, false} {
+		ti := NewTime(timeValue, true, set)
+		if ti.Set != set || ti.IsSet() != set {
+			t.Errorf("NewTime(..., %v) should populate Set, got %v", set, ti.Set)
+		}
+	}
+
+	lit := Time{Time: timeValue, Valid: true, Set: true}
+	if lit != NewTime(timeValue, true, true) {
+		t.Error("struct literal should match NewTime()")
+	}
+}
+
+func TestTimeSQL(t *testing.T) {
+	ti := TimeFromSQL(sql.NullTime{Time: timeValue, Valid: true})
+	assertTime(t, ti, "TimeFromSQL()")
+	if !ti.Set {
+		t.Error("TimeFromSQL() should be Set")
+	}
+	if n := ti.ToSQL(); !n.Valid || n.Time != timeValue {
+		t.Errorf("bad ToSQL(): %#v", n)
+	}
+
+	null := TimeFromSQL(sql.NullTime{})
+	assertNullTime(t, null, "TimeFromSQL() null")
+	if !null.Set {
+		t.Error("TimeFromSQL() should be Set")
+	}
+	if n := (Time{}).ToSQL(); n.Valid {
+		t.Errorf("bad ToSQL() null: %#v", n)
+	}
+}
+
 func TestTimeSetValid(t *testing.T) {
 	var ti time.Time
 	change := NewTime(ti, false, true)
@@ -102,6 +334,19 @@ func TestTimeSetValid(t *testing.T) {
 	assertTime(t, change, "SetValid()")
 }
 
+func TestTimeSetNull(t *testing.T) {
+	change := NewTime(time.Time{}, false, true)
+	change.SetValid(timeValue)
+	change.SetNull()
+	assertNullTime(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if !change.Time.IsZero() {
+		t.Errorf("SetNull() should zero the value, got %v", change.Time)
+	}
+}
+
 func TestTimePointer(t *testing.T) {
 	ti := TimeFrom(timeValue)
 	ptr := ti.Ptr()
@@ -117,6 +362,18 @@ func TestTimePointer(t *testing.T) {
 	}
 }
 
+func TestTimeValueOrZero(t *testing.T) {
+	valid := TimeFrom(timeValue)
+	if valid.ValueOrZero() != timeValue {
+		t.Error("unexpected ValueOrZero", valid.ValueOrZero())
+	}
+
+	invalid := NewTime(timeValue, false, true)
+	if !invalid.ValueOrZero().IsZero() {
+		t.Error("unexpected ValueOrZero", invalid.ValueOrZero())
+	}
+}
+
 func TestTimeIsZero(t *testing.T) {
 	ti := TimeFrom(time.Now())
 	if ti.IsZero() {
@@ -129,6 +386,134 @@ func TestTimeIsZero(t *testing.T) {
 	}
 }
 
+func TestTimeEqual(t *testing.T) {
+	now := time.Now()
+	stripped := now.Round(0)
+	if now == stripped {
+		t.Fatal("expected time.Now() to carry a monotonic reading")
+	}
+	if !TimeFrom(now).Equal(TimeFrom(stripped)) {
+		t.Error("Equal() should ignore the monotonic clock")
+	}
+
+	ny, err := time.LoadLocation("America/New_York")
+	maybePanic(err)
+	if !TimeFrom(timeValue).Equal(TimeFrom(timeValue.In(ny))) {
+		t.Error("Equal() should ignore the location")
+	}
+
+	if TimeFrom(timeValue).Equal(TimeFrom(timeValue.Add(time.Nanosecond))) {
+		t.Error("Equal() should compare instants")
+	}
+
+	null := NewTime(timeValue, false, true)
+	if !null.Equal(TimeFromPtr(nil)) {
+		t.Error("two null Times should be equal")
+	}
+	if null.Equal(TimeFrom(timeValue)) || TimeFrom(timeValue).Equal(null) {
+		t.Error("valid and null Times should not be equal")
+	}
+}
+
+func TestTimeBeforeAfter(t *testing.T) {
+	ti := TimeFrom(timeValue)
+	earlier, later := timeValue.Add(-time.Hour), timeValue.Add(time.Hour)
+
+	if !ti.After(earlier) || ti.After(later) || ti.After(timeValue) {
+		t.Error("bad After()")
+	}
+	if !ti.Before(later) || ti.Before(earlier) || ti.Before(timeValue) {
+		t.Error("bad Before()")
+	}
+
+	null := NewTime(timeValue, false, true)
+	if null.After(earlier) || null.After(later) {
+		t.Error("After() should be false for a null Time")
+	}
+	if null.Before(earlier) || null.Before(later) {
+		t.Error("Before() should be false for a null Time")
+	}
+}
+
+func TestTimeAdd(t *testing.T) {
+	ti := TimeFrom(timeValue).Add(-time.Hour)
+	if !ti.Time.Equal(timeValue.Add(-time.Hour)) || !ti.Valid || !ti.Set {
+		t.Errorf("bad Add(): %#v", ti)
+	}
+
+	null := NewTime(timeValue, false, true).Add(time.Hour)
+	assertNullTime(t, null, "Add() null")
+	if null.Time != timeValue || !null.Set {
+		t.Errorf("Add() should return a null Time unchanged: %#v", null)
+	}
+}
+
+func TestTimeTruncateRound(t *testing.T) {
+	ti := TimeFrom(timeValue.Add(29*time.Minute + 30*time.Second))
+	if got := ti.Truncate(time.Hour); !got.Time.Equal(time.Date(2012, 12, 21, 21, 0, 0, 0, time.UTC)) || !got.Valid || !got.Set {
+		t.Errorf("bad Truncate(): %#v", got)
+	}
+	if got := ti.Round(time.Hour); !got.Time.Equal(time.Date(2012, 12, 21, 22, 0, 0, 0, time.UTC)) || !got.Valid || !got.Set {
+		t.Errorf("bad Round(): %#v", got)
+	}
+
+	// Like time.Time, truncation works on absolute time, so truncating to a
+	// day in New York crosses back over the DST change to the UTC midnight.
+	ny, err := time.LoadLocation("America/New_York")
+	maybePanic(err)
+	dst := TimeFrom(time.Date(2021, 3, 14, 3, 30, 0, 0, ny))
+	if got := dst.Truncate(time.Hour).Time; !got.Equal(time.Date(2021, 3, 14, 3, 0, 0, 0, ny)) || got.Location() != ny {
+		t.Errorf("bad Truncate() across DST: %v", got)
+	}
+	if got := dst.Truncate(24 * time.Hour).Time; !got.Equal(time.Date(2021, 3, 13, 19, 0, 0, 0, ny)) {
+		t.Errorf("bad Truncate() to a day across DST: %v", got)
+	}
+	if got := dst.Round(2 * time.Hour).Time; !got.Equal(time.Date(2021, 3, 14, 4, 0, 0, 0, ny)) {
+		t.Errorf("bad Round() across DST: %v", got)
+	}
+
+	null := NewTime(timeValue.Add(time.Second), false, true)
+	for _, got := range []Time{null.Truncate(time.Hour), null.Round(time.Hour)} {
+		assertNullTime(t, got, "Truncate() or Round() null")
+		if got != null {
+			t.Errorf("Truncate() and Round() should return a null Time unchanged: %#v", got)
+		}
+	}
+}
+
+func TestTimeIn(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	maybePanic(err)
+
+	ti := TimeFrom(timeValue).In(tokyo)
+	if !ti.Valid || !ti.Set || !ti.Time.Equal(timeValue) {
+		t.Errorf("In() should keep the instant: %#v", ti)
+	}
+	if y, m, d := ti.Time.Date(); y != 2012 || m != 12 || d != 22 || ti.Time.Hour() != 6 || ti.Time.Minute() != 21 {
+		t.Errorf("bad In() wall clock: %v", ti.Time)
+	}
+	if name, offset := ti.Time.Zone(); name != "JST" || offset != 9*60*60 || ti.Time.Location() != tokyo {
+		t.Errorf("bad In() zone: %s %d", name, offset)
+	}
+
+	layout := NewTimeWithLayout(timeValue, true, true, "2006-01-02 15:04 MST").In(tokyo)
+	data, err := json.Marshal(layout)
+	maybePanic(err)
+	assertJSONEquals(t, data, `"2012-12-22 06:21 JST"`, "In() with layout")
+
+	null := NewTime(timeValue, false, true)
+	for _, loc := range []*time.Location{tokyo, nil} {
+		got := null.In(loc)
+		assertNullTime(t, got, "In() null")
+		if got != null {
+			t.Errorf("In() should return a null Time unchanged: %#v", got)
+		}
+	}
+	if got := TimeUnset().In(tokyo); got.Set {
+		t.Error("In() should keep an unset Time unset")
+	}
+}
+
 func TestTimeScanValue(t *testing.T) {
 	var ti Time
 	err := ti.Scan(timeValue)
@@ -147,13 +532,467 @@ func TestTimeScanValue(t *testing.T) {
 	}
 
 	var wrong Time
-	err = wrong.Scan(int64(42))
+	err = wrong.Scan(true)
 	if err == nil {
 		t.Error("expected error")
 	}
 	assertNullTime(t, wrong, "scanned wrong")
 }
 
+func TestTimeScanValuer(t *testing.T) {
+	var ti Time
+	err := ti.Scan(sql.NullTime{Time: timeValue, Valid: true})
+	maybePanic(err)
+	assertTime(t, ti, "scanned sql.NullTime")
+
+	var other Time
+	err = other.Scan(TimeFrom(timeValue))
+	maybePanic(err)
+	assertTime(t, other, "scanned Time")
+
+	var null Time
+	err = null.Scan(sql.NullTime{})
+	maybePanic(err)
+	assertNullTime(t, null, "scanned null sql.NullTime")
+
+	var inner interface{} = timeValue
+	var outer interface{} = &inner
+	var wrapped Time
+	err = wrapped.Scan(&outer)
+	maybePanic(err)
+	assertTime(t, wrapped, "scanned **interface{}")
+}
+
+func TestTimeScanUnix(t *testing.T) {
+	tests := []struct {
+		in   interface{}
+		want time.Time
+	}{
+		{int64(0), time.Unix(0, 0)},
+		{int64(timeValue.Unix()), timeValue},
+		{int64(-86400), time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{float64(0), time.Unix(0, 0)},
+		{float64(1.5), time.Unix(1, 500000000)},
+		{float64(-1.25), time.Unix(-2, 750000000)},
+	}
+
+	for _, test := range tests {
+		var ti Time
+		err := ti.Scan(test.in)
+		maybePanic(err)
+		if !ti.Time.Equal(test.want) || ti.Time.Location() != time.UTC {
+			t.Errorf("Scan(%v): expected %v, got %v", test.in, test.want.UTC(), ti.Time)
+		}
+		if !ti.Valid || !ti.Set {
+			t.Errorf("Scan(%v) should be valid and set", test.in)
+		}
+	}
+
+	var str Time
+	if err := str.Scan("1356124881"); err == nil {
+		t.Error("numeric strings should not be read as Unix seconds")
+	}
+
+	for _, in := range []float64{1e300, -1e300, math.MaxInt64, math.MinInt64, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		ti := TimeFrom(timeValue)
+		ti.Valid = false
+		if err := ti.Scan(in); err == nil {
+			t.Errorf("Scan(%v) should fail: out of range", in)
+		}
+		assertNullTime(t, ti, "scanned out of range float")
+		if !ti.Time.Equal(timeValue) {
+			t.Errorf("Scan(%v) should leave the time unchanged, got %v", in, ti.Time)
+		}
+	}
+}
+
+func TestTimeScanDefaultLocation(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	maybePanic(err)
+	local := time.Date(2012, 12, 21, 16, 21, 21, 0, ny)
+
+	var unchanged Time
+	err = unchanged.Scan(local)
+	maybePanic(err)
+	if unchanged.Time.Location() != ny {
+		t.Errorf("Scan() should keep the location by default, got %v", unchanged.Time.Location())
+	}
+
+	DefaultLocation = time.UTC
+	defer func() { DefaultLocation = nil }()
+
+	var ti Time
+	err = ti.Scan(local)
+	maybePanic(err)
+	assertTime(t, ti, "scanned with DefaultLocation")
+	if ti.Time.Location() != time.UTC {
+		t.Errorf("Scan() should normalize to UTC, got %v", ti.Time.Location())
+	}
+	if v, err := ti.Value(); v != timeValue || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	DefaultLocation = ny
+	var unix Time
+	err = unix.Scan(timeValue.Unix())
+	maybePanic(err)
+	if unix.Time != local {
+		t.Errorf("Scan() should convert to DefaultLocation, got %v", unix.Time)
+	}
+}
+
+func TestTimeFromUnixMilliMicro(t *testing.T) {
+	ms := TimeFromUnixMilli(timeValue.UnixMilli())
+	assertTime(t, ms, "TimeFromUnixMilli()")
+	us := TimeFromUnixMicro(timeValue.UnixMicro())
+	assertTime(t, us, "TimeFromUnixMicro()")
+
+	for _, zero := range []Time{TimeFromUnixMilli(0), TimeFromUnixMicro(0)} {
+		if !zero.Valid || !zero.Set || zero.Time != time.Unix(0, 0).UTC() {
+			t.Errorf("bad zero Unix time: %#v", zero)
+		}
+	}
+}
+
+func TestTimeScanUnixPrecision(t *testing.T) {
+	defer func() { ScanUnixPrecision = UnixSeconds }()
+
+	tests := []struct {
+		precision UnixPrecision
+		in        int64
+	}{
+		{UnixSeconds, timeValue.Unix()},
+		{UnixMillis, timeValue.UnixMilli()},
+		{UnixMicros, timeValue.UnixMicro()},
+	}
+	for _, test := range tests {
+		ScanUnixPrecision = test.precision
+		var ti Time
+		err := ti.Scan(test.in)
+		maybePanic(err)
+		assertTime(t, ti, "scanned Unix time")
+
+		var zero Time
+		err = zero.Scan(int64(0))
+		maybePanic(err)
+		if !zero.Valid || zero.Time != time.Unix(0, 0).UTC() {
+			t.Errorf("bad scanned zero Unix time at precision %d: %#v", test.precision, zero)
+		}
+	}
+
+	// Floats stay in seconds whatever the precision.
+	ScanUnixPrecision = UnixMillis
+	var f Time
+	err := f.Scan(float64(timeValue.Unix()))
+	maybePanic(err)
+	assertTime(t, f, "scanned float Unix time")
+}
+
+func TestTimeScanString(t *testing.T) {
+	tests := []struct {
+		in   interface{}
+		want time.Time
+	}{
+		{timeString, timeValue},
+		{[]byte(timeString), timeValue},
+		{"2012-12-21T16:21:21.5-05:00", timeValue.Add(500 * time.Millisecond)},
+		{"2012-12-21 21:21:21", timeValue},
+		{[]byte("2012-12-21 21:21:21.123"), timeValue.Add(123 * time.Millisecond)},
+		{"2012-12-21", time.Date(2012, 12, 21, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		var ti Time
+		err := ti.Scan(test.in)
+		maybePanic(err)
+		if !ti.Time.Equal(test.want) {
+			t.Errorf("Scan(%s): expected %v, got %v", test.in, test.want, ti.Time)
+		}
+		if !ti.Valid || !ti.Set {
+			t.Errorf("Scan(%s) should be valid and set", test.in)
+		}
+	}
+
+	var bad Time
+	err := bad.Scan("21/12/2012")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "2006-01-02 15:04:05") {
+		t.Errorf("error should list the layouts tried: %v", err)
+	}
+	assertNullTime(t, bad, "scanned bad string")
+}
+
+func TestTimeScanLayouts(t *testing.T) {
+	defer func(layouts []string) { TimeScanLayouts = layouts }(TimeScanLayouts)
+	TimeScanLayouts = append([]string{"02/01/2006"}, TimeScanLayouts...)
+
+	var ti Time
+	err := ti.Scan("21/12/2012")
+	maybePanic(err)
+	if want := time.Date(2012, 12, 21, 0, 0, 0, 0, time.UTC); !ti.Time.Equal(want) || !ti.Valid {
+		t.Errorf("Scan() with a custom layout: expected %v, got %v", want, ti.Time)
+	}
+
+	var d Date
+	err = d.Scan([]byte("21/12/2012"))
+	maybePanic(err)
+	assertDate(t, d, "Date.Scan() with a custom layout")
+
+	var v time.Time
+	err = convert.ConvertAssign(&v, "21/12/2012")
+	maybePanic(err)
+	if !v.Equal(ti.Time) {
+		t.Errorf("ConvertAssign() with a custom layout: expected %v, got %v", ti.Time, v)
+	}
+
+	err = ti.Scan([]byte(timeString))
+	maybePanic(err)
+	assertTime(t, ti, "Scan() with the default layouts")
+
+	var bad Time
+	err = bad.Scan("2012.12.21")
+	if want := `null: cannot parse "2012.12.21" into null.Time, tried layouts ["02/01/2006" "2006-01-02T15:04:05.999999999Z07:00" "2006-01-02 15:04:05.999999999" "2006-01-02"]`; errString(err) != want {
+		t.Errorf("got error %v, want %q", err, want)
+	}
+}
+
+func TestCompareTime(t *testing.T) {
+	a := TimeFrom(timeValue)
+	b := TimeFrom(timeValue.Add(time.Hour))
+	null := NewTime(timeValue, false, true)
+
+	tests := []struct {
+		a, b       Time
+		nullsFirst bool
+		want       int
+	}{
+		{a, b, false, -1},
+		{b, a, false, 1},
+		{a, TimeFrom(timeValue.In(time.FixedZone("UTC+1", 60*60))), false, 0},
+		{null, a, false, 1},
+		{a, null, false, -1},
+		{null, a, true, -1},
+		{a, null, true, 1},
+		{null, Time{}, false, 0},
+		{null, Time{}, true, 0},
+	}
+	for _, test := range tests {
+		if got := CompareTime(test.a, test.b, test.nullsFirst); got != test.want {
+			t.Errorf("CompareTime(%v, %v, %v): got %d, want %d", test.a, test.b, test.nullsFirst, got, test.want)
+		}
+	}
+
+	for _, nullsFirst := range []bool{false, true} {
+		ts := []Time{b, null, a, {}, b}
+		sort.SliceStable(ts, func(i, j int) bool { return CompareTime(ts[i], ts[j], nullsFirst) < 0 })
+		want := []Time{a, b, b, null, {}}
+		if nullsFirst {
+			want = []Time{null, {}, a, b, b}
+		}
+		for i := range ts {
+			if ts[i] != want[i] {
+				t.Errorf("sorted with nullsFirst=%v: got %v, want %v", nullsFirst, ts, want)
+				break
+			}
+		}
+	}
+}
+
+func TestTimeOr(t *testing.T) {
+	a := NewTime(timeValue, true, true)
+	b := NewTime(timeValue.Add(time.Hour), true, true)
+	null := NewTime(time.Time{}, false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestTimeIfValid(t *testing.T) {
+	var got time.Time
+	TimeFrom(timeValue).IfValid(func(v time.Time) { got = v })
+	if !got.Equal(timeValue) {
+		t.Errorf("IfValid() should be called with the value, got %v", got)
+	}
+	NewTime(timeValue, false, true).IfValid(func(time.Time) {
+		t.Error("IfValid() should not be called for a null Time")
+	})
+}
+
+func TestCoalesceTime(t *testing.T) {
+	null := NewTime(time.Time{}, false, true)
+	later := NewTime(timeValue.Add(time.Hour), true, true)
+	if got := CoalesceTime(null, TimeFrom(timeValue), later); !(got == TimeFrom(timeValue)) {
+		t.Errorf("CoalesceTime() should return the first valid value, got %v", got)
+	}
+	if got := CoalesceTime(null, null); got.Valid || got.Set {
+		t.Errorf("CoalesceTime() of nulls should be a zero Time, got %v", got)
+	}
+	if got := CoalesceTime(); got.Valid {
+		t.Error("CoalesceTime() with no values should be null")
+	}
+}
+
+func TestTimeGet(t *testing.T) {
+	if v, ok := TimeFrom(timeValue).Get(); !ok || v != timeValue {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null Time
+	if v, ok := null.Get(); ok || !v.IsZero() {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
+func TestTimeGob(t *testing.T) {
+	type row struct {
+		A, B, C, D, E Time
+	}
+	in := row{
+		A: TimeFrom(timeValue.In(time.FixedZone("X", 5*3600))),
+		B: NewTime(time.Time{}, false, true),
+		C: NewTime(time.Time{}, false, false),
+		D: NewTimeWithLayout(timeValue, true, true, time.Kitchen),
+		E: NewTimeWithLayout(time.Time{}, false, true, time.Kitchen),
+	}
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(in)
+	maybePanic(err)
+
+	var out row
+	err = gob.NewDecoder(&buf).Decode(&out)
+	maybePanic(err)
+	if !out.A.Valid || !out.A.Set || !out.A.Time.Equal(timeValue) {
+		t.Errorf("bad gob valid: %+v", out.A)
+	}
+	if _, off := out.A.Time.Zone(); off != 5*3600 {
+		t.Errorf("gob should keep the zone offset, got %d", off)
+	}
+	assertNullTime(t, out.B, "gob set but invalid")
+	if !out.B.Set {
+		t.Error("gob set but invalid should be Set")
+	}
+	assertNullTime(t, out.C, "gob unset")
+	if out.C.Set {
+		t.Error("gob unset should not be Set")
+	}
+	data, err := json.Marshal(out.D)
+	maybePanic(err)
+	assertJSONEquals(t, data, `"9:21PM"`, "gob layout")
+
+	// A null Time keeps its layout, so it can still be unmarshaled into.
+	assertNullTime(t, out.E, "gob null with layout")
+	err = json.Unmarshal([]byte(`"9:21PM"`), &out.E)
+	maybePanic(err)
+	if !out.E.Valid || out.E.Time.Hour() != 21 {
+		t.Errorf("gob null should keep the layout, got %+v", out.E)
+	}
+}
+
+func TestTimeUnmarshalBinaryErrors(t *testing.T) {
+	valid, err := TimeFrom(timeValue).MarshalBinary()
+	maybePanic(err)
+	for _, data := range [][]byte{
+		nil,
+		{binaryVersion},
+		{binaryVersion + 1, binaryFlagValid},
+		{binaryVersion, 0, 1},
+		{binaryVersion, binaryFlagLayout, 5, 'a'},
+		valid[:len(valid)-1],
+	} {
+		var ti Time
+		if err := ti.UnmarshalBinary(data); err == nil {
+			t.Errorf("UnmarshalBinary(%v) should fail", data)
+		}
+	}
+}
+
+func TestTimeAppendJSON(t *testing.T) {
+	for _, v := range []time.Time{
+		timeValue,
+		time.Date(2012, 12, 21, 21, 21, 21, 123456789, time.FixedZone("", -7*3600)),
+		time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		want, err := v.MarshalJSON()
+		maybePanic(err)
+		got, err := TimeFrom(v).AppendJSON([]byte("x:"))
+		maybePanic(err)
+		assertJSONEquals(t, got, "x:"+string(want), "AppendJSON()")
+
+		got, err = TimeFrom(v).MarshalJSON()
+		maybePanic(err)
+		assertJSONEquals(t, got, string(want), "MarshalJSON()")
+	}
+
+	got, err := NewTime(timeValue, false, true).AppendJSON([]byte("x:"))
+	maybePanic(err)
+	assertJSONEquals(t, got, "x:null", "AppendJSON() null")
+
+	got, err = NewTimeWithLayout(timeValue, true, true, `"15:04"`).AppendJSON(nil)
+	maybePanic(err)
+	assertJSONEquals(t, got, `"\"21:21\""`, "AppendJSON() layout")
+
+	dst := []byte("x:")
+	got, err = TimeFrom(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)).AppendJSON(dst)
+	if err == nil {
+		t.Error("expected error for a year outside of range")
+	}
+	assertJSONEquals(t, got, "x:", "AppendJSON() error")
+	if _, err = TimeFrom(time.Date(-1, 1, 1, 0, 0, 0, 0, time.UTC)).MarshalJSON(); err == nil {
+		t.Error("expected error for a negative year")
+	}
+}
+
+func TestMarshalTimeSlice(t *testing.T) {
+	ts := []Time{TimeFrom(timeValue), NewTime(timeValue, false, true), {}, TimeFrom(timeValue.Add(time.Nanosecond))}
+	data, err := MarshalTimeSlice(ts)
+	maybePanic(err)
+	want, err := json.Marshal(ts)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(want), "MarshalTimeSlice()")
+
+	for _, ts := range [][]Time{nil, {}} {
+		data, err := MarshalTimeSlice(ts)
+		maybePanic(err)
+		want, err := json.Marshal(ts)
+		maybePanic(err)
+		assertJSONEquals(t, data, string(want), "MarshalTimeSlice() empty")
+	}
+
+	_, err = MarshalTimeSlice([]Time{TimeFrom(timeValue), TimeFrom(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC))})
+	if err == nil {
+		t.Error("MarshalTimeSlice() should fail for a year outside [0,9999]")
+	}
+}
+
+func BenchmarkTimeMarshalJSON(b *testing.B) {
+	ti := TimeFrom(timeValue)
+	b.Run("MarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := ti.MarshalJSON(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("AppendJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]byte, 0, 64)
+		for i := 0; i < b.N; i++ {
+			var err error
+			if buf, err = ti.AppendJSON(buf[:0]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
 func assertTime(t *testing.T, ti Time, from string) {
 	if ti.Time != timeValue {
 		t.Errorf("bad %v time: %v ≠ %v\n", from, ti.Time, timeValue)
@@ -168,3 +1007,27 @@ func assertNullTime(t *testing.T, ti Time, from string) {
 		t.Error(from, "is valid, but should be invalid")
 	}
 }
+
+func BenchmarkMarshalTimeSlice(b *testing.B) {
+	ts := make([]Time, 1000)
+	for i := range ts {
+		if i%10 != 0 {
+			ts[i] = TimeFrom(timeValue.Add(time.Duration(i) * time.Second))
+		}
+	}
+
+	b.Run("json.Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, err := json.Marshal(ts)
+			maybePanic(err)
+		}
+	})
+	b.Run("MarshalTimeSlice", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, err := MarshalTimeSlice(ts)
+			maybePanic(err)
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"encoding/json"
+	"fmt"
 	"strconv"
 
 	"github.com/volatiletech/null/v9/convert"
@@ -38,6 +39,13 @@ func UintFromPtr(i *uint) Uint {
 	return NewUint(*i, true, true)
 }
 
+// UintUnset creates a new Uint that is null and not Set, as for a field
+// absent from the input. IsSet reports false until a value or an explicit
+// null is unmarshaled into it, or it is changed with SetValid or SetNull.
+func UintUnset() Uint {
+	return Uint{}
+}
+
 func (u Uint) IsSet() bool {
 	return u.Set
 }
@@ -100,6 +108,14 @@ func (u *Uint) SetValid(n uint) {
 	u.Set = true
 }
 
+// SetNull changes this Uint's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (u *Uint) SetNull() {
+	u.Uint = 0
+	u.Valid = false
+	u.Set = true
+}
+
 // Ptr returns a pointer to this Uint's value, or a nil pointer if this Uint is null.
 func (u Uint) Ptr() *uint {
 	if !u.Valid {
@@ -113,14 +129,38 @@ func (u Uint) IsZero() bool {
 	return !u.Valid
 }
 
+// Or returns this Uint if it is valid, or other if it is not.
+func (u Uint) Or(other Uint) Uint {
+	if u.Valid {
+		return u
+	}
+	return other
+}
+
+// Get returns this Uint's value and true, or the zero value and false if it is null.
+func (u Uint) Get() (uint, bool) {
+	if !u.Valid {
+		return 0, false
+	}
+	return u.Uint, true
+}
+
 // Scan implements the Scanner interface.
 func (u *Uint) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
 	if value == nil {
 		u.Uint, u.Valid, u.Set = 0, false, false
 		return nil
 	}
-	u.Valid, u.Set = true, true
-	return convert.ConvertAssign(&u.Uint, value)
+	v, err := scanUint(value, strconv.IntSize, "uint")
+	if err != nil {
+		return err
+	}
+	u.Uint, u.Valid, u.Set = uint(v), true, true
+	return nil
 }
 
 // Value implements the driver Valuer interface.
@@ -141,3 +181,15 @@ func (u *Uint) Randomize(nextInt func() int64, fieldType string, shouldBeNull bo
 		u.Valid = true
 	}
 }
+
+// scanUint is scanInt for unsigned integers: negative values overflow too.
+func scanUint(value interface{}, bits int, typ string) (uint64, error) {
+	var v uint64
+	if err := convert.ConvertAssign(&v, value); err != nil {
+		return 0, overflowError(value, typ, err)
+	}
+	if bits < 64 && v > 1<<bits-1 {
+		return 0, fmt.Errorf("null: value %d overflows %s", v, typ)
+	}
+	return v, nil
+}
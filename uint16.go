@@ -40,6 +40,13 @@ func Uint16FromPtr(i *uint16) Uint16 {
 	return NewUint16(*i, true, true)
 }
 
+// Uint16Unset creates a new Uint16 that is null and not Set, as for a field
+// absent from the input. IsSet reports false until a value or an explicit
+// null is unmarshaled into it, or it is changed with SetValid or SetNull.
+func Uint16Unset() Uint16 {
+	return Uint16{}
+}
+
 func (u Uint16) IsSet() bool {
 	return u.Set
 }
@@ -106,6 +113,14 @@ func (u *Uint16) SetValid(n uint16) {
 	u.Set = true
 }
 
+// SetNull changes this Uint16's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (u *Uint16) SetNull() {
+	u.Uint16 = 0
+	u.Valid = false
+	u.Set = true
+}
+
 // Ptr returns a pointer to this Uint16's value, or a nil pointer if this Uint16 is null.
 func (u Uint16) Ptr() *uint16 {
 	if !u.Valid {
@@ -119,14 +134,38 @@ func (u Uint16) IsZero() bool {
 	return !u.Valid
 }
 
+// Or returns this Uint16 if it is valid, or other if it is not.
+func (u Uint16) Or(other Uint16) Uint16 {
+	if u.Valid {
+		return u
+	}
+	return other
+}
+
+// Get returns this Uint16's value and true, or the zero value and false if it is null.
+func (u Uint16) Get() (uint16, bool) {
+	if !u.Valid {
+		return 0, false
+	}
+	return u.Uint16, true
+}
+
 // Scan implements the Scanner interface.
 func (u *Uint16) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
 	if value == nil {
 		u.Uint16, u.Valid, u.Set = 0, false, false
 		return nil
 	}
-	u.Valid, u.Set = true, true
-	return convert.ConvertAssign(&u.Uint16, value)
+	v, err := scanUint(value, 16, "uint16")
+	if err != nil {
+		return err
+	}
+	u.Uint16, u.Valid, u.Set = uint16(v), true, true
+	return nil
 }
 
 // Value implements the driver Valuer interface.
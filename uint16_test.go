@@ -31,6 +31,14 @@ func TestUint16FromPtr(t *testing.T) {
 	assertNullUint16(t, null, "Uint16FromPtr(nil)")
 }
 
+func TestUint16Unset(t *testing.T) {
+	v := Uint16Unset()
+	assertNullUint16(t, v, "Uint16Unset()")
+	if v.IsSet() {
+		t.Error("Uint16Unset() should not be Set")
+	}
+}
+
 func TestUnmarshalUint16(t *testing.T) {
 	var i Uint16
 	err := json.Unmarshal(uint16JSON, &i)
@@ -160,6 +168,55 @@ func TestUint16SetValid(t *testing.T) {
 	assertUint16(t, change, "SetValid()")
 }
 
+func TestUint16SetNull(t *testing.T) {
+	change := NewUint16(0, false, true)
+	change.SetValid(65534)
+	change.SetNull()
+	assertNullUint16(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.Uint16 != 0 {
+		t.Errorf("SetNull() should zero the value, got %v", change.Uint16)
+	}
+}
+
+func TestUint16ScanOverflow(t *testing.T) {
+	for _, test := range []struct {
+		in   interface{}
+		want uint16
+	}{
+		{int64(0), 0},
+		{"0", 0},
+		{int64(65535), uint16(math.MaxUint16)},
+		{"65535", uint16(math.MaxUint16)},
+		{[]byte("65535"), uint16(math.MaxUint16)},
+	} {
+		var v Uint16
+		err := v.Scan(test.in)
+		maybePanic(err)
+		if v.Uint16 != test.want || !v.Valid {
+			t.Errorf("Scan(%v): got %d, want %d", test.in, v.Uint16, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		in   interface{}
+		want string
+	}{
+		{int64(-1), "null: value -1 overflows uint16"},
+		{"-1", "null: value -1 overflows uint16"},
+		{int64(65536), "null: value 65536 overflows uint16"},
+		{"65536", "null: value 65536 overflows uint16"},
+	} {
+		var v Uint16
+		if err := v.Scan(test.in); errString(err) != test.want {
+			t.Errorf("Scan(%v): got error %v, want %q", test.in, err, test.want)
+		}
+		assertNullUint16(t, v, "scanned overflowing value")
+	}
+}
+
 func TestUint16Scan(t *testing.T) {
 	var i Uint16
 	err := i.Scan(65534)
@@ -172,6 +229,31 @@ func TestUint16Scan(t *testing.T) {
 	assertNullUint16(t, null, "scanned null")
 }
 
+func TestUint16Or(t *testing.T) {
+	a := NewUint16(1, true, true)
+	b := NewUint16(2, true, true)
+	null := NewUint16(0, false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestUint16Get(t *testing.T) {
+	if v, ok := Uint16From(12345).Get(); !ok || v != uint16(12345) {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null Uint16
+	if v, ok := null.Get(); ok || v != 0 {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
 func assertUint16(t *testing.T, i Uint16, from string) {
 	if i.Uint16 != 65534 {
 		t.Errorf("bad %s uint16: %d ≠ %d\n", from, i.Uint16, 65534)
@@ -40,6 +40,13 @@ func Uint32FromPtr(i *uint32) Uint32 {
 	return NewUint32(*i, true, true)
 }
 
+// Uint32Unset creates a new Uint32 that is null and not Set, as for a field
+// absent from the input. IsSet reports false until a value or an explicit
+// null is unmarshaled into it, or it is changed with SetValid or SetNull.
+func Uint32Unset() Uint32 {
+	return Uint32{}
+}
+
 func (u Uint32) IsSet() bool {
 	return u.Set
 }
@@ -106,6 +113,14 @@ func (u *Uint32) SetValid(n uint32) {
 	u.Set = true
 }
 
+// SetNull changes this Uint32's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (u *Uint32) SetNull() {
+	u.Uint32 = 0
+	u.Valid = false
+	u.Set = true
+}
+
 // Ptr returns a pointer to this Uint32's value, or a nil pointer if this Uint32 is null.
 func (u Uint32) Ptr() *uint32 {
 	if !u.Valid {
@@ -119,14 +134,38 @@ func (u Uint32) IsZero() bool {
 	return !u.Valid
 }
 
+// Or returns this Uint32 if it is valid, or other if it is not.
+func (u Uint32) Or(other Uint32) Uint32 {
+	if u.Valid {
+		return u
+	}
+	return other
+}
+
+// Get returns this Uint32's value and true, or the zero value and false if it is null.
+func (u Uint32) Get() (uint32, bool) {
+	if !u.Valid {
+		return 0, false
+	}
+	return u.Uint32, true
+}
+
 // Scan implements the Scanner interface.
 func (u *Uint32) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
 	if value == nil {
 		u.Uint32, u.Valid, u.Set = 0, false, false
 		return nil
 	}
-	u.Valid, u.Set = true, true
-	return convert.ConvertAssign(&u.Uint32, value)
+	v, err := scanUint(value, 32, "uint32")
+	if err != nil {
+		return err
+	}
+	u.Uint32, u.Valid, u.Set = uint32(v), true, true
+	return nil
 }
 
 // Value implements the driver Valuer interface.
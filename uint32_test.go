@@ -31,6 +31,14 @@ func TestUint32FromPtr(t *testing.T) {
 	assertNullUint32(t, null, "Uint32FromPtr(nil)")
 }
 
+func TestUint32Unset(t *testing.T) {
+	v := Uint32Unset()
+	assertNullUint32(t, v, "Uint32Unset()")
+	if v.IsSet() {
+		t.Error("Uint32Unset() should not be Set")
+	}
+}
+
 func TestUnmarshalUint32(t *testing.T) {
 	var i Uint32
 	err := json.Unmarshal(uint32JSON, &i)
@@ -160,6 +168,55 @@ func TestUint32SetValid(t *testing.T) {
 	assertUint32(t, change, "SetValid()")
 }
 
+func TestUint32SetNull(t *testing.T) {
+	change := NewUint32(0, false, true)
+	change.SetValid(4294967294)
+	change.SetNull()
+	assertNullUint32(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.Uint32 != 0 {
+		t.Errorf("SetNull() should zero the value, got %v", change.Uint32)
+	}
+}
+
+func TestUint32ScanOverflow(t *testing.T) {
+	for _, test := range []struct {
+		in   interface{}
+		want uint32
+	}{
+		{int64(0), 0},
+		{"0", 0},
+		{int64(4294967295), uint32(math.MaxUint32)},
+		{"4294967295", uint32(math.MaxUint32)},
+		{[]byte("4294967295"), uint32(math.MaxUint32)},
+	} {
+		var v Uint32
+		err := v.Scan(test.in)
+		maybePanic(err)
+		if v.Uint32 != test.want || !v.Valid {
+			t.Errorf("Scan(%v): got %d, want %d", test.in, v.Uint32, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		in   interface{}
+		want string
+	}{
+		{int64(-1), "null: value -1 overflows uint32"},
+		{"-1", "null: value -1 overflows uint32"},
+		{int64(4294967296), "null: value 4294967296 overflows uint32"},
+		{"4294967296", "null: value 4294967296 overflows uint32"},
+	} {
+		var v Uint32
+		if err := v.Scan(test.in); errString(err) != test.want {
+			t.Errorf("Scan(%v): got error %v, want %q", test.in, err, test.want)
+		}
+		assertNullUint32(t, v, "scanned overflowing value")
+	}
+}
+
 func TestUint32Scan(t *testing.T) {
 	var i Uint32
 	err := i.Scan(4294967294)
@@ -172,6 +229,31 @@ func TestUint32Scan(t *testing.T) {
 	assertNullUint32(t, null, "scanned null")
 }
 
+func TestUint32Or(t *testing.T) {
+	a := NewUint32(1, true, true)
+	b := NewUint32(2, true, true)
+	null := NewUint32(0, false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestUint32Get(t *testing.T) {
+	if v, ok := Uint32From(12345).Get(); !ok || v != uint32(12345) {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null Uint32
+	if v, ok := null.Get(); ok || v != 0 {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
 func assertUint32(t *testing.T, i Uint32, from string) {
 	if i.Uint32 != 4294967294 {
 		t.Errorf("bad %s uint32: %d ≠ %d\n", from, i.Uint32, 4294967294)
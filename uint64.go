@@ -6,7 +6,7 @@ import (
 	"encoding/json"
 	"strconv"
 
-	"github.com/volatiletech/null/v8/convert"
+	"github.com/volatiletech/null/v9/convert"
 )
 
 // Uint64 is an nullable uint64.
@@ -38,6 +38,13 @@ func Uint64FromPtr(i *uint64) Uint64 {
 	return NewUint64(*i, true)
 }
 
+// Uint64Unset creates a new Uint64 that is null and not Set, as for a field
+// absent from the input. IsSet reports false until a value or an explicit
+// null is unmarshaled into it, or it is changed with SetValid or SetNull.
+func Uint64Unset() Uint64 {
+	return Uint64{}
+}
+
 func (u Uint64) IsSet() bool {
 	return u.Set
 }
@@ -98,6 +105,14 @@ func (u *Uint64) SetValid(n uint64) {
 	u.Set = true
 }
 
+// SetNull changes this Uint64's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (u *Uint64) SetNull() {
+	u.Uint64 = 0
+	u.Valid = false
+	u.Set = true
+}
+
 // Ptr returns a pointer to this Uint64's value, or a nil pointer if this Uint64 is null.
 func (u Uint64) Ptr() *uint64 {
 	if !u.Valid {
@@ -111,20 +126,46 @@ func (u Uint64) IsZero() bool {
 	return !u.Valid
 }
 
+// Or returns this Uint64 if it is valid, or other if it is not.
+func (u Uint64) Or(other Uint64) Uint64 {
+	if u.Valid {
+		return u
+	}
+	return other
+}
+
+// Get returns this Uint64's value and true, or the zero value and false if it is null.
+func (u Uint64) Get() (uint64, bool) {
+	if !u.Valid {
+		return 0, false
+	}
+	return u.Uint64, true
+}
+
 // Scan implements the Scanner interface.
 func (u *Uint64) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
 	if value == nil {
 		u.Uint64, u.Valid, u.Set = 0, false, false
 		return nil
 	}
-	u.Valid, u.Set = true, true
-
-	// If value is negative int64, convert it to uint64
+	// A negative int64 is taken as the bit pattern of an unsigned BIGINT,
+	// which drivers that only produce int64 return for values above
+	// math.MaxInt64. Anything else goes through the same checks as the
+	// smaller unsigned types.
 	if i, ok := value.(int64); ok && i < 0 {
-		return convert.ConvertAssign(&u.Uint64, uint64(i))
+		u.Uint64, u.Valid, u.Set = uint64(i), true, true
+		return nil
 	}
-
-	return convert.ConvertAssign(&u.Uint64, value)
+	v, err := scanUint(value, 64, "uint64")
+	if err != nil {
+		return err
+	}
+	u.Uint64, u.Valid, u.Set = v, true, true
+	return nil
 }
 
 // Value implements the driver Valuer interface.
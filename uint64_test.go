@@ -2,6 +2,7 @@ package null
 
 import (
 	"encoding/json"
+	"math"
 	"testing"
 )
 
@@ -29,6 +30,14 @@ func TestUint64FromPtr(t *testing.T) {
 	assertNullUint64(t, null, "Uint64FromPtr(nil)")
 }
 
+func TestUint64Unset(t *testing.T) {
+	v := Uint64Unset()
+	assertNullUint64(t, v, "Uint64Unset()")
+	if v.IsSet() {
+		t.Error("Uint64Unset() should not be Set")
+	}
+}
+
 func TestUnmarshalUint64(t *testing.T) {
 	var i Uint64
 	err := json.Unmarshal(uint64JSON, &i)
@@ -142,6 +151,19 @@ func TestUint64SetValid(t *testing.T) {
 	assertUint64(t, change, "SetValid()")
 }
 
+func TestUint64SetNull(t *testing.T) {
+	change := NewUint64(0, false)
+	change.SetValid(18446744073709551614)
+	change.SetNull()
+	assertNullUint64(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.Uint64 != 0 {
+		t.Errorf("SetNull() should zero the value, got %v", change.Uint64)
+	}
+}
+
 func TestUint64Scan(t *testing.T) {
 	var i Uint64
 	err := i.Scan(uint64(18446744073709551614))
@@ -157,6 +179,76 @@ func TestUint64Scan(t *testing.T) {
 	assertNullUint64(t, i, "scanned null")
 }
 
+func TestUint64Or(t *testing.T) {
+	a := NewUint64(1, true)
+	b := NewUint64(2, true)
+	null := NewUint64(0, false)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestUint64Get(t *testing.T) {
+	if v, ok := Uint64From(12345).Get(); !ok || v != uint64(12345) {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null Uint64
+	if v, ok := null.Get(); ok || v != 0 {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
+func TestUint64ScanOverflow(t *testing.T) {
+	for _, test := range []struct {
+		in   interface{}
+		want uint64
+	}{
+		{int64(0), 0},
+		{"0", 0},
+		{int64(math.MaxInt64), math.MaxInt64},
+		{uint64(math.MaxUint64), math.MaxUint64},
+		{int64(-1), math.MaxUint64},
+		{int64(math.MinInt64), 1 << 63},
+		{"18446744073709551615", math.MaxUint64},
+		{[]byte("18446744073709551615"), math.MaxUint64},
+	} {
+		var v Uint64
+		err := v.Scan(test.in)
+		maybePanic(err)
+		if v.Uint64 != test.want || !v.Valid {
+			t.Errorf("Scan(%v): got %d, want %d", test.in, v.Uint64, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		in   interface{}
+		want string
+	}{
+		{"-1", "null: value -1 overflows uint64"},
+		{[]byte("-1"), "null: value -1 overflows uint64"},
+		{"18446744073709551616", "null: value 18446744073709551616 overflows uint64"},
+		{[]byte("18446744073709551616"), "null: value 18446744073709551616 overflows uint64"},
+	} {
+		var v Uint64
+		if err := v.Scan(test.in); errString(err) != test.want {
+			t.Errorf("Scan(%v): got error %v, want %q", test.in, err, test.want)
+		}
+		assertNullUint64(t, v, "scanned overflowing value")
+	}
+
+	var bad Uint64
+	if err := bad.Scan("twelve"); err == nil {
+		t.Error("expected error")
+	}
+	assertNullUint64(t, bad, "scanned bad string")
+}
+
 func assertUint64(t *testing.T, i Uint64, from string) {
 	if i.Uint64 != 18446744073709551614 {
 		t.Errorf("bad %s uint64: %d ≠ %d\n", from, i.Uint64, uint64(18446744073709551614))
@@ -40,6 +40,13 @@ func Uint8FromPtr(i *uint8) Uint8 {
 	return NewUint8(*i, true, true)
 }
 
+// Uint8Unset creates a new Uint8 that is null and not Set, as for a field
+// absent from the input. IsSet reports false until a value or an explicit
+// null is unmarshaled into it, or it is changed with SetValid or SetNull.
+func Uint8Unset() Uint8 {
+	return Uint8{}
+}
+
 func (u Uint8) IsSet() bool {
 	return u.Set
 }
@@ -106,6 +113,14 @@ func (u *Uint8) SetValid(n uint8) {
 	u.Set = true
 }
 
+// SetNull changes this Uint8's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (u *Uint8) SetNull() {
+	u.Uint8 = 0
+	u.Valid = false
+	u.Set = true
+}
+
 // Ptr returns a pointer to this Uint8's value, or a nil pointer if this Uint8 is null.
 func (u Uint8) Ptr() *uint8 {
 	if !u.Valid {
@@ -119,14 +134,38 @@ func (u Uint8) IsZero() bool {
 	return !u.Valid
 }
 
+// Or returns this Uint8 if it is valid, or other if it is not.
+func (u Uint8) Or(other Uint8) Uint8 {
+	if u.Valid {
+		return u
+	}
+	return other
+}
+
+// Get returns this Uint8's value and true, or the zero value and false if it is null.
+func (u Uint8) Get() (uint8, bool) {
+	if !u.Valid {
+		return 0, false
+	}
+	return u.Uint8, true
+}
+
 // Scan implements the Scanner interface.
 func (u *Uint8) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
 	if value == nil {
 		u.Uint8, u.Valid, u.Set = 0, false, false
 		return nil
 	}
-	u.Valid, u.Set = true, true
-	return convert.ConvertAssign(&u.Uint8, value)
+	v, err := scanUint(value, 8, "uint8")
+	if err != nil {
+		return err
+	}
+	u.Uint8, u.Valid, u.Set = uint8(v), true, true
+	return nil
 }
 
 // Value implements the driver Valuer interface.
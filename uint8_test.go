@@ -31,6 +31,14 @@ func TestUint8FromPtr(t *testing.T) {
 	assertNullUint8(t, null, "Uint8FromPtr(nil)")
 }
 
+func TestUint8Unset(t *testing.T) {
+	v := Uint8Unset()
+	assertNullUint8(t, v, "Uint8Unset()")
+	if v.IsSet() {
+		t.Error("Uint8Unset() should not be Set")
+	}
+}
+
 func TestUnmarshalUint8(t *testing.T) {
 	var i Uint8
 	err := json.Unmarshal(uint8JSON, &i)
@@ -160,6 +168,55 @@ func TestUint8SetValid(t *testing.T) {
 	assertUint8(t, change, "SetValid()")
 }
 
+func TestUint8SetNull(t *testing.T) {
+	change := NewUint8(0, false, true)
+	change.SetValid(254)
+	change.SetNull()
+	assertNullUint8(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.Uint8 != 0 {
+		t.Errorf("SetNull() should zero the value, got %v", change.Uint8)
+	}
+}
+
+func TestUint8ScanOverflow(t *testing.T) {
+	for _, test := range []struct {
+		in   interface{}
+		want uint8
+	}{
+		{int64(0), 0},
+		{"0", 0},
+		{int64(255), uint8(math.MaxUint8)},
+		{"255", uint8(math.MaxUint8)},
+		{[]byte("255"), uint8(math.MaxUint8)},
+	} {
+		var v Uint8
+		err := v.Scan(test.in)
+		maybePanic(err)
+		if v.Uint8 != test.want || !v.Valid {
+			t.Errorf("Scan(%v): got %d, want %d", test.in, v.Uint8, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		in   interface{}
+		want string
+	}{
+		{int64(-1), "null: value -1 overflows uint8"},
+		{"-1", "null: value -1 overflows uint8"},
+		{int64(256), "null: value 256 overflows uint8"},
+		{"256", "null: value 256 overflows uint8"},
+	} {
+		var v Uint8
+		if err := v.Scan(test.in); errString(err) != test.want {
+			t.Errorf("Scan(%v): got error %v, want %q", test.in, err, test.want)
+		}
+		assertNullUint8(t, v, "scanned overflowing value")
+	}
+}
+
 func TestUint8Scan(t *testing.T) {
 	var i Uint8
 	err := i.Scan(254)
@@ -172,6 +229,31 @@ func TestUint8Scan(t *testing.T) {
 	assertNullUint8(t, null, "scanned null")
 }
 
+func TestUint8Or(t *testing.T) {
+	a := NewUint8(1, true, true)
+	b := NewUint8(2, true, true)
+	null := NewUint8(0, false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestUint8Get(t *testing.T) {
+	if v, ok := Uint8From(123).Get(); !ok || v != uint8(123) {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null Uint8
+	if v, ok := null.Get(); ok || v != 0 {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
 func assertUint8(t *testing.T, i Uint8, from string) {
 	if i.Uint8 != 254 {
 		t.Errorf("bad %s uint8: %d ≠ %d\n", from, i.Uint8, 254)
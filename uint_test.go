@@ -2,6 +2,7 @@ package null
 
 import (
 	"encoding/json"
+	"math"
 	"testing"
 )
 
@@ -29,6 +30,14 @@ func TestUintFromPtr(t *testing.T) {
 	assertNullUint(t, null, "UintFromPtr(nil)")
 }
 
+func TestUintUnset(t *testing.T) {
+	v := UintUnset()
+	assertNullUint(t, v, "UintUnset()")
+	if v.IsSet() {
+		t.Error("UintUnset() should not be Set")
+	}
+}
+
 func TestUnmarshalUint(t *testing.T) {
 	var i Uint
 	err := json.Unmarshal(uintJSON, &i)
@@ -142,6 +151,53 @@ func TestUintSetValid(t *testing.T) {
 	assertUint(t, change, "SetValid()")
 }
 
+func TestUintSetNull(t *testing.T) {
+	change := NewUint(0, false, true)
+	change.SetValid(12345)
+	change.SetNull()
+	assertNullUint(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.Uint != 0 {
+		t.Errorf("SetNull() should zero the value, got %v", change.Uint)
+	}
+}
+
+func TestUintScanOverflow(t *testing.T) {
+	for _, test := range []struct {
+		in   interface{}
+		want uint
+	}{
+		{int64(0), 0},
+		{"0", 0},
+		{"18446744073709551615", uint(math.MaxUint)},
+		{[]byte("18446744073709551615"), uint(math.MaxUint)},
+	} {
+		var v Uint
+		err := v.Scan(test.in)
+		maybePanic(err)
+		if v.Uint != test.want || !v.Valid {
+			t.Errorf("Scan(%v): got %d, want %d", test.in, v.Uint, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		in   interface{}
+		want string
+	}{
+		{int64(-1), "null: value -1 overflows uint"},
+		{"-1", "null: value -1 overflows uint"},
+		{"18446744073709551616", "null: value 18446744073709551616 overflows uint"},
+	} {
+		var v Uint
+		if err := v.Scan(test.in); errString(err) != test.want {
+			t.Errorf("Scan(%v): got error %v, want %q", test.in, err, test.want)
+		}
+		assertNullUint(t, v, "scanned overflowing value")
+	}
+}
+
 func TestUintScan(t *testing.T) {
 	var i Uint
 	err := i.Scan(12345)
@@ -154,6 +210,31 @@ func TestUintScan(t *testing.T) {
 	assertNullUint(t, null, "scanned null")
 }
 
+func TestUintOr(t *testing.T) {
+	a := NewUint(1, true, true)
+	b := NewUint(2, true, true)
+	null := NewUint(0, false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestUintGet(t *testing.T) {
+	if v, ok := UintFrom(12345).Get(); !ok || v != uint(12345) {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null Uint
+	if v, ok := null.Get(); ok || v != 0 {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
 func assertUint(t *testing.T, i Uint, from string) {
 	if i.Uint != 12345 {
 		t.Errorf("bad %s uint: %d ≠ %d\n", from, i.Uint, 12345)
@@ -0,0 +1,161 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// UnixTime is a nullable time.Time that marshals to JSON and text as whole
+// Unix seconds instead of RFC 3339. It has the same fields as Time, so the
+// two convert freely with Time.Unix and ToTime, and SQL scanning and values
+// are those of Time. Anything finer than a second is dropped when
+// marshaling.
+type UnixTime Time
+
+// NewUnixTime creates a new UnixTime.
+func NewUnixTime(t time.Time, valid, set bool) UnixTime {
+	return UnixTime(NewTime(t, valid, set))
+}
+
+// UnixTimeFrom creates a new UnixTime that will always be valid.
+func UnixTimeFrom(t time.Time) UnixTime {
+	return NewUnixTime(t, true, true)
+}
+
+// UnixTimeFromPtr creates a new UnixTime that will be null if t is nil.
+func UnixTimeFromPtr(t *time.Time) UnixTime {
+	if t == nil {
+		return NewUnixTime(time.Time{}, false, true)
+	}
+	return UnixTimeFrom(*t)
+}
+
+// UnixTimeUnset creates a new UnixTime that is null and not Set, as for a
+// field absent from the input. IsSet reports false until a value or an
+// explicit null is unmarshaled into it, or it is changed with SetValid or
+// SetNull.
+func UnixTimeUnset() UnixTime {
+	return UnixTime{}
+}
+
+// Unix converts this Time to a UnixTime holding the same value.
+func (t Time) Unix() UnixTime {
+	return UnixTime(t)
+}
+
+// ToTime converts this UnixTime to a Time holding the same value.
+func (u UnixTime) ToTime() Time {
+	return Time(u)
+}
+
+func (u UnixTime) IsSet() bool {
+	return u.Set
+}
+
+// MarshalJSON implements json.Marshaler.
+// It encodes a valid UnixTime as a bare integer of Unix seconds.
+func (u UnixTime) MarshalJSON() ([]byte, error) {
+	if !u.Valid {
+		return NullBytes, nil
+	}
+	return strconv.AppendInt(nil, u.Time.Unix(), 10), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts null or an integer of Unix seconds, read as a time in UTC.
+// As for Time, a parsed time is passed to the validators added with
+// RegisterTimeValidator before Time and Valid are changed.
+func (u *UnixTime) UnmarshalJSON(data []byte) error {
+	u.Set = true
+	if bytes.Equal(data, NullBytes) {
+		u.Time, u.Valid = time.Time{}, false
+		return nil
+	}
+	return u.setUnix(data)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (u UnixTime) MarshalText() ([]byte, error) {
+	if !u.Valid {
+		return NullBytes, nil
+	}
+	return strconv.AppendInt(nil, u.Time.Unix(), 10), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// Empty text, or null as written by MarshalText, is read as a null UnixTime.
+func (u *UnixTime) UnmarshalText(text []byte) error {
+	u.Set = true
+	if len(text) == 0 || bytes.Equal(text, NullBytes) {
+		u.Time, u.Valid = time.Time{}, false
+		return nil
+	}
+	return u.setUnix(text)
+}
+
+func (u *UnixTime) setUnix(data []byte) error {
+	sec, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return fmt.Errorf("null: cannot unmarshal %q into null.UnixTime: want integer seconds", data)
+	}
+	v := time.Unix(sec, 0).UTC()
+	for _, fn := range timeValidators {
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+	u.Time, u.Valid = v, true
+	return nil
+}
+
+// SetValid changes this UnixTime's value and sets it to be non-null.
+func (u *UnixTime) SetValid(v time.Time) {
+	(*Time)(u).SetValid(v)
+}
+
+// SetNull changes this UnixTime's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (u *UnixTime) SetNull() {
+	(*Time)(u).SetNull()
+}
+
+// Ptr returns a pointer to this UnixTime's value, or a nil pointer if this UnixTime is null.
+func (u UnixTime) Ptr() *time.Time {
+	return Time(u).Ptr()
+}
+
+// IsZero returns true for an invalid UnixTime's value, for potential future omitempty support.
+func (u UnixTime) IsZero() bool {
+	return !u.Valid
+}
+
+// Or returns this UnixTime if it is valid, or other if it is not.
+func (u UnixTime) Or(other UnixTime) UnixTime {
+	if u.Valid {
+		return u
+	}
+	return other
+}
+
+// Get returns this UnixTime's value and true, or the zero value and false if it is null.
+func (u UnixTime) Get() (time.Time, bool) {
+	return Time(u).Get()
+}
+
+// Scan implements the Scanner interface, as Time.Scan does.
+func (u *UnixTime) Scan(value interface{}) error {
+	return (*Time)(u).Scan(value)
+}
+
+// Value implements the driver Valuer interface, as Time.Value does.
+func (u UnixTime) Value() (driver.Value, error) {
+	return Time(u).Value()
+}
+
+// Randomize for sqlboiler
+func (u *UnixTime) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	(*Time)(u).Randomize(nextInt, fieldType, shouldBeNull)
+}
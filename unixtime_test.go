@@ -0,0 +1,236 @@
+package null
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+var (
+	unixTimeJSON  = []byte(`1356124881`)
+	unixTimeValue = time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)
+)
+
+func TestUnmarshalUnixTimeJSON(t *testing.T) {
+	var u UnixTime
+	err := json.Unmarshal(unixTimeJSON, &u)
+	maybePanic(err)
+	assertUnixTime(t, u, "UnmarshalJSON() json")
+	if u.Time.Location() != time.UTC {
+		t.Errorf("UnmarshalJSON() should read times in UTC, got %v", u.Time.Location())
+	}
+
+	var null UnixTime
+	err = json.Unmarshal(nullTimeJSON, &null)
+	maybePanic(err)
+	assertNullUnixTime(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	for _, in := range []string{`"1356124881"`, `1356124881.5`, `1.356124881e9`, timeString, `{}`} {
+		var bad UnixTime
+		if err := json.Unmarshal([]byte(in), &bad); err == nil {
+			t.Errorf("expected error unmarshaling %s", in)
+		}
+		assertNullUnixTime(t, bad, "bad json")
+	}
+}
+
+func TestMarshalUnixTime(t *testing.T) {
+	u := UnixTimeFrom(unixTimeValue.Add(999 * time.Millisecond).In(time.FixedZone("UTC+2", 2*60*60)))
+	data, err := json.Marshal(u)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(unixTimeJSON), "non-empty json marshal")
+
+	var back UnixTime
+	err = json.Unmarshal(data, &back)
+	maybePanic(err)
+	assertUnixTime(t, back, "json round trip")
+
+	u.Valid = false
+	data, err = json.Marshal(u)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(nullJSON), "null json marshal")
+
+	var s struct {
+		At   UnixTime `json:"at"`
+		Gone UnixTime `json:"gone"`
+	}
+	s.At = UnixTimeFrom(time.Unix(-1, 0))
+	data, err = json.Marshal(s)
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"at":-1,"gone":null}`, "struct json marshal")
+}
+
+func TestUnixTimeText(t *testing.T) {
+	u := UnixTimeFrom(unixTimeValue)
+	txt, err := u.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, txt, string(unixTimeJSON), "marshal text")
+
+	var back UnixTime
+	err = back.UnmarshalText(txt)
+	maybePanic(err)
+	assertUnixTime(t, back, "unmarshal text")
+
+	var null UnixTime
+	txt, err = null.MarshalText()
+	maybePanic(err)
+	for _, in := range [][]byte{txt, {}} {
+		err = back.UnmarshalText(in)
+		maybePanic(err)
+		assertNullUnixTime(t, back, "null text")
+	}
+
+	if err := back.UnmarshalText([]byte("soon")); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestUnixTimeValidator(t *testing.T) {
+	defer func(saved []func(time.Time) error) { timeValidators = saved }(timeValidators)
+	errFuture := errors.New("in the future")
+	RegisterTimeValidator(func(v time.Time) error {
+		if v.After(unixTimeValue) {
+			return errFuture
+		}
+		return nil
+	})
+
+	var u UnixTime
+	if err := json.Unmarshal([]byte(`1356124882`), &u); err != errFuture {
+		t.Errorf("expected validator error, got %v", err)
+	}
+	assertNullUnixTime(t, u, "rejected json")
+	err := json.Unmarshal(unixTimeJSON, &u)
+	maybePanic(err)
+	assertUnixTime(t, u, "accepted json")
+}
+
+func TestUnixTimeConvert(t *testing.T) {
+	ti := TimeFrom(unixTimeValue)
+	u := ti.Unix()
+	assertUnixTime(t, u, "Time.Unix()")
+	if !u.ToTime().Equal(ti) {
+		t.Error("ToTime() should give back the Time")
+	}
+
+	null := NewTime(time.Time{}, false, true).Unix()
+	assertNullUnixTime(t, null, "Time.Unix() null")
+	if !null.Set {
+		t.Error("Time.Unix() should keep Set")
+	}
+	if back := UnixTimeUnset().ToTime(); back.Valid || back.Set {
+		t.Error("ToTime() of an unset UnixTime should be unset")
+	}
+}
+
+func TestUnixTimeFrom(t *testing.T) {
+	assertUnixTime(t, UnixTimeFrom(unixTimeValue), "UnixTimeFrom()")
+
+	u := UnixTimeFromPtr(&unixTimeValue)
+	assertUnixTime(t, u, "UnixTimeFromPtr()")
+
+	null := UnixTimeFromPtr(nil)
+	assertNullUnixTime(t, null, "UnixTimeFromPtr(nil)")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	unset := UnixTimeUnset()
+	assertNullUnixTime(t, unset, "UnixTimeUnset()")
+	if unset.IsSet() {
+		t.Error("UnixTimeUnset() should not be Set")
+	}
+}
+
+func TestUnixTimeScanValue(t *testing.T) {
+	for _, in := range []interface{}{unixTimeValue, int64(1356124881), timeString} {
+		var u UnixTime
+		err := u.Scan(in)
+		maybePanic(err)
+		assertUnixTime(t, u, "scanned time")
+		if v, err := u.Value(); v != unixTimeValue || err != nil {
+			t.Error("bad value or err:", v, err)
+		}
+	}
+
+	var null UnixTime
+	err := null.Scan(nil)
+	maybePanic(err)
+	assertNullUnixTime(t, null, "scanned null")
+	if null.Set {
+		t.Error("scanned null should not be Set")
+	}
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	var wrong UnixTime
+	if err := wrong.Scan(true); err == nil {
+		t.Error("expected error")
+	}
+	assertNullUnixTime(t, wrong, "scanned wrong")
+}
+
+func TestUnixTimeHelpers(t *testing.T) {
+	u := UnixTimeFrom(unixTimeValue)
+	if ptr := u.Ptr(); ptr == nil || !ptr.Equal(unixTimeValue) {
+		t.Errorf("bad Ptr() result: %v", ptr)
+	}
+	if v, ok := u.Get(); !ok || !v.Equal(unixTimeValue) {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+
+	null := NewUnixTime(unixTimeValue, false, true)
+	if null.Ptr() != nil || !null.IsZero() || u.IsZero() {
+		t.Error("bad Ptr() or IsZero() result for null")
+	}
+	if v, ok := null.Get(); ok || !v.IsZero() {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+	assertUnixTime(t, null.Or(u), "Or()")
+	assertNullUnixTime(t, null.Or(null), "Or() of nulls")
+
+	u.SetNull()
+	assertNullUnixTime(t, u, "SetNull()")
+	if !u.IsSet() {
+		t.Error("should be Set")
+	}
+	u.SetValid(unixTimeValue)
+	assertUnixTime(t, u, "SetValid()")
+}
+
+func TestUnixTimeRandomize(t *testing.T) {
+	var seed int64
+	nextInt := func() int64 {
+		seed++
+		return seed
+	}
+
+	var u UnixTime
+	u.Randomize(nextInt, "timestamp", false)
+	if !u.Valid || u.Time.IsZero() {
+		t.Errorf("Randomize() should produce a valid time: %v", u.Time)
+	}
+
+	u.Randomize(nextInt, "timestamp", true)
+	assertNullUnixTime(t, u, "Randomize() null")
+}
+
+func assertUnixTime(t *testing.T, u UnixTime, from string) {
+	if !u.Time.Equal(unixTimeValue) {
+		t.Errorf("bad %v time: %v ≠ %v\n", from, u.Time, unixTimeValue)
+	}
+	if !u.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullUnixTime(t *testing.T, u UnixTime, from string) {
+	if u.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
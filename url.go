@@ -0,0 +1,216 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/volatiletech/null/v9/convert"
+)
+
+// URL is a nullable url.URL. It supports SQL and JSON serialization.
+// Input is parsed with url.Parse and output is written with URL.String.
+type URL struct {
+	URL   url.URL
+	Valid bool
+	Set   bool
+}
+
+// NewURL creates a new URL
+func NewURL(u url.URL, valid, set bool) URL {
+	return URL{
+		URL:   u,
+		Valid: valid,
+		Set:   set,
+	}
+}
+
+// URLFrom creates a new URL by parsing s, returning an error if s is not a
+// valid URL. An empty s produces a null URL.
+func URLFrom(s string) (URL, error) {
+	var u URL
+	err := u.UnmarshalText([]byte(s))
+	return u, err
+}
+
+// URLFromPtr creates a new URL that will be null if u is nil.
+func URLFromPtr(u *url.URL) URL {
+	if u == nil {
+		return NewURL(url.URL{}, false, true)
+	}
+	return NewURL(*u, true, true)
+}
+
+// URLUnset creates a new URL that is null and not Set, as for a field absent
+// from the input. IsSet reports false until a value or an explicit null is
+// unmarshaled into it, or it is changed with SetValid or SetNull.
+func URLUnset() URL {
+	return URL{}
+}
+
+func (u URL) IsSet() bool {
+	return u.Set
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (u *URL) UnmarshalJSON(data []byte) error {
+	u.Set = true
+	if bytes.Equal(data, NullBytes) {
+		u.URL, u.Valid = url.URL{}, false
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (u *URL) UnmarshalText(text []byte) error {
+	u.Set = true
+	if len(text) == 0 {
+		u.URL, u.Valid = url.URL{}, false
+		return nil
+	}
+	v, err := parseURL(string(text))
+	if err != nil {
+		return err
+	}
+	u.URL, u.Valid = *v, true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (u URL) MarshalJSON() ([]byte, error) {
+	if !u.Valid {
+		return NullBytes, nil
+	}
+	return json.Marshal(u.URL.String())
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (u URL) MarshalText() ([]byte, error) {
+	if !u.Valid {
+		return []byte{}, nil
+	}
+	return []byte(u.URL.String()), nil
+}
+
+// String returns the text form of this URL, or an empty string if it is null.
+func (u URL) String() string {
+	if !u.Valid {
+		return ""
+	}
+	return u.URL.String()
+}
+
+// SetValid changes this URL's value and also sets it to be non-null.
+func (u *URL) SetValid(v url.URL) {
+	u.URL = v
+	u.Valid = true
+	u.Set = true
+}
+
+// SetNull changes this URL's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (u *URL) SetNull() {
+	u.URL = url.URL{}
+	u.Valid = false
+	u.Set = true
+}
+
+// Ptr returns a pointer to this URL's value, or a nil pointer if this URL is null.
+func (u URL) Ptr() *url.URL {
+	if !u.Valid {
+		return nil
+	}
+	return &u.URL
+}
+
+// IsZero returns true for invalid URLs, for potential future omitempty support.
+func (u URL) IsZero() bool {
+	return !u.Valid
+}
+
+// Or returns this URL if it is valid, or other if it is not.
+func (u URL) Or(other URL) URL {
+	if u.Valid {
+		return u
+	}
+	return other
+}
+
+// Get returns this URL's value and true, or the zero value and false if it is null.
+func (u URL) Get() (url.URL, bool) {
+	if !u.Valid {
+		return url.URL{}, false
+	}
+	return u.URL, true
+}
+
+// Scan implements the Scanner interface.
+// An empty string scans as a null URL, matching UnmarshalText.
+func (u *URL) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
+	var text string
+	switch x := value.(type) {
+	case string:
+		text = x
+	case []byte:
+		text = string(x)
+	case nil:
+		u.URL, u.Valid, u.Set = url.URL{}, false, false
+		return nil
+	default:
+		return fmt.Errorf("null: cannot scan type %T into null.URL: %v", value, value)
+	}
+
+	if text == "" {
+		u.URL, u.Valid, u.Set = url.URL{}, false, true
+		return nil
+	}
+	v, err := parseURL(text)
+	if err != nil {
+		return err
+	}
+	u.URL, u.Valid, u.Set = *v, true, true
+	return nil
+}
+
+// Value implements the driver Valuer interface.
+func (u URL) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.URL.String(), nil
+}
+
+// Randomize for sqlboiler
+func (u *URL) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		u.URL = url.URL{}
+		u.Valid = false
+	} else {
+		u.URL = url.URL{
+			Scheme: "https",
+			Host:   fmt.Sprintf("host%d.example.com", nextInt()%10000),
+			Path:   fmt.Sprintf("/%d", nextInt()),
+		}
+		u.Valid = true
+	}
+}
+
+func parseURL(s string) (*url.URL, error) {
+	v, err := url.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("null: invalid URL: %v", err)
+	}
+	return v, nil
+}
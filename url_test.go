@@ -0,0 +1,258 @@
+package null
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+)
+
+var (
+	urlString = "https://user@example.com:8443/hook?id=1#frag"
+	urlJSON   = []byte(`"https://user@example.com:8443/hook?id=1#frag"`)
+	urlValue  = url.URL{
+		Scheme:   "https",
+		User:     url.User("user"),
+		Host:     "example.com:8443",
+		Path:     "/hook",
+		RawQuery: "id=1",
+		Fragment: "frag",
+	}
+)
+
+func TestURLFrom(t *testing.T) {
+	u, err := URLFrom(urlString)
+	maybePanic(err)
+	assertURL(t, u, "URLFrom()")
+
+	blank, err := URLFrom("")
+	maybePanic(err)
+	assertNullURL(t, blank, "URLFrom() empty")
+
+	bad, err := URLFrom("http://[::1")
+	if err == nil {
+		t.Error("expected error for an invalid URL")
+	}
+	assertNullURL(t, bad, "URLFrom() bad")
+}
+
+func TestURLFromPtr(t *testing.T) {
+	v := urlValue
+	u := URLFromPtr(&v)
+	assertURL(t, u, "URLFromPtr()")
+
+	null := URLFromPtr(nil)
+	assertNullURL(t, null, "URLFromPtr(nil)")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+}
+
+func TestURLUnset(t *testing.T) {
+	v := URLUnset()
+	assertNullURL(t, v, "URLUnset()")
+	if v.IsSet() {
+		t.Error("URLUnset() should not be Set")
+	}
+}
+
+func TestUnmarshalURL(t *testing.T) {
+	var u URL
+	err := json.Unmarshal(urlJSON, &u)
+	maybePanic(err)
+	assertURL(t, u, "url json")
+
+	var null URL
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullURL(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var blank URL
+	err = json.Unmarshal(blankStringJSON, &blank)
+	maybePanic(err)
+	assertNullURL(t, blank, "blank string json")
+
+	var badType URL
+	err = json.Unmarshal(intJSON, &badType)
+	if err == nil {
+		panic("err should not be nil")
+	}
+	assertNullURL(t, badType, "wrong type json")
+
+	var badString URL
+	err = json.Unmarshal([]byte(`"%zz"`), &badString)
+	if err == nil {
+		panic("err should not be nil")
+	}
+	assertNullURL(t, badString, "bad string json")
+}
+
+func TestTextUnmarshalURL(t *testing.T) {
+	var u URL
+	err := u.UnmarshalText([]byte(urlString))
+	maybePanic(err)
+	assertURL(t, u, "UnmarshalText() url")
+
+	var blank URL
+	err = blank.UnmarshalText([]byte(""))
+	maybePanic(err)
+	assertNullURL(t, blank, "UnmarshalText() empty url")
+}
+
+func TestMarshalURL(t *testing.T) {
+	u := NewURL(urlValue, true, true)
+	data, err := json.Marshal(u)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(urlJSON), "non-empty json marshal")
+
+	data, err = u.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, urlString, "non-empty text marshal")
+
+	// invalid values should be encoded as null
+	null := NewURL(urlValue, false, true)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+}
+
+func TestURLPointer(t *testing.T) {
+	u := NewURL(urlValue, true, true)
+	ptr := u.Ptr()
+	if ptr.String() != urlString {
+		t.Errorf("bad %s url: %#v ≠ %s\n", "pointer", ptr, urlString)
+	}
+
+	null := NewURL(urlValue, false, true)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s url: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestURLIsZero(t *testing.T) {
+	u := NewURL(url.URL{}, true, true)
+	if u.IsZero() {
+		t.Errorf("IsZero() should be false")
+	}
+
+	null := NewURL(urlValue, false, true)
+	if !null.IsZero() {
+		t.Errorf("IsZero() should be true")
+	}
+}
+
+func TestURLSetValid(t *testing.T) {
+	change := NewURL(url.URL{}, false, true)
+	assertNullURL(t, change, "SetValid()")
+	change.SetValid(urlValue)
+	assertURL(t, change, "SetValid()")
+}
+
+func TestURLSetNull(t *testing.T) {
+	change := NewURL(url.URL{}, false, true)
+	change.SetValid(urlValue)
+	change.SetNull()
+	assertNullURL(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.URL != (url.URL{}) {
+		t.Errorf("SetNull() should zero the value, got %v", change.URL)
+	}
+}
+
+func TestURLScanValue(t *testing.T) {
+	for _, in := range []interface{}{urlString, []byte(urlString)} {
+		var u URL
+		err := u.Scan(in)
+		maybePanic(err)
+		assertURL(t, u, "scanned url")
+		if v, err := u.Value(); v != urlString || err != nil {
+			t.Error("bad value or err:", v, err)
+		}
+	}
+
+	var null URL
+	err := null.Scan(nil)
+	maybePanic(err)
+	assertNullURL(t, null, "scanned null")
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	var blank URL
+	err = blank.Scan("")
+	maybePanic(err)
+	assertNullURL(t, blank, "scanned empty string")
+
+	for _, in := range []interface{}{"%zz", int64(1)} {
+		var wrong URL
+		if err = wrong.Scan(in); err == nil {
+			t.Errorf("Scan(%v) should fail", in)
+		}
+		assertNullURL(t, wrong, "scanned wrong")
+	}
+}
+
+func TestURLRandomize(t *testing.T) {
+	var seed int64
+	nextInt := func() int64 {
+		seed++
+		return seed
+	}
+
+	var u URL
+	u.Randomize(nextInt, "text", false)
+	if !u.Valid || u.URL.Host == "" {
+		t.Errorf("Randomize() should produce a valid URL: %s", u)
+	}
+	if _, err := URLFrom(u.String()); err != nil {
+		t.Error(err)
+	}
+
+	u.Randomize(nextInt, "text", true)
+	assertNullURL(t, u, "Randomize() null")
+}
+
+func TestURLOr(t *testing.T) {
+	a := NewURL(urlValue, true, true)
+	b := NewURL(url.URL{Scheme: "https", Host: "example.org"}, true, true)
+	null := NewURL(url.URL{}, false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestURLGet(t *testing.T) {
+	if v, ok := NewURL(urlValue, true, true).Get(); !ok || v != urlValue {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null URL
+	if v, ok := null.Get(); ok || v != (url.URL{}) {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
+func assertURL(t *testing.T, u URL, from string) {
+	if u.URL.String() != urlString {
+		t.Errorf("bad %s url: %s ≠ %s\n", from, u.URL.String(), urlString)
+	}
+	if !u.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullURL(t *testing.T, u URL, from string) {
+	if u.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
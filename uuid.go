@@ -0,0 +1,233 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/volatiletech/null/v9/convert"
+)
+
+// UUID is a nullable RFC 4122 UUID. It supports SQL and JSON serialization.
+// It scans from the hyphenated text form or 16 raw bytes, and is written to
+// SQL and JSON in the canonical hyphenated form.
+type UUID struct {
+	UUID  [16]byte
+	Valid bool
+	Set   bool
+}
+
+// NewUUID creates a new UUID
+func NewUUID(u [16]byte, valid, set bool) UUID {
+	return UUID{
+		UUID:  u,
+		Valid: valid,
+		Set:   set,
+	}
+}
+
+// UUIDFrom creates a new valid UUID from its hyphenated text form,
+// returning an error if s is not a well-formed UUID.
+func UUIDFrom(s string) (UUID, error) {
+	u, err := parseUUID([]byte(s))
+	if err != nil {
+		return UUID{}, err
+	}
+	return NewUUID(u, true, true), nil
+}
+
+// UUIDFromPtr creates a new UUID that will be null if u is nil.
+func UUIDFromPtr(u *[16]byte) UUID {
+	if u == nil {
+		return NewUUID([16]byte{}, false, true)
+	}
+	return NewUUID(*u, true, true)
+}
+
+// UUIDUnset creates a new UUID that is null and not Set, as for a field
+// absent from the input. IsSet reports false until a value or an explicit
+// null is unmarshaled into it, or it is changed with SetValid or SetNull.
+func UUIDUnset() UUID {
+	return UUID{}
+}
+
+func (u UUID) IsSet() bool {
+	return u.Set
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (u *UUID) UnmarshalJSON(data []byte) error {
+	u.Set = true
+	if bytes.Equal(data, NullBytes) {
+		u.UUID, u.Valid = [16]byte{}, false
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (u *UUID) UnmarshalText(text []byte) error {
+	u.Set = true
+	if len(text) == 0 {
+		u.Valid = false
+		return nil
+	}
+	var err error
+	u.UUID, err = parseUUID(text)
+	u.Valid = err == nil
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+func (u UUID) MarshalJSON() ([]byte, error) {
+	if !u.Valid {
+		return NullBytes, nil
+	}
+	return []byte(`"` + u.String() + `"`), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (u UUID) MarshalText() ([]byte, error) {
+	if !u.Valid {
+		return []byte{}, nil
+	}
+	return []byte(u.String()), nil
+}
+
+// String returns the canonical hyphenated form of this UUID, or an empty string if it is null.
+func (u UUID) String() string {
+	if !u.Valid {
+		return ""
+	}
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], u.UUID[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u.UUID[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u.UUID[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u.UUID[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u.UUID[10:])
+	return string(buf[:])
+}
+
+// SetValid changes this UUID's value and also sets it to be non-null.
+func (u *UUID) SetValid(v [16]byte) {
+	u.UUID = v
+	u.Valid = true
+	u.Set = true
+}
+
+// SetNull changes this UUID's value to its zero value and sets it to be null.
+// It stays Set, as for an explicit null.
+func (u *UUID) SetNull() {
+	u.UUID = [16]byte{}
+	u.Valid = false
+	u.Set = true
+}
+
+// Ptr returns a pointer to this UUID's value, or a nil pointer if this UUID is null.
+func (u UUID) Ptr() *[16]byte {
+	if !u.Valid {
+		return nil
+	}
+	return &u.UUID
+}
+
+// IsZero returns true for invalid UUIDs, for potential future omitempty support.
+func (u UUID) IsZero() bool {
+	return !u.Valid
+}
+
+// Or returns this UUID if it is valid, or other if it is not.
+func (u UUID) Or(other UUID) UUID {
+	if u.Valid {
+		return u
+	}
+	return other
+}
+
+// Get returns this UUID's value and true, or the zero value and false if it is null.
+func (u UUID) Get() ([16]byte, bool) {
+	if !u.Valid {
+		return [16]byte{}, false
+	}
+	return u.UUID, true
+}
+
+// Scan implements the Scanner interface.
+func (u *UUID) Scan(value interface{}) error {
+	value, err := convert.ValuerValue(value)
+	if err != nil {
+		return err
+	}
+	switch x := value.(type) {
+	case string:
+		u.UUID, err = parseUUID([]byte(x))
+	case []byte:
+		if len(x) == 16 {
+			copy(u.UUID[:], x)
+		} else {
+			u.UUID, err = parseUUID(x)
+		}
+	case nil:
+		u.UUID, u.Valid, u.Set = [16]byte{}, false, false
+		return nil
+	default:
+		err = fmt.Errorf("null: cannot scan type %T into null.UUID: %v", value, value)
+	}
+	if err == nil {
+		u.Valid, u.Set = true, true
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+func (u UUID) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.String(), nil
+}
+
+// Randomize for sqlboiler
+func (u *UUID) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		u.UUID = [16]byte{}
+		u.Valid = false
+	} else {
+		for i := range u.UUID {
+			u.UUID[i] = byte(nextInt() % 256)
+		}
+		// Mark it as a version 4, RFC 4122 variant UUID.
+		u.UUID[6] = u.UUID[6]&0x0f | 0x40
+		u.UUID[8] = u.UUID[8]&0x3f | 0x80
+		u.Valid = true
+	}
+}
+
+func parseUUID(text []byte) ([16]byte, error) {
+	var u [16]byte
+	if len(text) != 36 || text[8] != '-' || text[13] != '-' || text[18] != '-' || text[23] != '-' {
+		return u, fmt.Errorf("null: invalid UUID %q: expected the form xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx", text)
+	}
+
+	dst := u[:]
+	for _, g := range [][2]int{{0, 8}, {9, 13}, {14, 18}, {19, 23}, {24, 36}} {
+		n, err := hex.Decode(dst, text[g[0]:g[1]])
+		if err != nil {
+			return [16]byte{}, fmt.Errorf("null: invalid UUID %q: %v", text, err)
+		}
+		dst = dst[n:]
+	}
+	return u, nil
+}
@@ -0,0 +1,240 @@
+package null
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var (
+	uuidString = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	uuidJSON   = []byte(`"` + uuidString + `"`)
+	uuidValue  = [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+)
+
+func TestUUIDFrom(t *testing.T) {
+	u, err := UUIDFrom(uuidString)
+	maybePanic(err)
+	assertUUID(t, u, "UUIDFrom()")
+
+	u, err = UUIDFrom(strings.ToUpper(uuidString))
+	maybePanic(err)
+	assertUUID(t, u, "UUIDFrom() upper case")
+
+	for _, bad := range []string{"", "6ba7b810-9dad-11d1-80b4", "6ba7b8109dad11d180b400c04fd430c8", "6ba7b810-9dad-11d1-80b4-00c04fd430cz", "6ba7b810+9dad-11d1-80b4-00c04fd430c8"} {
+		u, err = UUIDFrom(bad)
+		if err == nil {
+			t.Errorf("UUIDFrom(%q) should fail", bad)
+		}
+		assertNullUUID(t, u, "UUIDFrom() bad")
+	}
+}
+
+func TestUUIDFromPtr(t *testing.T) {
+	v := uuidValue
+	u := UUIDFromPtr(&v)
+	assertUUID(t, u, "UUIDFromPtr()")
+
+	null := UUIDFromPtr(nil)
+	assertNullUUID(t, null, "UUIDFromPtr(nil)")
+}
+
+func TestUUIDUnset(t *testing.T) {
+	v := UUIDUnset()
+	assertNullUUID(t, v, "UUIDUnset()")
+	if v.IsSet() {
+		t.Error("UUIDUnset() should not be Set")
+	}
+}
+
+func TestUnmarshalUUID(t *testing.T) {
+	var u UUID
+	err := json.Unmarshal(uuidJSON, &u)
+	maybePanic(err)
+	assertUUID(t, u, "uuid json")
+
+	var null UUID
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullUUID(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var badType UUID
+	err = json.Unmarshal(intJSON, &badType)
+	if err == nil {
+		panic("err should not be nil")
+	}
+	assertNullUUID(t, badType, "wrong type json")
+
+	var badString UUID
+	err = json.Unmarshal([]byte(`"not-a-uuid"`), &badString)
+	if err == nil {
+		panic("err should not be nil")
+	}
+	assertNullUUID(t, badString, "bad string json")
+}
+
+func TestTextUnmarshalUUID(t *testing.T) {
+	var u UUID
+	err := u.UnmarshalText([]byte(uuidString))
+	maybePanic(err)
+	assertUUID(t, u, "UnmarshalText() uuid")
+
+	var blank UUID
+	err = blank.UnmarshalText([]byte(""))
+	maybePanic(err)
+	assertNullUUID(t, blank, "UnmarshalText() empty uuid")
+}
+
+func TestMarshalUUID(t *testing.T) {
+	u := NewUUID(uuidValue, true, true)
+	data, err := json.Marshal(u)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(uuidJSON), "non-empty json marshal")
+
+	data, err = u.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, uuidString, "non-empty text marshal")
+
+	// invalid values should be encoded as null
+	null := NewUUID(uuidValue, false, true)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+}
+
+func TestUUIDPointer(t *testing.T) {
+	u := NewUUID(uuidValue, true, true)
+	ptr := u.Ptr()
+	if *ptr != uuidValue {
+		t.Errorf("bad %s uuid: %#v ≠ %v\n", "pointer", ptr, uuidValue)
+	}
+
+	null := NewUUID(uuidValue, false, true)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s uuid: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestUUIDIsZero(t *testing.T) {
+	u := NewUUID([16]byte{}, true, true)
+	if u.IsZero() {
+		t.Errorf("IsZero() should be false")
+	}
+
+	null := NewUUID(uuidValue, false, true)
+	if !null.IsZero() {
+		t.Errorf("IsZero() should be true")
+	}
+}
+
+func TestUUIDSetValid(t *testing.T) {
+	change := NewUUID([16]byte{}, false, true)
+	assertNullUUID(t, change, "SetValid()")
+	change.SetValid(uuidValue)
+	assertUUID(t, change, "SetValid()")
+}
+
+func TestUUIDSetNull(t *testing.T) {
+	change := NewUUID([16]byte{}, false, true)
+	change.SetValid(uuidValue)
+	change.SetNull()
+	assertNullUUID(t, change, "SetNull()")
+	if !change.IsSet() {
+		t.Error("should be Set")
+	}
+	if change.UUID != [16]byte{} {
+		t.Errorf("SetNull() should zero the value, got %v", change.UUID)
+	}
+}
+
+func TestUUIDScanValue(t *testing.T) {
+	for _, in := range []interface{}{uuidString, []byte(uuidString), uuidValue[:]} {
+		var u UUID
+		err := u.Scan(in)
+		maybePanic(err)
+		assertUUID(t, u, "scanned uuid")
+		if v, err := u.Value(); v != uuidString || err != nil {
+			t.Error("bad value or err:", v, err)
+		}
+	}
+
+	var null UUID
+	err := null.Scan(nil)
+	maybePanic(err)
+	assertNullUUID(t, null, "scanned null")
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	for _, in := range []interface{}{"not-a-uuid", []byte{1, 2, 3}, int64(1)} {
+		var wrong UUID
+		if err = wrong.Scan(in); err == nil {
+			t.Errorf("Scan(%v) should fail", in)
+		}
+		assertNullUUID(t, wrong, "scanned wrong")
+	}
+}
+
+func TestUUIDRandomize(t *testing.T) {
+	var seed int64
+	nextInt := func() int64 {
+		seed++
+		return seed
+	}
+
+	var u UUID
+	u.Randomize(nextInt, "uuid", false)
+	if !u.Valid || u.String()[14] != '4' {
+		t.Errorf("Randomize() should produce a valid version 4 UUID: %s", u)
+	}
+	if _, err := UUIDFrom(u.String()); err != nil {
+		t.Error(err)
+	}
+
+	u.Randomize(nextInt, "uuid", true)
+	assertNullUUID(t, u, "Randomize() null")
+}
+
+func TestUUIDOr(t *testing.T) {
+	a := NewUUID(uuidValue, true, true)
+	b := NewUUID([16]byte{1}, true, true)
+	null := NewUUID([16]byte{}, false, true)
+	if got := a.Or(b); !(got == a) {
+		t.Errorf("Or() should return a valid receiver, got %v", got)
+	}
+	if got := null.Or(b); !(got == b) {
+		t.Errorf("Or() should return other for a null receiver, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Error("Or() of two nulls should be null")
+	}
+}
+
+func TestUUIDGet(t *testing.T) {
+	if v, ok := NewUUID(uuidValue, true, true).Get(); !ok || v != uuidValue {
+		t.Errorf("bad Get() result: %v, %v", v, ok)
+	}
+	var null UUID
+	if v, ok := null.Get(); ok || v != [16]byte{} {
+		t.Errorf("bad null Get() result: %v, %v", v, ok)
+	}
+}
+
+func assertUUID(t *testing.T, u UUID, from string) {
+	if u.UUID != uuidValue {
+		t.Errorf("bad %s uuid: %v ≠ %v\n", from, u.UUID, uuidValue)
+	}
+	if !u.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullUUID(t *testing.T, u UUID, from string) {
+	if u.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
@@ -0,0 +1,680 @@
+package null
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/volatiletech/null/v9/convert"
+)
+
+// XMLNilMode controls how an invalid value is written as an XML element.
+type XMLNilMode int
+
+const (
+	// XMLNilAttr writes an empty element marked with xsi:nil="true".
+	XMLNilAttr XMLNilMode = iota
+	// XMLNilEmpty writes an empty element.
+	XMLNilEmpty
+	// XMLNilOmit leaves the element out.
+	XMLNilOmit
+)
+
+// XMLNil is the XMLNilMode used by MarshalXML. Invalid values in attributes
+// are always left out.
+var XMLNil = XMLNilAttr
+
+// xsiNamespace is the XML Schema instance namespace that xsi:nil belongs to.
+const xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// XML support writes the text form of valid values, as MarshalText does, in
+// both element and attribute contexts. Bytes are written in base64. When
+// unmarshaling, an element marked with xsi:nil="true", an empty element and
+// an empty attribute all yield an invalid, Set value.
+
+func marshalXMLText(enc *xml.Encoder, start xml.StartElement, m encoding.TextMarshaler, valid bool) error {
+	if !valid {
+		switch XMLNil {
+		case XMLNilOmit:
+			return nil
+		case XMLNilAttr:
+			// encoding/xml would invent its own prefix for a namespaced
+			// attribute, so spell out the conventional xsi one instead.
+			start.Attr = append(start.Attr,
+				xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: xsiNamespace},
+				xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"},
+			)
+		}
+		return enc.EncodeElement("", start)
+	}
+	text, err := m.MarshalText()
+	if err != nil {
+		return err
+	}
+	return enc.EncodeElement(string(text), start)
+}
+
+func unmarshalXMLText(dec *xml.Decoder, start xml.StartElement, u encoding.TextUnmarshaler) error {
+	if isXMLNil(start) {
+		if err := dec.Skip(); err != nil {
+			return err
+		}
+		return u.UnmarshalText(nil)
+	}
+	var s string
+	if err := dec.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	if s == "" {
+		return u.UnmarshalText(nil)
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+func marshalXMLAttrText(name xml.Name, m encoding.TextMarshaler, valid bool) (xml.Attr, error) {
+	if !valid {
+		return xml.Attr{}, nil
+	}
+	text, err := m.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+func unmarshalXMLAttrText(attr xml.Attr, u encoding.TextUnmarshaler) error {
+	if attr.Value == "" {
+		return u.UnmarshalText(nil)
+	}
+	return u.UnmarshalText([]byte(attr.Value))
+}
+
+// isXMLNil reports whether start carries xsi:nil="true". The decoder resolves
+// the xsi prefix to its namespace when it is declared, and leaves it as is
+// when it is not.
+func isXMLNil(start xml.StartElement) bool {
+	for _, a := range start.Attr {
+		if a.Name.Local == "nil" && (a.Name.Space == xsiNamespace || a.Name.Space == "xsi") {
+			return a.Value == "true" || a.Value == "1"
+		}
+	}
+	return false
+}
+
+// MarshalXML implements xml.Marshaler.
+// A valid Null's value is written with encoding/xml's own rules.
+func (n Null[T]) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	if !n.Valid {
+		return marshalXMLText(enc, start, nil, false)
+	}
+	return enc.EncodeElement(n.Val, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (n *Null[T]) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var zero T
+	n.Val, n.Valid, n.Set = zero, false, true
+	if isXMLNil(start) {
+		return dec.Skip()
+	}
+
+	// Read the element's content first, so that an empty element can be
+	// told apart from one that decodes to T's zero value.
+	var inner struct {
+		XML string `xml:",innerxml"`
+	}
+	if err := dec.DecodeElement(&inner, &start); err != nil {
+		return err
+	}
+	if strings.TrimSpace(inner.XML) == "" {
+		return nil
+	}
+	if err := xml.Unmarshal([]byte("<v>"+inner.XML+"</v>"), &n.Val); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The value is written with its MarshalText method if it has one, and with
+// fmt otherwise.
+func (n Null[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !n.Valid {
+		return xml.Attr{}, nil
+	}
+	if m, ok := interface{}(n.Val).(encoding.TextMarshaler); ok {
+		return marshalXMLAttrText(name, m, true)
+	}
+	return xml.Attr{Name: name, Value: fmt.Sprint(n.Val)}, nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// The text is converted to T with the same rules as Scan.
+func (n *Null[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	var zero T
+	n.Val, n.Valid, n.Set = zero, false, true
+	if attr.Value == "" {
+		return nil
+	}
+	if u, ok := interface{}(&n.Val).(encoding.TextUnmarshaler); ok {
+		if err := u.UnmarshalText([]byte(attr.Value)); err != nil {
+			return err
+		}
+	} else if err := convert.ConvertAssign(&n.Val, attr.Value); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+func (b Bool) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, b, b.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (b *Bool) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, b)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (b Bool) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, b, b.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (b *Bool) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, b)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (b Byte) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, b, b.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (b *Byte) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, b)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (b Byte) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, b, b.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (b *Byte) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, b)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (b Bytes) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, xmlBase64(b.Bytes), b.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (b *Bytes) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var x xmlBase64
+	err := unmarshalXMLText(dec, start, &x)
+	*b = NewBytes(x, x != nil, err == nil)
+	return err
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (b Bytes) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, xmlBase64(b.Bytes), b.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (b *Bytes) UnmarshalXMLAttr(attr xml.Attr) error {
+	var x xmlBase64
+	err := unmarshalXMLAttrText(attr, &x)
+	*b = NewBytes(x, x != nil, err == nil)
+	return err
+}
+
+// MarshalXML implements xml.Marshaler.
+func (d Date) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, d, d.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (d *Date) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, d)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (d Date) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, d, d.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (d *Date) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, d)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (d Decimal) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, d, d.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (d *Decimal) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, d)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (d Decimal) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, d, d.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (d *Decimal) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, d)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (d Duration) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, d, d.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (d *Duration) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, d)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (d Duration) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, d, d.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (d *Duration) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, d)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (f Float32) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, f, f.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (f *Float32) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, f)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (f Float32) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, f, f.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (f *Float32) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, f)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (f Float64) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, f, f.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (f *Float64) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, f)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (f Float64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, f, f.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (f *Float64) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, f)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (i Int) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, i, i.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (i *Int) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, i)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (i Int) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, i, i.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (i *Int) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, i)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (i Int8) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, i, i.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (i *Int8) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, i)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (i Int8) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, i, i.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (i *Int8) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, i)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (i Int16) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, i, i.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (i *Int16) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, i)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (i Int16) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, i, i.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (i *Int16) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, i)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (i Int32) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, i, i.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (i *Int32) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, i)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (i Int32) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, i, i.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (i *Int32) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, i)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (i Int64) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, i, i.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (i *Int64) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, i)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (i Int64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, i, i.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (i *Int64) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, i)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (i IP) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, i, i.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (i *IP) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, i)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (i IP) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, i, i.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (i *IP) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, i)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (j JSON) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, j, j.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (j *JSON) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, j)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (j JSON) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, j, j.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (j *JSON) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, j)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (s String) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, s, s.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (s *String) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, s)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (s String) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, s, s.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (s *String) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, s)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (t Time) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, t, t.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (t *Time) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, t)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (t Time) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, t, t.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (t *Time) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, t)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (u Uint) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, u, u.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (u *Uint) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, u)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (u Uint) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, u, u.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (u *Uint) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, u)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (u Uint8) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, u, u.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (u *Uint8) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, u)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (u Uint8) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, u, u.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (u *Uint8) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, u)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (u Uint16) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, u, u.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (u *Uint16) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, u)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (u Uint16) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, u, u.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (u *Uint16) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, u)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (u Uint32) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, u, u.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (u *Uint32) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, u)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (u Uint32) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, u, u.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (u *Uint32) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, u)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (u Uint64) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, u, u.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (u *Uint64) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, u)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (u Uint64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, u, u.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (u *Uint64) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, u)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (u URL) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, u, u.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (u *URL) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, u)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (u URL) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, u, u.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (u *URL) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, u)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (u UUID) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(enc, start, u, u.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (u *UUID) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLText(dec, start, u)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (u UUID) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, u, u.Valid)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (u *UUID) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttrText(attr, u)
+}
+
+// xmlBase64 is the text form of Bytes in XML, following xsd:base64Binary.
+type xmlBase64 []byte
+
+func (x xmlBase64) MarshalText() ([]byte, error) {
+	out := make([]byte, base64.StdEncoding.EncodedLen(len(x)))
+	base64.StdEncoding.Encode(out, x)
+	return out, nil
+}
+
+func (x *xmlBase64) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*x = nil
+		return nil
+	}
+	out := make([]byte, base64.StdEncoding.DecodedLen(len(text)))
+	n, err := base64.StdEncoding.Decode(out, text)
+	if err != nil {
+		return err
+	}
+	*x = out[:n]
+	return nil
+}
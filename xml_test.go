@@ -0,0 +1,253 @@
+package null
+
+import (
+	"encoding/xml"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+)
+
+type xmlRecord struct {
+	XMLName  xml.Name  `xml:"record"`
+	Bool     Bool      `xml:"bool"`
+	Byte     Byte      `xml:"byte"`
+	Bytes    Bytes     `xml:"bytes"`
+	Date     Date      `xml:"date"`
+	Decimal  Decimal   `xml:"decimal"`
+	Duration Duration  `xml:"duration"`
+	Float32  Float32   `xml:"float32"`
+	Float64  Float64   `xml:"float64"`
+	Int      Int       `xml:"int"`
+	Int8     Int8      `xml:"int8"`
+	Int16    Int16     `xml:"int16"`
+	Int32    Int32     `xml:"int32"`
+	Int64    Int64     `xml:"int64"`
+	IP       IP        `xml:"ip"`
+	JSON     JSON      `xml:"json"`
+	String   String    `xml:"string"`
+	Time     Time      `xml:"time"`
+	Uint     Uint      `xml:"uint"`
+	Uint8    Uint8     `xml:"uint8"`
+	Uint16   Uint16    `xml:"uint16"`
+	Uint32   Uint32    `xml:"uint32"`
+	Uint64   Uint64    `xml:"uint64"`
+	URL      URL       `xml:"url"`
+	UUID     UUID      `xml:"uuid"`
+	Null     Null[int] `xml:"null"`
+}
+
+const xmlDoc = `<record>` +
+	`<bool>true</bool><byte>a</byte><bytes>aGVsbG8=</bytes><date>2012-12-21</date>` +
+	`<decimal>1234.5678</decimal><duration>1h30m0s</duration><float32>1.5</float32><float64>1.5</float64>` +
+	`<int>-1</int><int8>-8</int8><int16>-16</int16><int32>-32</int32><int64>-64</int64>` +
+	`<ip>192.0.2.1</ip><json>{&#34;a&#34;:1}</json><string>test</string><time>2012-12-21T21:21:21Z</time>` +
+	`<uint>1</uint><uint8>8</uint8><uint16>16</uint16><uint32>32</uint32><uint64>64</uint64>` +
+	`<url>https://user@example.com:8443/hook?id=1#frag</url><uuid>6ba7b810-9dad-11d1-80b4-00c04fd430c8</uuid>` +
+	`<null>12345</null>` +
+	`</record>`
+
+func xmlRecordValue() xmlRecord {
+	return xmlRecord{
+		Bool:     BoolFrom(true),
+		Byte:     ByteFrom('a'),
+		Bytes:    BytesFrom([]byte("hello")),
+		Date:     DateFrom(dateValue),
+		Decimal:  DecimalFrom(big.NewRat(12345678, 10000)),
+		Duration: DurationFrom(90 * time.Minute),
+		Float32:  Float32From(1.5),
+		Float64:  Float64From(1.5),
+		Int:      IntFrom(-1),
+		Int8:     Int8From(-8),
+		Int16:    Int16From(-16),
+		Int32:    Int32From(-32),
+		Int64:    Int64From(-64),
+		IP:       IPFrom(net.IPv4(192, 0, 2, 1)),
+		JSON:     JSONFrom([]byte(`{"a":1}`)),
+		String:   StringFrom("test"),
+		Time:     TimeFrom(timeValue),
+		Uint:     UintFrom(1),
+		Uint8:    Uint8From(8),
+		Uint16:   Uint16From(16),
+		Uint32:   Uint32From(32),
+		Uint64:   Uint64From(64),
+		URL:      NewURL(urlValue, true, true),
+		UUID:     NewUUID(uuidValue, true, true),
+		Null:     From(12345),
+	}
+}
+
+func TestMarshalXML(t *testing.T) {
+	data, err := xml.Marshal(xmlRecordValue())
+	maybePanic(err)
+	assertJSONEquals(t, data, xmlDoc, "xml marshal")
+}
+
+func TestUnmarshalXML(t *testing.T) {
+	var r xmlRecord
+	err := xml.Unmarshal([]byte(xmlDoc), &r)
+	maybePanic(err)
+
+	assertBool(t, r.Bool, "xml bool")
+	if !r.Byte.Valid || r.Byte.Byte != 'a' || string(r.Bytes.Bytes) != "hello" || !r.Date.Date.Equal(dateValue) {
+		t.Errorf("bad xml byte, bytes or date: %v %v %v", r.Byte, r.Bytes, r.Date)
+	}
+	assertDecimal(t, r.Decimal, "xml decimal")
+	if r.Duration.Duration != 90*time.Minute || r.Float32.Float32 != 1.5 || r.Float64.Float64 != 1.5 {
+		t.Errorf("bad xml duration or floats: %v %v %v", r.Duration, r.Float32, r.Float64)
+	}
+	if r.Int.Int != -1 || r.Int8.Int8 != -8 || r.Int16.Int16 != -16 || r.Int32.Int32 != -32 || r.Int64.Int64 != -64 {
+		t.Errorf("bad xml ints: %v %v %v %v %v", r.Int, r.Int8, r.Int16, r.Int32, r.Int64)
+	}
+	if r.Uint.Uint != 1 || r.Uint8.Uint8 != 8 || r.Uint16.Uint16 != 16 || r.Uint32.Uint32 != 32 || r.Uint64.Uint64 != 64 {
+		t.Errorf("bad xml uints: %v %v %v %v %v", r.Uint, r.Uint8, r.Uint16, r.Uint32, r.Uint64)
+	}
+	assertIP(t, r.IP, "xml ip")
+	assertJSONEquals(t, r.JSON.JSON, `{"a":1}`, "xml json")
+	assertStr(t, r.String, "xml string")
+	assertTime(t, r.Time, "xml time")
+	assertURL(t, r.URL, "xml url")
+	assertUUID(t, r.UUID, "xml uuid")
+	assertNull(t, r.Null, 12345, "xml generic")
+}
+
+func TestMarshalXMLNil(t *testing.T) {
+	v := struct {
+		XMLName xml.Name  `xml:"r"`
+		Int     Int       `xml:"int"`
+		Time    Time      `xml:"time"`
+		Null    Null[int] `xml:"null"`
+	}{}
+
+	data, err := xml.Marshal(v)
+	maybePanic(err)
+	nilAttr := ` xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"`
+	assertJSONEquals(t, data, `<r><int`+nilAttr+`></int><time`+nilAttr+`></time><null`+nilAttr+`></null></r>`, "xsi:nil marshal")
+
+	XMLNil = XMLNilEmpty
+	data, err = xml.Marshal(v)
+	maybePanic(err)
+	assertJSONEquals(t, data, `<r><int></int><time></time><null></null></r>`, "empty marshal")
+
+	XMLNil = XMLNilOmit
+	data, err = xml.Marshal(v)
+	XMLNil = XMLNilAttr
+	maybePanic(err)
+	assertJSONEquals(t, data, `<r></r>`, "omit marshal")
+}
+
+func TestUnmarshalXMLNil(t *testing.T) {
+	doc := `<record xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">` +
+		`<int xsi:nil="true"/><string></string><time xsi:nil="1"></time>` +
+		`<json/><uuid xsi:nil="true">ignored</uuid><null xsi:nil="true"/><bool xsi:nil="false">true</bool>` +
+		`</record>`
+	r := xmlRecordValue()
+	err := xml.Unmarshal([]byte(doc), &r)
+	maybePanic(err)
+
+	assertNullInt(t, r.Int, "xsi:nil int")
+	assertNullStr(t, r.String, "empty string")
+	assertNullTime(t, r.Time, "xsi:nil time")
+	assertNullJSON(t, r.JSON, "empty json")
+	assertNullUUID(t, r.UUID, "xsi:nil uuid")
+	assertNullNull(t, r.Null, "xsi:nil generic")
+	assertBool(t, r.Bool, "xsi:nil false")
+	for _, n := range []Nullable{r.Int, r.String, r.Time, r.JSON, r.UUID, r.Null} {
+		if !n.IsSet() {
+			t.Errorf("%T should be Set", n)
+		}
+	}
+
+	// Without a declaration the prefix is left unresolved, which is still recognised.
+	var undeclared struct {
+		Int Int `xml:"int"`
+	}
+	err = xml.Unmarshal([]byte(`<r><int xsi:nil="true"/></r>`), &undeclared)
+	maybePanic(err)
+	assertNullInt(t, undeclared.Int, "undeclared xsi:nil")
+}
+
+func TestXMLAttr(t *testing.T) {
+	type attrs struct {
+		XMLName xml.Name  `xml:"r"`
+		Int     Int       `xml:"int,attr"`
+		Time    Time      `xml:"time,attr"`
+		Bytes   Bytes     `xml:"bytes,attr"`
+		UUID    UUID      `xml:"uuid,attr"`
+		Null    Null[int] `xml:"null,attr"`
+	}
+	v := attrs{
+		Int:   IntFrom(12345),
+		Time:  TimeFrom(timeValue),
+		Bytes: BytesFrom([]byte("hello")),
+		UUID:  NewUUID(uuidValue, true, true),
+		Null:  From(7),
+	}
+	data, err := xml.Marshal(v)
+	maybePanic(err)
+	want := `<r int="12345" time="2012-12-21T21:21:21Z" bytes="aGVsbG8=" uuid="` + uuidString + `" null="7"></r>`
+	assertJSONEquals(t, data, want, "attr marshal")
+
+	var back attrs
+	err = xml.Unmarshal(data, &back)
+	maybePanic(err)
+	assertInt(t, back.Int, "attr int")
+	assertTime(t, back.Time, "attr time")
+	if string(back.Bytes.Bytes) != "hello" {
+		t.Errorf("bad attr bytes: %v", back.Bytes)
+	}
+	assertUUID(t, back.UUID, "attr uuid")
+	assertNull(t, back.Null, 7, "attr generic")
+
+	// invalid values leave the attribute out
+	data, err = xml.Marshal(attrs{})
+	maybePanic(err)
+	assertJSONEquals(t, data, `<r></r>`, "null attr marshal")
+
+	var empty attrs
+	err = xml.Unmarshal([]byte(`<r int="" time="" null=""></r>`), &empty)
+	maybePanic(err)
+	assertNullInt(t, empty.Int, "empty attr int")
+	assertNullTime(t, empty.Time, "empty attr time")
+	assertNullNull(t, empty.Null, "empty attr generic")
+	if !empty.Int.Set || !empty.Null.Set {
+		t.Error("empty attributes should be Set")
+	}
+}
+
+func TestUnmarshalXMLErrors(t *testing.T) {
+	for _, doc := range []string{
+		`<record><int>abc</int></record>`,
+		`<record><int8>1000</int8></record>`,
+		`<record><bytes>!!!</bytes></record>`,
+		`<record><uuid>nope</uuid></record>`,
+		`<record><null>abc</null></record>`,
+	} {
+		var r xmlRecord
+		if err := xml.Unmarshal([]byte(doc), &r); err == nil {
+			t.Errorf("expected error unmarshaling %s", doc)
+		}
+	}
+
+	var attr struct {
+		Int Int `xml:"int,attr"`
+	}
+	if err := xml.Unmarshal([]byte(`<r int="abc"></r>`), &attr); err == nil {
+		t.Error("expected error unmarshaling a bad attribute")
+	}
+}
+
+func TestXMLGenericStruct(t *testing.T) {
+	type wrapper struct {
+		XMLName xml.Name    `xml:"w"`
+		P       Null[point] `xml:"p"`
+	}
+	data, err := xml.Marshal(wrapper{P: From(point{1, 2})})
+	maybePanic(err)
+	assertJSONEquals(t, data, `<w><p><X>1</X><Y>2</Y></p></w>`, "generic struct marshal")
+
+	var back wrapper
+	err = xml.Unmarshal(data, &back)
+	maybePanic(err)
+	assertNull(t, back.P, point{1, 2}, "generic struct unmarshal")
+}
@@ -0,0 +1,486 @@
+package null
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// YAML support uses the MarshalYAML() (interface{}, error) and
+// UnmarshalYAML(func(interface{}) error) error method signatures, which both
+// gopkg.in/yaml.v2 and gopkg.in/yaml.v3 understand, so this package does not
+// need to import either of them.
+//
+// An invalid value marshals to a YAML null. A key that is present unmarshals
+// to a valid, Set value. The YAML libraries do not call unmarshalers for a
+// null (~) node and leave the zero value instead, so both a missing key and
+// an explicit null yield a value that is invalid and not Set.
+
+// MarshalYAML implements yaml.Marshaler.
+func (n Null[T]) MarshalYAML() (interface{}, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Val, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (n *Null[T]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v *T
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		var zero T
+		n.Val, n.Valid, n.Set = zero, false, true
+		return nil
+	}
+	n.SetValid(*v)
+	return nil
+}
+
+// unmarshalYAMLText decodes a YAML scalar as a string and hands it to u,
+// for the types whose YAML form is the same as their text form.
+func unmarshalYAMLText(unmarshal func(interface{}) error, u encoding.TextUnmarshaler) error {
+	var s *string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	if s == nil {
+		return u.UnmarshalText(nil)
+	}
+	return u.UnmarshalText([]byte(*s))
+}
+
+// marshalYAMLText returns the text form of m as a string, or nil if
+// valid is false.
+func marshalYAMLText(m encoding.TextMarshaler, valid bool) (interface{}, error) {
+	if !valid {
+		return nil, nil
+	}
+	text, err := m.MarshalText()
+	return string(text), err
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (b Bool) MarshalYAML() (interface{}, error) {
+	return Null[bool]{Val: b.Bool, Valid: b.Valid}.MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (b *Bool) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var n Null[bool]
+	err := n.UnmarshalYAML(unmarshal)
+	*b = NewBool(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// A Byte is written as a one character string, as in JSON.
+func (b Byte) MarshalYAML() (interface{}, error) {
+	return marshalYAMLText(b, b.Valid)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (b *Byte) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, b)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// Bytes are written as a base64 string, as in JSON.
+func (b Bytes) MarshalYAML() (interface{}, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+	return base64.StdEncoding.EncodeToString(b.Bytes), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (b *Bytes) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s *string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	if s == nil {
+		b.Bytes, b.Valid, b.Set = nil, false, true
+		return nil
+	}
+	v, err := base64.StdEncoding.DecodeString(*s)
+	if err != nil {
+		return err
+	}
+	b.SetValid(v)
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (d Date) MarshalYAML() (interface{}, error) {
+	return marshalYAMLText(d, d.Valid)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (d *Date) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, d)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// A Decimal is written as a string so that no precision is lost.
+func (d Decimal) MarshalYAML() (interface{}, error) {
+	return marshalYAMLText(d, d.Valid)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// Both bare and quoted numbers are accepted.
+func (d *Decimal) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, d)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return marshalYAMLText(d, d.Valid)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, d)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (f Float32) MarshalYAML() (interface{}, error) {
+	return Null[float32]{Val: f.Float32, Valid: f.Valid}.MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (f *Float32) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var n Null[float32]
+	err := n.UnmarshalYAML(unmarshal)
+	*f = NewFloat32(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (f Float64) MarshalYAML() (interface{}, error) {
+	return Null[float64]{Val: f.Float64, Valid: f.Valid}.MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (f *Float64) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var n Null[float64]
+	err := n.UnmarshalYAML(unmarshal)
+	*f = NewFloat64(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (i Int) MarshalYAML() (interface{}, error) {
+	return Null[int]{Val: i.Int, Valid: i.Valid}.MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (i *Int) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var n Null[int]
+	err := n.UnmarshalYAML(unmarshal)
+	*i = NewInt(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (i Int8) MarshalYAML() (interface{}, error) {
+	return Null[int8]{Val: i.Int8, Valid: i.Valid}.MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (i *Int8) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var n Null[int8]
+	err := n.UnmarshalYAML(unmarshal)
+	*i = NewInt8(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (i Int16) MarshalYAML() (interface{}, error) {
+	return Null[int16]{Val: i.Int16, Valid: i.Valid}.MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (i *Int16) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var n Null[int16]
+	err := n.UnmarshalYAML(unmarshal)
+	*i = NewInt16(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (i Int32) MarshalYAML() (interface{}, error) {
+	return Null[int32]{Val: i.Int32, Valid: i.Valid}.MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (i *Int32) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var n Null[int32]
+	err := n.UnmarshalYAML(unmarshal)
+	*i = NewInt32(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (i Int64) MarshalYAML() (interface{}, error) {
+	return Null[int64]{Val: i.Int64, Valid: i.Valid}.MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (i *Int64) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var n Null[int64]
+	err := n.UnmarshalYAML(unmarshal)
+	*i = NewInt64(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (i IP) MarshalYAML() (interface{}, error) {
+	return marshalYAMLText(i, i.Valid)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (i *IP) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, i)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// The JSON document is written as the equivalent YAML structure.
+func (j JSON) MarshalYAML() (interface{}, error) {
+	if !j.Valid || len(j.JSON) == 0 {
+		return nil, nil
+	}
+	v, err := decodeJSON(j.JSON)
+	if err != nil {
+		return nil, err
+	}
+	return yamlFromJSONValue(v), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// Any YAML content is accepted and stored as its JSON representation.
+func (j *JSON) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		j.JSON, j.Valid, j.Set = nil, false, true
+		return nil
+	}
+	v, err := jsonFromYAMLValue(v)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	j.SetValid(data)
+	return nil
+}
+
+// yamlFromJSONValue replaces the json.Number values produced by decodeJSON
+// with native numbers, which the YAML encoders would otherwise quote. A
+// number that neither int64, uint64 nor float64 holds exactly is kept as
+// its literal string, so that no digits are lost.
+func yamlFromJSONValue(v interface{}) interface{} {
+	switch x := v.(type) {
+	case json.Number:
+		if i, err := x.Int64(); err == nil {
+			return i
+		}
+		if u, err := strconv.ParseUint(string(x), 10, 64); err == nil {
+			return u
+		}
+		if f, err := x.Float64(); err == nil && exactFloat(string(x), f) {
+			return f
+		}
+		return string(x)
+	case map[string]interface{}:
+		for k, e := range x {
+			x[k] = yamlFromJSONValue(e)
+		}
+	case []interface{}:
+		for i, e := range x {
+			x[i] = yamlFromJSONValue(e)
+		}
+	}
+	return v
+}
+
+// exactFloat reports whether f, parsed from the number literal s, formats
+// back to the same value, so writing f instead of s loses nothing.
+func exactFloat(s string, f float64) bool {
+	want, ok := new(big.Rat).SetString(s)
+	if !ok || math.IsInf(f, 0) {
+		return false
+	}
+	got, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	return ok && want.Cmp(got) == 0
+}
+
+// jsonFromYAMLValue converts the map[interface{}]interface{} values that
+// yaml.v2 produces into map[string]interface{}, which encoding/json requires.
+func jsonFromYAMLValue(v interface{}) (interface{}, error) {
+	var err error
+	switch x := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(x))
+		for k, e := range x {
+			ks, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("null: cannot convert YAML map key %v of type %T to JSON", k, k)
+			}
+			if m[ks], err = jsonFromYAMLValue(e); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case map[string]interface{}:
+		for k, e := range x {
+			if x[k], err = jsonFromYAMLValue(e); err != nil {
+				return nil, err
+			}
+		}
+	case []interface{}:
+		for i, e := range x {
+			if x[i], err = jsonFromYAMLValue(e); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return v, nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (s String) MarshalYAML() (interface{}, error) {
+	return Null[string]{Val: s.String, Valid: s.Valid}.MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// Unlike UnmarshalText, an empty string is a valid value.
+func (s *String) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var n Null[string]
+	err := n.UnmarshalYAML(unmarshal)
+	*s = NewString(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// A Time is written as a YAML timestamp, or as a string in its custom
+// layout if it has one.
+func (t Time) MarshalYAML() (interface{}, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	if t.layout != "" {
+		return t.Time.Format(t.layout), nil
+	}
+	return t.Time, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It accepts the timestamp forms the YAML library understands, and parses
+// strings with the Time's layout if it has one.
+func (t *Time) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if t.layout != "" {
+		return unmarshalYAMLText(unmarshal, t)
+	}
+	var n Null[time.Time]
+	err := n.UnmarshalYAML(unmarshal)
+	t.Time, t.Valid, t.Set = n.Val, n.Valid, err == nil
+	return err
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (u Uint) MarshalYAML() (interface{}, error) {
+	return Null[uint]{Val: u.Uint, Valid: u.Valid}.MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (u *Uint) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var n Null[uint]
+	err := n.UnmarshalYAML(unmarshal)
+	*u = NewUint(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (u Uint8) MarshalYAML() (interface{}, error) {
+	return Null[uint8]{Val: u.Uint8, Valid: u.Valid}.MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (u *Uint8) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var n Null[uint8]
+	err := n.UnmarshalYAML(unmarshal)
+	*u = NewUint8(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (u Uint16) MarshalYAML() (interface{}, error) {
+	return Null[uint16]{Val: u.Uint16, Valid: u.Valid}.MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (u *Uint16) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var n Null[uint16]
+	err := n.UnmarshalYAML(unmarshal)
+	*u = NewUint16(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (u Uint32) MarshalYAML() (interface{}, error) {
+	return Null[uint32]{Val: u.Uint32, Valid: u.Valid}.MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (u *Uint32) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var n Null[uint32]
+	err := n.UnmarshalYAML(unmarshal)
+	*u = NewUint32(n.Val, n.Valid, err == nil)
+	return err
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (u Uint64) MarshalYAML() (interface{}, error) {
+	return Null[uint64]{Val: u.Uint64, Valid: u.Valid}.MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (u *Uint64) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var n Null[uint64]
+	err := n.UnmarshalYAML(unmarshal)
+	u.Uint64, u.Valid, u.Set = n.Val, n.Valid, err == nil
+	return err
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (u URL) MarshalYAML() (interface{}, error) {
+	return marshalYAMLText(u, u.Valid)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (u *URL) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, u)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (u UUID) MarshalYAML() (interface{}, error) {
+	return marshalYAMLText(u, u.Valid)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (u *UUID) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, u)
+}
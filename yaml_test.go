@@ -0,0 +1,220 @@
+package null
+
+import (
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type yamlConfig struct {
+	Bool     Bool      `yaml:"bool"`
+	Byte     Byte      `yaml:"byte"`
+	Bytes    Bytes     `yaml:"bytes"`
+	Date     Date      `yaml:"date"`
+	Decimal  Decimal   `yaml:"decimal"`
+	Duration Duration  `yaml:"duration"`
+	Float32  Float32   `yaml:"float32"`
+	Float64  Float64   `yaml:"float64"`
+	Int      Int       `yaml:"int"`
+	Int8     Int8      `yaml:"int8"`
+	Int16    Int16     `yaml:"int16"`
+	Int32    Int32     `yaml:"int32"`
+	Int64    Int64     `yaml:"int64"`
+	IP       IP        `yaml:"ip"`
+	JSON     JSON      `yaml:"json"`
+	String   String    `yaml:"string"`
+	Time     Time      `yaml:"time"`
+	Uint     Uint      `yaml:"uint"`
+	Uint8    Uint8     `yaml:"uint8"`
+	Uint16   Uint16    `yaml:"uint16"`
+	Uint32   Uint32    `yaml:"uint32"`
+	Uint64   Uint64    `yaml:"uint64"`
+	URL      URL       `yaml:"url"`
+	UUID     UUID      `yaml:"uuid"`
+	Null     Null[int] `yaml:"null"`
+}
+
+const yamlDoc = `bool: true
+byte: a
+bytes: aGVsbG8=
+date: "2012-12-21"
+decimal: "1234.5678"
+duration: 1h30m0s
+float32: 1.5
+float64: 1.5
+int: -1
+int8: -8
+int16: -16
+int32: -32
+int64: -64
+ip: 192.0.2.1
+json:
+    a: 1
+    b:
+        - x
+        - true
+string: ""
+time: 2012-12-21T21:21:21Z
+uint: 1
+uint8: 8
+uint16: 16
+uint32: 32
+uint64: 64
+url: https://user@example.com:8443/hook?id=1#frag
+uuid: 6ba7b810-9dad-11d1-80b4-00c04fd430c8
+"null": 12345
+`
+
+func TestUnmarshalYAML(t *testing.T) {
+	var c yamlConfig
+	err := yaml.Unmarshal([]byte(yamlDoc), &c)
+	maybePanic(err)
+
+	assertBool(t, c.Bool, "yaml bool")
+	if !c.Byte.Valid || c.Byte.Byte != 'a' {
+		t.Errorf("bad yaml byte: %v", c.Byte)
+	}
+	if !c.Bytes.Valid || string(c.Bytes.Bytes) != "hello" {
+		t.Errorf("bad yaml bytes: %v", c.Bytes)
+	}
+	if !c.Date.Valid || !c.Date.Date.Equal(dateValue) {
+		t.Errorf("bad yaml date: %v", c.Date)
+	}
+	assertDecimal(t, c.Decimal, "yaml decimal")
+	if !c.Duration.Valid || c.Duration.Duration != 90*time.Minute {
+		t.Errorf("bad yaml duration: %v", c.Duration)
+	}
+	if !c.Float32.Valid || c.Float32.Float32 != 1.5 || !c.Float64.Valid || c.Float64.Float64 != 1.5 {
+		t.Errorf("bad yaml floats: %v %v", c.Float32, c.Float64)
+	}
+	if c.Int.Int != -1 || c.Int8.Int8 != -8 || c.Int16.Int16 != -16 || c.Int32.Int32 != -32 || c.Int64.Int64 != -64 {
+		t.Errorf("bad yaml ints: %v %v %v %v %v", c.Int, c.Int8, c.Int16, c.Int32, c.Int64)
+	}
+	if c.Uint.Uint != 1 || c.Uint8.Uint8 != 8 || c.Uint16.Uint16 != 16 || c.Uint32.Uint32 != 32 || c.Uint64.Uint64 != 64 {
+		t.Errorf("bad yaml uints: %v %v %v %v %v", c.Uint, c.Uint8, c.Uint16, c.Uint32, c.Uint64)
+	}
+	assertIP(t, c.IP, "yaml ip")
+	assertJSONEquals(t, c.JSON.JSON, `{"a":1,"b":["x",true]}`, "yaml json")
+	if !c.String.Valid || c.String.String != "" {
+		t.Errorf("empty yaml string should be valid: %v", c.String)
+	}
+	assertTime(t, c.Time, "yaml time")
+	assertURL(t, c.URL, "yaml url")
+	assertUUID(t, c.UUID, "yaml uuid")
+	assertNull(t, c.Null, 12345, "yaml generic")
+
+	for _, set := range []Nullable{c.Bool, c.Bytes, c.Int, c.JSON, c.String, c.Time, c.Uint64, c.UUID} {
+		if !set.IsSet() {
+			t.Errorf("%T should be Set", set)
+		}
+	}
+}
+
+func TestUnmarshalYAMLNull(t *testing.T) {
+	var c yamlConfig
+	err := yaml.Unmarshal([]byte("int: ~\nstring: null\ntime:\njson: null\n"), &c)
+	maybePanic(err)
+	assertNullInt(t, c.Int, "yaml ~ int")
+	assertNullStr(t, c.String, "yaml null string")
+	assertNullTime(t, c.Time, "yaml empty time")
+	assertNullJSON(t, c.JSON, "yaml null json")
+	assertNullUUID(t, c.UUID, "yaml missing uuid")
+}
+
+func TestUnmarshalYAMLErrors(t *testing.T) {
+	for _, doc := range []string{
+		"int8: 1000",
+		"int: abc",
+		"byte: ab",
+		"bytes: '!!!'",
+		"ip: not-an-ip",
+		"uuid: 1234",
+		"json: {1: x}",
+	} {
+		var c yamlConfig
+		if err := yaml.Unmarshal([]byte(doc), &c); err == nil {
+			t.Errorf("expected error unmarshaling %q", doc)
+		}
+	}
+}
+
+func TestMarshalYAML(t *testing.T) {
+	c := yamlConfig{
+		Bool:     BoolFrom(true),
+		Byte:     ByteFrom('a'),
+		Bytes:    BytesFrom([]byte("hello")),
+		Date:     DateFrom(dateValue),
+		Decimal:  DecimalFrom(big.NewRat(12345678, 10000)),
+		Duration: DurationFrom(90 * time.Minute),
+		Float32:  Float32From(1.5),
+		Float64:  Float64From(1.5),
+		Int:      IntFrom(-1),
+		Int8:     Int8From(-8),
+		Int16:    Int16From(-16),
+		Int32:    Int32From(-32),
+		Int64:    Int64From(-64),
+		IP:       IPFrom(net.IPv4(192, 0, 2, 1)),
+		JSON:     JSONFrom([]byte(`{"b":["x",true],"a":1}`)),
+		String:   StringFrom(""),
+		Time:     TimeFrom(timeValue),
+		Uint:     UintFrom(1),
+		Uint8:    Uint8From(8),
+		Uint16:   Uint16From(16),
+		Uint32:   Uint32From(32),
+		Uint64:   Uint64From(64),
+		URL:      NewURL(urlValue, true, true),
+		UUID:     NewUUID(uuidValue, true, true),
+		Null:     From(12345),
+	}
+	data, err := yaml.Marshal(c)
+	maybePanic(err)
+	assertJSONEquals(t, data, yamlDoc, "yaml marshal")
+
+	var round yamlConfig
+	err = yaml.Unmarshal(data, &round)
+	maybePanic(err)
+	data2, err := yaml.Marshal(round)
+	maybePanic(err)
+	assertJSONEquals(t, data2, yamlDoc, "yaml round trip")
+}
+
+func TestMarshalYAMLNull(t *testing.T) {
+	var c struct {
+		Int  Int  `yaml:"int"`
+		JSON JSON `yaml:"json"`
+		Time Time `yaml:"time"`
+	}
+	data, err := yaml.Marshal(c)
+	maybePanic(err)
+	assertJSONEquals(t, data, "int: null\njson: null\ntime: null\n", "yaml null marshal")
+}
+
+func TestTimeYAMLWithLayout(t *testing.T) {
+	ti := NewTimeWithLayout(timeValue, true, true, time.Kitchen)
+	data, err := yaml.Marshal(ti)
+	maybePanic(err)
+	assertJSONEquals(t, data, "9:21PM\n", "yaml layout marshal")
+
+	back := NewTimeWithLayout(time.Time{}, false, false, time.Kitchen)
+	err = yaml.Unmarshal(data, &back)
+	maybePanic(err)
+	if !back.Valid || back.Time.Format(time.Kitchen) != "9:21PM" {
+		t.Errorf("bad yaml layout unmarshal: %v", back)
+	}
+}
+
+func TestJSONYAMLNumbers(t *testing.T) {
+	j := JSONFrom([]byte(`{"big":12345678901234567890,"int":9007199254740993,"neg":-9223372036854775808,"float":0.5,"exp":1e3}`))
+	data, err := yaml.Marshal(j)
+	maybePanic(err)
+	assertJSONEquals(t, data, "big: 12345678901234567890\nexp: 1000\nfloat: 0.5\nint: 9007199254740993\nneg: -9223372036854775808\n", "yaml json numbers")
+
+	// Numbers no Go number holds exactly are written as their literals.
+	j = JSONFrom([]byte(`{"huge":123456789012345678901234567890,"pi":3.14159265358979323846,"tiny":1e-400}`))
+	data, err = yaml.Marshal(j)
+	maybePanic(err)
+	assertJSONEquals(t, data, "huge: \"123456789012345678901234567890\"\npi: \"3.14159265358979323846\"\ntiny: \"1e-400\"\n", "yaml inexact json numbers")
+}